@@ -0,0 +1,402 @@
+// Package backup keeps a catalogue of copies of keymap files taken before onekeymap-cli overwrites
+// them, and restores them.
+//
+// The catalogue lives in a directory with one sub-directory per editor. Each backup is a copy of
+// the file (<id>.bak) next to its metadata (<id>.json). Restores are appended to restores.jsonl.
+package backup
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xinnjie/onekeymap-cli/internal/safewrite"
+)
+
+// DefaultRetention is how many backups are kept per editor unless configured otherwise.
+const DefaultRetention = 10
+
+const restoresFile = "restores.jsonl"
+
+var (
+	// ErrNotFound is returned when no backup has the requested ID.
+	ErrNotFound = errors.New("backup not found")
+	// ErrInvalidID is returned for an ID that Create cannot have generated.
+	ErrInvalidID = errors.New("invalid backup ID")
+	// ErrInvalidEditor is returned for an editor name that cannot name a catalogue directory.
+	ErrInvalidEditor = errors.New("invalid editor name")
+)
+
+var (
+	// idPattern matches the IDs Create generates: <editor>-YYYYMMDD-HHMMSS[-n].
+	idPattern = regexp.MustCompile(`^(.+)-\d{8}-\d{6}(-\d+)?$`)
+	// editorPattern matches editor names such as "vscode" or "intellij.android-studio".
+	editorPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+)
+
+// Entry describes one backup.
+type Entry struct {
+	ID     string `json:"id"`
+	Editor string `json:"editor"`
+	// Path is the absolute path of the file that was backed up.
+	Path    string    `json:"path"`
+	Created time.Time `json:"created"`
+	// Reason is the command that made the backup, e.g. "export" or "restore".
+	Reason string `json:"reason"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Restore records that a backup was written back to its file.
+type Restore struct {
+	ID       string    `json:"id"`
+	Path     string    `json:"path"`
+	Restored time.Time `json:"restored"`
+	// Replaced is the ID of the backup of the content that was replaced; empty if the file did
+	// not exist.
+	Replaced string `json:"replaced,omitempty"`
+}
+
+// Options configure a Catalog.
+type Options struct {
+	// Retention is how many backups to keep per editor. Zero means DefaultRetention, and a negative
+	// value keeps all backups.
+	Retention int
+	// EditorRetention overrides Retention for some editors.
+	EditorRetention map[string]int
+}
+
+// Catalog is a directory of backups.
+type Catalog struct {
+	dir  string
+	opts Options
+	now  func() time.Time
+}
+
+// DefaultDir returns the catalogue directory under the user cache dir.
+func DefaultDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to get cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "onekeymap-cli", "backups"), nil
+}
+
+// New returns the catalogue in dir. The directory is created on the first backup.
+func New(dir string, opts Options) *Catalog {
+	return &Catalog{dir: dir, opts: opts, now: time.Now}
+}
+
+// Create backs up the file at path for editor and prunes the editor's backups beyond its retention
+// limit. It reports false, without error, when there is no regular file to back up.
+func (c *Catalog) Create(editor, path, reason string) (Entry, bool, error) {
+	entry, ok, err := c.create(editor, path, reason)
+	if err != nil || !ok {
+		return entry, ok, err
+	}
+	return entry, true, c.prune(editor, "")
+}
+
+// create backs up the file at path for editor without pruning.
+func (c *Catalog) create(editor, path, reason string) (Entry, bool, error) {
+	if err := ValidateEditor(editor); err != nil {
+		return Entry{}, false, err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return Entry{}, false, err
+	}
+	info, err := os.Stat(absPath)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && !info.Mode().IsRegular()) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	editorDir := filepath.Join(c.dir, editor)
+	if err := os.MkdirAll(editorDir, 0o750); err != nil {
+		return Entry{}, false, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	entry := Entry{
+		Editor:  editor,
+		Path:    absPath,
+		Created: c.now().UTC(),
+		Reason:  reason,
+		Size:    int64(len(data)),
+		SHA256:  hex.EncodeToString(sum[:]),
+	}
+	// IDs sort by creation time; the suffix keeps backups taken within the same second apart.
+	base := fmt.Sprintf("%s-%s", editor, entry.Created.Format("20060102-150405"))
+	for i := 0; ; i++ {
+		entry.ID = base
+		if i > 0 {
+			entry.ID = fmt.Sprintf("%s-%d", base, i)
+		}
+		f, err := os.OpenFile(filepath.Join(editorDir, entry.ID+".bak"), os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return Entry{}, false, err
+		}
+		_, err = f.Write(data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return Entry{}, false, err
+		}
+		break
+	}
+
+	meta, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return Entry{}, false, err
+	}
+	if err := os.WriteFile(filepath.Join(editorDir, entry.ID+".json"), meta, 0o600); err != nil {
+		return Entry{}, false, err
+	}
+	return entry, true, nil
+}
+
+// ValidateEditor checks that editor can name a catalogue directory.
+func ValidateEditor(editor string) error {
+	if !editorPattern.MatchString(editor) || strings.Contains(editor, "..") {
+		return fmt.Errorf("%w: %q", ErrInvalidEditor, editor)
+	}
+	return nil
+}
+
+// List returns the backups of editor, or of every editor when editor is empty, newest first.
+func (c *Catalog) List(editor string) ([]Entry, error) {
+	editors := []string{editor}
+	if editor == "" {
+		var err error
+		if editors, err = c.editors(); err != nil {
+			return nil, err
+		}
+	} else if err := ValidateEditor(editor); err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, e := range editors {
+		files, err := os.ReadDir(filepath.Join(c.dir, e))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+				continue
+			}
+			entry, err := readEntry(filepath.Join(c.dir, e, file.Name()))
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Created.Equal(entries[j].Created) {
+			return entries[i].Created.After(entries[j].Created)
+		}
+		return entries[i].ID > entries[j].ID
+	})
+	return entries, nil
+}
+
+// Get returns the backup with the given ID and its content.
+func (c *Catalog) Get(id string) (Entry, []byte, error) {
+	m := idPattern.FindStringSubmatch(id)
+	if m == nil || ValidateEditor(m[1]) != nil {
+		return Entry{}, nil, fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	editors, err := c.editors()
+	if err != nil {
+		return Entry{}, nil, err
+	}
+	var files []string
+	for _, editor := range editors {
+		file := filepath.Join(c.dir, editor, id+".json")
+		if _, err := os.Stat(file); err == nil {
+			files = append(files, file)
+		}
+	}
+	switch len(files) {
+	case 0:
+		return Entry{}, nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	case 1:
+	default:
+		return Entry{}, nil, fmt.Errorf("backup ID %s is ambiguous: found in %d editors", id, len(files))
+	}
+	entry, err := readEntry(files[0])
+	if err != nil {
+		return Entry{}, nil, err
+	}
+	data, err := os.ReadFile(strings.TrimSuffix(files[0], ".json") + ".bak")
+	if err != nil {
+		return Entry{}, nil, fmt.Errorf("failed to read backup %s: %w", id, err)
+	}
+	return entry, data, nil
+}
+
+// Restore writes the backup with the given ID back to its path. The current content is backed up
+// first, the file is replaced atomically, and the restore is recorded in the catalogue.
+func (c *Catalog) Restore(id string) (Restore, error) {
+	entry, data, err := c.Get(id)
+	if err != nil {
+		return Restore{}, err
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != entry.SHA256 {
+		return Restore{}, fmt.Errorf("backup %s is corrupted: checksum mismatch", id)
+	}
+
+	target, err := safewrite.Open(entry.Path)
+	if err != nil {
+		return Restore{}, err
+	}
+	restore := Restore{ID: id, Path: entry.Path}
+	// Pruning waits until the restore is recorded, so that it never removes the restored backup.
+	replaced, ok, err := c.create(entry.Editor, entry.Path, "restore")
+	if err != nil {
+		return Restore{}, fmt.Errorf("failed to back up current file: %w", err)
+	}
+	if ok {
+		restore.Replaced = replaced.ID
+	}
+
+	// The replaced content was backed up from target; refuse to overwrite anything newer.
+	if err := target.Write(data); err != nil {
+		return Restore{}, fmt.Errorf("failed to restore %s: %w", entry.Path, err)
+	}
+	restore.Restored = c.now().UTC()
+	if err := c.appendRestore(restore); err != nil {
+		return restore, fmt.Errorf("restored %s but failed to record it: %w", entry.Path, err)
+	}
+	return restore, c.prune(entry.Editor, id)
+}
+
+// Restores returns the recorded restores of the backup with the given ID, oldest first.
+func (c *Catalog) Restores(id string) ([]Restore, error) {
+	f, err := os.Open(filepath.Join(c.dir, restoresFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var restores []Restore
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Restore
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", restoresFile, err)
+		}
+		if r.ID == id {
+			restores = append(restores, r)
+		}
+	}
+	return restores, scanner.Err()
+}
+
+func (c *Catalog) appendRestore(r Restore) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(c.dir, restoresFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (c *Catalog) retention(editor string) int {
+	if n, ok := c.opts.EditorRetention[editor]; ok && n != 0 {
+		return n
+	}
+	if c.opts.Retention != 0 {
+		return c.opts.Retention
+	}
+	return DefaultRetention
+}
+
+// editors returns the editors with a catalogue directory.
+func (c *Catalog) editors() ([]string, error) {
+	dirs, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var editors []string
+	for _, d := range dirs {
+		if d.IsDir() && ValidateEditor(d.Name()) == nil {
+			editors = append(editors, d.Name())
+		}
+	}
+	return editors, nil
+}
+
+// prune removes the oldest backups of editor beyond its retention limit, except keep.
+func (c *Catalog) prune(editor, keep string) error {
+	limit := c.retention(editor)
+	if limit < 0 {
+		return nil
+	}
+	entries, err := c.List(editor)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries[min(limit, len(entries)):] {
+		if e.ID == keep {
+			continue
+		}
+		base := filepath.Join(c.dir, editor, e.ID)
+		if err := os.Remove(base + ".bak"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if err := os.Remove(base + ".json"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func readEntry(file string) (Entry, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Entry{}, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Entry{}, fmt.Errorf("failed to parse backup metadata %s: %w", file, err)
+	}
+	return entry, nil
+}
@@ -0,0 +1,147 @@
+package backup_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/internal/backup"
+)
+
+func TestCatalog_CreateListRestore(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "keybindings.json")
+	catalog := backup.New(filepath.Join(dir, "backups"), backup.Options{})
+
+	_, ok, err := catalog.Create("vscode", target, "export")
+	require.NoError(t, err)
+	assert.False(t, ok, "missing files are not backed up")
+
+	require.NoError(t, os.WriteFile(target, []byte("v1"), 0o600))
+	first, ok, err := catalog.Create("vscode", target, "export")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "vscode", first.Editor)
+	assert.Equal(t, target, first.Path)
+	assert.Equal(t, int64(2), first.Size)
+
+	require.NoError(t, os.WriteFile(target, []byte("v2"), 0o600))
+	second, _, err := catalog.Create("vscode", target, "sync")
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	entries, err := catalog.List("vscode")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, second.ID, entries[0].ID, "newest first")
+
+	entries, err = catalog.List("zed")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, data, err := catalog.Get(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(data))
+
+	require.NoError(t, os.WriteFile(target, []byte("v3"), 0o600))
+	restore, err := catalog.Restore(first.ID)
+	require.NoError(t, err)
+	got, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(got))
+
+	// The replaced content is backed up too, and the restore is recorded.
+	_, replaced, err := catalog.Get(restore.Replaced)
+	require.NoError(t, err)
+	assert.Equal(t, "v3", string(replaced))
+	restores, err := catalog.Restores(first.ID)
+	require.NoError(t, err)
+	require.Len(t, restores, 1)
+	assert.Equal(t, restore.Replaced, restores[0].Replaced)
+
+	_, _, err = catalog.Get("vscode-20000101-000000")
+	require.ErrorIs(t, err, backup.ErrNotFound)
+}
+
+func TestCatalog_RejectsPatterns(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "keybindings.json")
+	require.NoError(t, os.WriteFile(target, []byte("v1"), 0o600))
+	catalog := backup.New(filepath.Join(dir, "backups"), backup.Options{})
+	_, _, err := catalog.Create("vscode", target, "export")
+	require.NoError(t, err)
+
+	for _, id := range []string{"*", "?*", "[a-z]*", "vscode-*", "../vscode-20000101-000000", ""} {
+		_, _, err := catalog.Get(id)
+		require.ErrorIs(t, err, backup.ErrInvalidID, id)
+	}
+	for _, editor := range []string{"*", "..", "../vscode", "vs*"} {
+		_, err := catalog.List(editor)
+		require.ErrorIs(t, err, backup.ErrInvalidEditor, editor)
+	}
+}
+
+func TestCatalog_RestoreOldestAtFullRetention(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "keymap.json")
+	catalog := backup.New(filepath.Join(dir, "backups"), backup.Options{Retention: 3})
+
+	var ids []string
+	for i := range 3 {
+		require.NoError(t, os.WriteFile(target, []byte{byte('a' + i)}, 0o600))
+		e, _, err := catalog.Create("zed", target, "export")
+		require.NoError(t, err)
+		ids = append(ids, e.ID)
+	}
+
+	restore, err := catalog.Restore(ids[0])
+	require.NoError(t, err)
+	got, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(got))
+
+	// The restored backup stays in the catalogue, so its recorded restore can still be shown.
+	_, data, err := catalog.Get(ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+	_, _, err = catalog.Get(restore.Replaced)
+	require.NoError(t, err)
+	restores, err := catalog.Restores(ids[0])
+	require.NoError(t, err)
+	assert.Len(t, restores, 1)
+}
+
+func TestCatalog_Retention(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "keymap.json")
+	require.NoError(t, os.WriteFile(target, []byte("{}"), 0o600))
+	catalog := backup.New(filepath.Join(dir, "backups"), backup.Options{
+		Retention:       3,
+		EditorRetention: map[string]int{"zed": 1},
+	})
+
+	var ids []string
+	for range 5 {
+		e, _, err := catalog.Create("vscode", target, "export")
+		require.NoError(t, err)
+		ids = append(ids, e.ID)
+		_, _, err = catalog.Create("zed", target, "export")
+		require.NoError(t, err)
+	}
+
+	entries, err := catalog.List("vscode")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, ids[4], entries[0].ID)
+	assert.Equal(t, ids[2], entries[2].ID)
+
+	entries, err = catalog.List("zed")
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	entries, err = catalog.List("")
+	require.NoError(t, err)
+	assert.Len(t, entries, 4)
+}
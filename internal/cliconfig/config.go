@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -21,9 +22,28 @@ var (
 type EditorConfig struct {
 	// KeymapPath is the path to the editor's keymap file.
 	KeymapPath string `mapstructure:"keymap_path"`
-	// TODO(xinnjie): Sync command is not implement yet
-	// SyncEnabled specifies whether keymap syncing is enabled for this editor.
+	// SyncEnabled specifies whether `onekeymap-cli sync` exports to this editor.
 	SyncEnabled bool `mapstructure:"sync_enabled"`
+	// BackupRetention overrides backup.retention for this editor.
+	BackupRetention int `mapstructure:"backup_retention"`
+}
+
+// BackupConfig holds settings for the backup catalogue.
+type BackupConfig struct {
+	// Dir is where backups are kept (default: onekeymap-cli/backups under the user cache dir).
+	Dir string `mapstructure:"dir"`
+	// Retention is how many backups to keep per editor (default: 10). A negative value keeps all.
+	Retention int `mapstructure:"retention"`
+}
+
+// GitConfig holds settings for committing keymap changes to a local git repository.
+type GitConfig struct {
+	// Enabled commits every change import, export, migrate, sync and undo make to onekeymap.json
+	// and editor keymaps (default: false).
+	Enabled bool `mapstructure:"enabled"`
+	// Dir is where the repository is created when it is not inside one already (default: the
+	// directory of onekeymap.json). Editor keymaps outside the repository are copied to editors/.
+	Dir string `mapstructure:"dir"`
 }
 
 // TelemetryConfig holds OpenTelemetry configuration.
@@ -47,6 +67,10 @@ type Config struct {
 	Telemetry TelemetryConfig `mapstructure:"telemetry"`
 	// Editors holds configuration for different editors.
 	Editors map[string]EditorConfig `mapstructure:"editors"`
+	// Backup holds backup catalogue configuration.
+	Backup BackupConfig `mapstructure:"backup"`
+	// Git holds the optional git versioning configuration.
+	Git GitConfig `mapstructure:"git"`
 }
 
 // Environment variables mapping
@@ -119,6 +143,29 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// BackupRetention returns the per-editor overrides of backup.retention.
+func (c *Config) BackupRetention() map[string]int {
+	retention := make(map[string]int)
+	for name, editor := range c.Editors {
+		if editor.BackupRetention != 0 {
+			retention[name] = editor.BackupRetention
+		}
+	}
+	return retention
+}
+
+// SyncEditors returns the names of the editors with sync_enabled set, sorted.
+func (c *Config) SyncEditors() []string {
+	var names []string
+	for name, editor := range c.Editors {
+		if editor.SyncEnabled {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
 // IsTelemetryExplicitlySet returns true if telemetry.enabled is explicitly set in config or environment.
 func IsTelemetryExplicitlySet() bool {
 	return viper.IsSet("telemetry.enabled")
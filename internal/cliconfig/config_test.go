@@ -0,0 +1,27 @@
+package cliconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xinnjie/onekeymap-cli/internal/cliconfig"
+)
+
+func TestConfig_SyncEditors(t *testing.T) {
+	cfg := cliconfig.Config{Editors: map[string]cliconfig.EditorConfig{
+		"zed":      {SyncEnabled: true},
+		"intellij": {KeymapPath: "/tmp/custom.xml"},
+		"vscode":   {KeymapPath: "/tmp/keybindings.json", SyncEnabled: true},
+	}}
+	assert.Equal(t, []string{"vscode", "zed"}, cfg.SyncEditors())
+
+	assert.Empty(t, (&cliconfig.Config{}).SyncEditors())
+}
+
+func TestConfig_BackupRetention(t *testing.T) {
+	cfg := cliconfig.Config{Editors: map[string]cliconfig.EditorConfig{
+		"zed":    {BackupRetention: 3},
+		"vscode": {SyncEnabled: true},
+	}}
+	assert.Equal(t, map[string]int{"zed": 3}, cfg.BackupRetention())
+}
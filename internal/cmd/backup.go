@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type backupFlags struct {
+	// No flags for backup command currently
+}
+
+func NewCmdBackup() *cobra.Command {
+	f := backupFlags{}
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "List, inspect and restore keymap backups",
+		Long: `List, inspect and restore the backups taken by --backup on import, export, migrate and sync.
+Backups are kept under the user cache directory (see backup.dir and backup.retention in config.yaml).`,
+		Run:  backupRun(&f),
+		Args: cobra.ExactArgs(0),
+	}
+
+	return cmd
+}
+
+func backupRun(_ *backupFlags) func(_ *cobra.Command, _ []string) {
+	return func(_ *cobra.Command, _ []string) {
+		// Empty implementation - this is a parent command for backup subcommands
+	}
+}
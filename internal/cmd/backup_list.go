@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xinnjie/onekeymap-cli/internal/backup"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/registry"
+)
+
+type backupListFlags struct {
+	editor string
+}
+
+func NewCmdBackupList() *cobra.Command {
+	f := backupListFlags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List backups, newest first",
+		RunE: backupListRun(&f, func() (*registry.Registry, *backup.Catalog, error) {
+			catalog, err := openBackupCatalog()
+			return cmdPluginRegistry, catalog, err
+		}),
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVar(&f.editor, "editor", "",
+		"Only list backups of this editor (\""+backupEditorOnekeymap+"\" for onekeymap.json)")
+
+	return cmd
+}
+
+func backupListRun(
+	f *backupListFlags,
+	dependencies func() (*registry.Registry, *backup.Catalog, error),
+) func(cmd *cobra.Command, _ []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		pluginRegistry, catalog, err := dependencies()
+		if err != nil {
+			return err
+		}
+		if f.editor != "" && f.editor != backupEditorOnekeymap {
+			if _, ok := pluginRegistry.Get(pluginapi.EditorType(f.editor)); !ok {
+				return fmt.Errorf("unknown editor %q", f.editor)
+			}
+		}
+		entries, err := catalog.List(f.editor)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			cmd.Println("No backups found.")
+			return nil
+		}
+
+		idWidth := len("ID")
+		for _, e := range entries {
+			idWidth = max(idWidth, len(e.ID))
+		}
+		cmd.Printf("%-*s  %-19s  %-8s  %s\n", idWidth, "ID", "CREATED", "REASON", "PATH")
+		for _, e := range entries {
+			created := e.Created.In(time.Local).Format(time.DateTime)
+			cmd.Printf("%-*s  %-19s  %-8s  %s\n", idWidth, e.ID, created, e.Reason, e.Path)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"github.com/xinnjie/onekeymap-cli/internal/backup"
+)
+
+type backupRestoreFlags struct {
+	interactive bool
+}
+
+func NewCmdBackupRestore() *cobra.Command {
+	f := backupRestoreFlags{}
+	cmd := &cobra.Command{
+		Use:   "restore <id>",
+		Short: "Write a backup back to the file it was taken from",
+		Long: `Write a backup back to the file it was taken from. The file is replaced atomically, its
+current content is backed up first, and the restore is recorded in the backup catalogue.`,
+		RunE: backupRestoreRun(&f, func() (*slog.Logger, *backup.Catalog, error) {
+			catalog, err := openBackupCatalog()
+			return cmdLogger, catalog, err
+		}),
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().BoolVar(&f.interactive, "interactive", true, "Confirm before overwriting the file")
+
+	return cmd
+}
+
+func backupRestoreRun(
+	f *backupRestoreFlags,
+	dependencies func() (*slog.Logger, *backup.Catalog, error),
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		logger, catalog, err := dependencies()
+		if err != nil {
+			return err
+		}
+		entry, _, err := catalog.Get(args[0])
+		if err != nil {
+			return err
+		}
+
+		if f.interactive {
+			if !confirm(cmd, entry.Path) {
+				cmd.Println("Restore canceled; no changes were written.")
+				return nil
+			}
+		}
+
+		restore, err := catalog.Restore(entry.ID)
+		if err != nil {
+			return err
+		}
+		logger.Info("Restored backup", "id", restore.ID, "path", restore.Path, "replaced", restore.Replaced)
+		cmd.Printf("Restored %s to %s\n", restore.ID, restore.Path)
+		if restore.Replaced != "" {
+			cmd.Printf("The replaced content was backed up as %s\n", restore.Replaced)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/internal/backup"
+	"github.com/xinnjie/onekeymap-cli/pkg/registry"
+)
+
+func TestBackupRestore(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "keybindings.json")
+	require.NoError(t, os.WriteFile(target, []byte("v1"), 0o600))
+	catalog := backup.New(filepath.Join(dir, "backups"), backup.Options{})
+	entry, _, err := catalog.Create("vscode", target, "export")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(target, []byte("v2"), 0o600))
+
+	run := backupRestoreRun(&backupRestoreFlags{}, func() (*slog.Logger, *backup.Catalog, error) {
+		return slog.New(slog.NewTextHandler(io.Discard, nil)), catalog, nil
+	})
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+
+	for _, id := range []string{"*", "vscode-*", "../" + entry.ID} {
+		require.ErrorIs(t, run(cmd, []string{id}), backup.ErrInvalidID, id)
+	}
+	require.ErrorIs(t, run(cmd, []string{"vscode-20000101-000000"}), backup.ErrNotFound)
+	got, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(got), "a bad ID restores nothing")
+
+	require.NoError(t, run(cmd, []string{entry.ID}))
+	got, err = os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(got))
+}
+
+func TestBackupList_UnknownEditor(t *testing.T) {
+	s := newTestServices(t)
+	catalog := backup.New(t.TempDir(), backup.Options{})
+	run := backupListRun(&backupListFlags{editor: "*"}, func() (*registry.Registry, *backup.Catalog, error) {
+		return s.registry, catalog, nil
+	})
+	cmd := &cobra.Command{}
+	cmd.SetOut(io.Discard)
+	require.ErrorContains(t, run(cmd, nil), "unknown editor")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xinnjie/onekeymap-cli/internal/backup"
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+)
+
+type backupShowFlags struct {
+	// No flags for backup show command currently
+}
+
+func NewCmdBackupShow() *cobra.Command {
+	f := backupShowFlags{}
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a backup and how restoring it would change the current file",
+		RunE: backupShowRun(&f, func() (*backup.Catalog, error) {
+			return openBackupCatalog()
+		}),
+		Args: cobra.ExactArgs(1),
+	}
+
+	return cmd
+}
+
+func backupShowRun(
+	_ *backupShowFlags,
+	dependencies func() (*backup.Catalog, error),
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		catalog, err := dependencies()
+		if err != nil {
+			return err
+		}
+		entry, data, err := catalog.Get(args[0])
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("ID:      %s\n", entry.ID)
+		cmd.Printf("Editor:  %s\n", entry.Editor)
+		cmd.Printf("Path:    %s\n", entry.Path)
+		cmd.Printf("Created: %s\n", entry.Created.In(time.Local).Format(time.DateTime))
+		cmd.Printf("Reason:  %s\n", entry.Reason)
+		cmd.Printf("Size:    %d bytes (sha256 %s)\n", entry.Size, entry.SHA256)
+		restores, err := catalog.Restores(entry.ID)
+		if err != nil {
+			return err
+		}
+		for _, r := range restores {
+			line := fmt.Sprintf("Restored: %s", r.Restored.In(time.Local).Format(time.DateTime))
+			if r.Replaced != "" {
+				line += fmt.Sprintf(" (replaced content saved as %s)", r.Replaced)
+			}
+			cmd.Println(line)
+		}
+
+		current, err := os.ReadFile(entry.Path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read current file: %w", err)
+		}
+		if bytes.Equal(current, data) {
+			cmd.Println("\nThe current file matches this backup.")
+			return nil
+		}
+		differ := diff.NewUnifiedDiffFormatDiffer()
+		d, err := differ.Diff(bytes.NewReader(current), bytes.NewReader(data), filepath.Base(entry.Path))
+		if err != nil {
+			return fmt.Errorf("failed to compute diff: %w", err)
+		}
+		cmd.Println("\nChanges restoring this backup would make to the current file:")
+		cmd.Println(strings.TrimRight(d, "\n"))
+		return nil
+	}
+}
@@ -2,66 +2,290 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
-	"io"
-	"os"
+	"log/slog"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/xinnjie/onekeymap-cli/internal/backup"
+	"github.com/xinnjie/onekeymap-cli/internal/cliconfig"
+	"github.com/xinnjie/onekeymap-cli/internal/gitrepo"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+	"github.com/xinnjie/onekeymap-cli/internal/safewrite"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
 )
 
+// platformValue is a pflag.Value for the --platform flag. The zero value means the current platform.
+type platformValue platform.Platform
+
+func (v *platformValue) String() string { return string(*v) }
+
+func (v *platformValue) Set(s string) error {
+	p, err := platform.Parse(s)
+	if err != nil {
+		return err
+	}
+	*v = platformValue(p)
+	return nil
+}
+
+func (v *platformValue) Type() string { return "platform" }
+
+// modifierStrategyValue is a pflag.Value for the --modifier-strategy flag.
+type modifierStrategyValue keymap.ModifierStrategy
+
+func (v *modifierStrategyValue) String() string { return string(*v) }
+
+func (v *modifierStrategyValue) Set(s string) error {
+	strategy, err := keymap.ParseModifierStrategy(s)
+	if err != nil {
+		return err
+	}
+	*v = modifierStrategyValue(strategy)
+	return nil
+}
+
+func (v *modifierStrategyValue) Type() string { return "strategy" }
+
+// addModifierFlags registers --modifier-strategy and --keymap-platform, which translate modifiers
+// between the editor keymap's platform and the platform onekeymap.json is written for.
+func addModifierFlags(cmd *cobra.Command, strategy *keymap.ModifierStrategy, keymapPlatform *platform.Platform) {
+	cmd.Flags().Var((*modifierStrategyValue)(strategy), "modifier-strategy",
+		"How to translate modifiers between platforms: keep, meta-to-ctrl or ctrl-alt-on-collision (default keep)")
+	cmd.Flags().Var((*platformValue)(keymapPlatform), "keymap-platform",
+		"Platform onekeymap.json is written for: macos, linux or windows (defaults to the current platform)")
+	_ = cmd.RegisterFlagCompletionFunc(
+		"modifier-strategy",
+		func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			names := make([]string, 0, len(keymap.ModifierStrategies))
+			for _, s := range keymap.ModifierStrategies {
+				names = append(names, string(s))
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+	)
+}
+
+// printModifierCollisions lists the bindings that gave way to translated modifiers.
+func printModifierCollisions(cmd *cobra.Command, report keymap.TranslationReport) {
+	if len(report.Collisions) == 0 {
+		return
+	}
+	format := keybinding.FormatOption{Platform: platform.PlatformMacOS, Separator: "+"}
+	cmd.Printf("  \u21c4 %d modifier collisions resolved:\n", len(report.Collisions))
+	for _, c := range report.Collisions {
+		line := fmt.Sprintf("    - %s: %s now belongs to %s", c.Displaced, c.Key.String(format), c.Action)
+		if len(c.MovedTo.KeyChords) > 0 {
+			line += fmt.Sprintf(", moved to %s", c.MovedTo.String(format))
+		} else {
+			line += ", dropped"
+		}
+		if !c.Scope.IsZero() {
+			line += fmt.Sprintf(" (scope %s)", c.Scope)
+		}
+		cmd.Println(line)
+	}
+}
+
+// addPlatformFlag registers --platform, which selects the platform an editor keymap is read or
+// written for, e.g. to generate a Windows keybindings.json on a Linux machine.
+func addPlatformFlag(cmd *cobra.Command, p *platform.Platform, usage string) {
+	cmd.Flags().Var((*platformValue)(p), "platform", usage+": macos, linux or windows (defaults to the current platform)")
+	_ = cmd.RegisterFlagCompletionFunc(
+		"platform",
+		func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return []string{
+				string(platform.PlatformMacOS), string(platform.PlatformLinux), string(platform.PlatformWindows),
+			}, cobra.ShellCompDirectiveNoFileComp
+		},
+	)
+}
+
 func confirm(cmd *cobra.Command, path string) bool {
 	if path == "" {
 		panic("path is empty")
 	}
 	cmd.Printf("Write config to %s? [y/N]: ", path)
-	reader := bufio.NewReader(os.Stdin)
+	reader := bufio.NewReader(cmd.InOrStdin())
 	answer, _ := reader.ReadString('\n')
 	answer = strings.ToLower(strings.TrimSpace(answer))
 	return answer == "y" || answer == "yes"
 }
 
-func backupIfExists(path string) (string, error) {
-	info, err := os.Stat(path)
+// writeError explains how to recover when a safe write was refused.
+func writeError(err error) error {
+	switch {
+	case errors.Is(err, safewrite.ErrChanged):
+		return fmt.Errorf("%w; nothing was written, re-run to include those edits", err)
+	case errors.Is(err, safewrite.ErrLocked):
+		return fmt.Errorf("%w; another onekeymap-cli run is writing it, or remove the lock file", err)
+	}
+	return err
+}
+
+// backupEditorOnekeymap files backups of onekeymap.json itself in the backup catalogue.
+const backupEditorOnekeymap = "onekeymap"
+
+// backupIfExists adds the file at path to the backup catalogue and returns the backup ID, or ""
+// when there is no file to back up.
+func backupIfExists(editor, path, reason string) (string, error) {
+	catalog, err := openBackupCatalog()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return "", nil
-		}
 		return "", err
 	}
-	if !info.Mode().IsRegular() {
-		// Only back up regular files
-		return "", nil
+	entry, ok, err := catalog.Create(editor, path, reason)
+	if err != nil || !ok {
+		return "", err
 	}
+	return entry.ID, nil
+}
 
-	dir := filepath.Dir(path)
-	base := filepath.Base(path)
-	ts := time.Now().Format("20060102-150405")
-	backup := filepath.Join(dir, base+".bak-"+ts)
-	// Ensure uniqueness if a backup with the same timestamp exists
-	for i := 1; ; i++ {
-		if _, err := os.Stat(backup); os.IsNotExist(err) {
-			break
+// openBackupCatalog opens the backup catalogue configured in config.yaml.
+func openBackupCatalog() (*backup.Catalog, error) {
+	var cfg cliconfig.Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	dir := cfg.Backup.Dir
+	if dir == "" {
+		var err error
+		if dir, err = backup.DefaultDir(); err != nil {
+			return nil, err
 		}
-		backup = filepath.Join(dir, fmt.Sprintf("%s.bak-%s-%d", base, ts, i))
 	}
+	return backup.New(dir, backup.Options{
+		Retention:       cfg.Backup.Retention,
+		EditorRetention: cfg.BackupRetention(),
+	}), nil
+}
 
-	in, err := os.Open(path)
-	if err != nil {
-		return "", err
+// recordHistory appends a version of the onekeymap config at path to its history journal and, with
+// git versioning on, commits it. The config is already written, so a failure is only logged.
+func recordHistory(logger *slog.Logger, path string, before []byte, e history.Entry) {
+	if _, err := history.Record(history.Path(path), before, e); err != nil {
+		logger.Warn("Failed to record keymap history", "path", history.Path(path), "error", err)
 	}
-	defer func() { _ = in.Close() }()
+	commitVersion(logger, historySubject(e), e.Changes, versionFile{path: path})
+}
+
+// historySubject describes a history entry in one line.
+func historySubject(e history.Entry) string {
+	switch {
+	case e.Command == history.CommandUndo:
+		return fmt.Sprintf("undo: revert to version %d", e.Restores)
+	case e.Command == history.CommandEdit:
+		return "edit made outside onekeymap-cli"
+	case e.Editor != "":
+		return e.Command + " from " + e.Editor
+	}
+	return e.Command
+}
 
-	out, err := os.OpenFile(backup, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+// versionFile is a file committed by commitVersion: an editor's keymap, or onekeymap.json when
+// editor is empty.
+type versionFile struct {
+	editor string
+	path   string
+}
+
+// commitVersion commits files to the git repository enabled with git.enabled in config.yaml, with a
+// message made of subject and the actions in changes. The files are already written, so a failure
+// is only logged.
+func commitVersion(logger *slog.Logger, subject string, changes history.Summary, files ...versionFile) {
+	repo, err := openGitRepo()
 	if err != nil {
-		return "", err
+		logger.Warn("Failed to open git repository for versioning", "error", err)
+		return
+	}
+	if repo == nil || len(files) == 0 {
+		return
 	}
-	defer func() { _ = out.Close() }()
+	gitFiles := make([]gitrepo.File, len(files))
+	for i, f := range files {
+		gitFiles[i] = gitrepo.File{Path: f.path, Name: repo.Name(f.path, versionMirror(f.editor, f.path))}
+	}
+	commit, ok, err := repo.Commit(versionMessage(subject, changes), gitFiles...)
+	if err != nil {
+		logger.Warn("Failed to commit keymap version", "repository", repo.Root(), "error", err)
+		return
+	}
+	if ok {
+		logger.Info("Committed keymap version", "repository", repo.Root(), "commit", commit.Hash[:7])
+	}
+}
 
-	if _, err := io.Copy(out, in); err != nil {
-		return "", err
+// openGitRepo opens the repository keymap versions are committed to, or returns nil when git
+// versioning is off.
+func openGitRepo() (*gitrepo.Repo, error) {
+	var cfg cliconfig.Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if !cfg.Git.Enabled {
+		return nil, nil
+	}
+	dir := cfg.Git.Dir
+	if dir == "" {
+		dir = filepath.Dir(viper.GetString("onekeymap"))
+	}
+	return gitrepo.Open(dir)
+}
+
+// versionMirror is where a file outside the git repository is copied to, relative to git.dir.
+func versionMirror(editor, path string) string {
+	if editor == "" {
+		return filepath.Base(path)
+	}
+	return "editors/" + editor + "/" + filepath.Base(path)
+}
+
+// versionMessage builds a commit message: subject with the numbers of added (+), updated (~) and
+// removed (-) actions, followed by the action IDs.
+func versionMessage(subject string, changes history.Summary) string {
+	var b strings.Builder
+	b.WriteString(subject)
+	if len(changes.Added)+len(changes.Updated)+len(changes.Removed) == 0 {
+		return b.String()
+	}
+	fmt.Fprintf(&b, " (+%d ~%d -%d)\n", len(changes.Added), len(changes.Updated), len(changes.Removed))
+	for _, group := range []struct {
+		title string
+		names []string
+	}{{"Added", changes.Added}, {"Updated", changes.Updated}, {"Removed", changes.Removed}} {
+		if len(group.names) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s:\n", group.title)
+		for _, name := range group.names {
+			fmt.Fprintf(&b, "  %s\n", name)
+		}
+	}
+	return b.String()
+}
+
+// printKeymapChanges prints one line per added (+), updated (~) and removed (-) action.
+func printKeymapChanges(cmd *cobra.Command, changes *importerapi.KeymapChanges) {
+	for _, a := range changes.Add {
+		cmd.Printf("  + %s: %s\n", a.Name, formatActionKeys(a))
+	}
+	for _, d := range changes.Update {
+		cmd.Printf("  ~ %s: %s -> %s\n", d.After.Name, formatActionKeys(d.Before), formatActionKeys(d.After))
+	}
+	for _, a := range changes.Remove {
+		cmd.Printf("  - %s: %s\n", a.Name, formatActionKeys(a))
+	}
+}
+
+func formatActionKeys(a keymap.Action) string {
+	parts := make([]string, 0, len(a.Bindings))
+	for _, kb := range a.Bindings {
+		parts = append(parts, kb.String(keybinding.FormatOption{Platform: platform.PlatformMacOS, Separator: "+"}))
 	}
-	return backup, nil
+	return strings.Join(parts, " or ")
 }
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+)
+
+func TestVersionMessage(t *testing.T) {
+	assert.Equal(t, "export to vscode", versionMessage("export to vscode", history.Summary{}))
+
+	msg := versionMessage("import from zed", history.Summary{
+		Added:   []string{"actions.file.save"},
+		Removed: []string{"actions.clipboard.copy", "actions.clipboard.paste"},
+	})
+	assert.Equal(t, `import from zed (+1 ~0 -2)
+
+Added:
+  actions.file.save
+
+Removed:
+  actions.clipboard.copy
+  actions.clipboard.paste
+`, msg)
+}
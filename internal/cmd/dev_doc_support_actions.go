@@ -23,7 +23,7 @@ func NewCmdDevDocSupportActions() *cobra.Command {
 		Use:   "docSupportActions",
 		Short: "Generate markdown table showing action support across editors",
 		Long: `Reads all action mappings and generates a markdown table showing which editors
-support each action. The table includes columns for VSCode, Zed, IntelliJ, Helix, and Xcode.`,
+support each action, with one column per editor after the action's name, ID and description.`,
 		Run: devDocSupportActionsRun(&f, func() *slog.Logger {
 			return cmdLogger
 		}),
@@ -50,14 +50,11 @@ func devDocSupportActionsRun(
 		// Prepare data structures for template rendering
 		type supportRow struct {
 			Action         string
-			VSCode         string
-			Zed            string
-			IntelliJ       string
-			Xcode          string
-			Helix          string
 			Description    string
 			ActionID       string
 			FeaturedReason string
+			// Support holds one cell per editor in supportMatrixEditors.
+			Support []string
 		}
 
 		type categorySection struct {
@@ -75,13 +72,6 @@ func devDocSupportActionsRun(
 				category = "Uncategorized"
 			}
 
-			// Check support for each editor
-			vscodeSupport, vscodeReason := mapping.IsSupported(pluginapi.EditorTypeVSCode)
-			zedSupport, zedReason := mapping.IsSupported(pluginapi.EditorTypeZed)
-			intellijSupport, intellijReason := mapping.IsSupported(pluginapi.EditorTypeIntelliJ)
-			xcodeSupport, xcodeReason := mapping.IsSupported(pluginapi.EditorTypeXcode)
-			helixSupport, helixReason := mapping.IsSupported(pluginapi.EditorTypeHelix)
-
 			// Format description for markdown (escape pipes and newlines)
 			description := strings.ReplaceAll(mapping.Description, "|", "\\|")
 			description = strings.ReplaceAll(description, "\n", " ")
@@ -98,15 +88,14 @@ func devDocSupportActionsRun(
 
 			row := supportRow{
 				Action:         mapping.Name,
-				VSCode:         formatSupport(vscodeSupport, vscodeReason),
-				Zed:            formatSupport(zedSupport, zedReason),
-				IntelliJ:       formatSupport(intellijSupport, intellijReason),
-				Xcode:          formatSupport(xcodeSupport, xcodeReason),
-				Helix:          formatSupport(helixSupport, helixReason),
 				Description:    description,
 				ActionID:       id,
 				FeaturedReason: featuredReason,
 			}
+			// Check support for each editor
+			for _, editor := range supportMatrixEditors {
+				row.Support = append(row.Support, formatSupport(mapping.IsSupported(editor.Type)))
+			}
 
 			// Separate common and featured actions
 			if mapping.Featured {
@@ -154,15 +143,15 @@ func devDocSupportActionsRun(
 		}
 
 		const supportMatrixTmpl = `# Action Support Matrix
-{{- range . }}
+{{- range .Sections }}
 
 ## {{ .Category }}
 {{- if .Rows }}
 
-| Action | VSCode | Zed | IntelliJ | Xcode | Helix | Description | Action ID |
-|--------|--------|-----|----------|-------|-------|-------------|-----------|
+| Action | Action ID | Description |{{ range $.Editors }} {{ .Title }} |{{ end }}
+|--------|-----------|-------------|{{ range $.Editors }}{{ dashes .Title }}|{{ end }}
 {{- range .Rows }}
-| {{ .Action }} | {{ .VSCode }} | {{ .Zed }} | {{ .IntelliJ }} | {{ .Xcode }} | {{ .Helix }} | {{ .Description }} | {{ .ActionID }} |
+| {{ .Action }} | {{ .ActionID }} | {{ .Description }} |{{ range .Support }} {{ . }} |{{ end }}
 {{- end }}
 {{- end }}
 {{- if .FeaturedRows }}
@@ -170,10 +159,10 @@ func devDocSupportActionsRun(
 <details>
 <summary>Featured Actions</summary>
 
-| Action | VSCode | Zed | IntelliJ | Xcode | Helix | Description | Action ID | Featured Reason |
-|--------|--------|-----|----------|-------|-------|-------------|-----------|-----------------|
+| Action | Action ID | Description | Featured Reason |{{ range $.Editors }} {{ .Title }} |{{ end }}
+|--------|-----------|-------------|-----------------|{{ range $.Editors }}{{ dashes .Title }}|{{ end }}
 {{- range .FeaturedRows }}
-| {{ .Action }} | {{ .VSCode }} | {{ .Zed }} | {{ .IntelliJ }} | {{ .Xcode }} | {{ .Helix }} | {{ .Description }} | {{ .ActionID }} | {{ .FeaturedReason }} |
+| {{ .Action }} | {{ .ActionID }} | {{ .Description }} | {{ .FeaturedReason }} |{{ range .Support }} {{ . }} |{{ end }}
 {{- end }}
 </details>
 
@@ -181,14 +170,45 @@ func devDocSupportActionsRun(
 {{- end }}
 `
 
-		t := template.Must(template.New("support-matrix").Parse(supportMatrixTmpl))
-		if err := t.Execute(cmd.OutOrStdout(), sections); err != nil {
+		t := template.Must(template.New("support-matrix").Funcs(template.FuncMap{
+			"dashes": func(title string) string { return strings.Repeat("-", len(title)+2) },
+		}).Parse(supportMatrixTmpl))
+		data := struct {
+			Editors  []supportMatrixEditor
+			Sections []categorySection
+		}{supportMatrixEditors, sections}
+		if err := t.Execute(cmd.OutOrStdout(), data); err != nil {
 			logger.ErrorContext(ctx, "Error executing template", "error", err)
 			os.Exit(1)
 		}
 	}
 }
 
+// supportMatrixEditor is an editor column of the action support matrix.
+type supportMatrixEditor struct {
+	Title string
+	Type  pluginapi.EditorType
+}
+
+// supportMatrixEditors lists the editor columns of the action support matrix, after the action's
+// name, ID and description. Append new editors at the end: rows then only grow by one cell, and
+// regenerating the matrix leaves the other columns as they are.
+var supportMatrixEditors = []supportMatrixEditor{
+	{"VSCode", pluginapi.EditorTypeVSCode},
+	{"Zed", pluginapi.EditorTypeZed},
+	{"IntelliJ", pluginapi.EditorTypeIntelliJ},
+	{"Xcode", pluginapi.EditorTypeXcode},
+	{"Helix", pluginapi.EditorTypeHelix},
+	{"Vim", pluginapi.EditorTypeVim},
+	{"Sublime", pluginapi.EditorTypeSublime},
+	{"Emacs", pluginapi.EditorTypeEmacs},
+	{"Eclipse", pluginapi.EditorTypeEclipse},
+	{"Visual Studio", pluginapi.EditorTypeVisualStudio},
+	{"Lapce", pluginapi.EditorTypeLapce},
+	{"Kakoune", pluginapi.EditorTypeKakoune},
+	{"Pulsar", pluginapi.EditorTypePulsar},
+}
+
 func formatSupport(supported bool, reason string) string {
 	if supported {
 		if reason != "" {
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -14,9 +13,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+	"github.com/xinnjie/onekeymap-cli/internal/safewrite"
 	"github.com/xinnjie/onekeymap-cli/internal/views"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/exporterapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/registry"
 )
@@ -27,6 +29,10 @@ type exportFlags struct {
 	output      string
 	interactive bool
 	backup      bool
+	platform    platform.Platform
+	// modifierStrategy and keymapPlatform translate modifiers, e.g. cmd+c to ctrl+c.
+	modifierStrategy keymap.ModifierStrategy
+	keymapPlatform   platform.Platform
 }
 
 //nolint:dupl // Import/Export command constructors are intentionally symmetrical; limited duplication keeps each isolated and clearer
@@ -46,6 +52,8 @@ func NewCmdExport() *cobra.Command {
 	cmd.Flags().StringVar(&f.output, "output", "", "Optional: Path to the target editor's config file")
 	cmd.Flags().BoolVar(&f.interactive, "interactive", true, "Run in interactive mode")
 	cmd.Flags().BoolVar(&f.backup, "backup", false, "Create a backup of the target editor's keymap")
+	addPlatformFlag(cmd, &f.platform, "Platform to generate the target editor's keymap for")
+	addModifierFlags(cmd, &f.modifierStrategy, &f.keymapPlatform)
 
 	// Add completion for 'to' flag
 	_ = cmd.RegisterFlagCompletionFunc(
@@ -82,13 +90,18 @@ func exportRun(
 			}
 		}()
 
-		setting, err := keymap.Load(inputFile, keymap.LoadOptions{})
+		setting, err := keymap.Load(inputFile, keymap.LoadOptions{Dir: filepath.Dir(f.input)})
 		if err != nil {
 			logger.Error("Failed to load config file", "error", err)
 			return err
 		}
 
-		opts := exporterapi.ExportOptions{EditorType: pluginapi.EditorType(f.to)}
+		opts := exporterapi.ExportOptions{
+			EditorType:       pluginapi.EditorType(f.to),
+			TargetPlatform:   f.platform,
+			ModifierStrategy: f.modifierStrategy,
+			KeymapPlatform:   f.keymapPlatform,
+		}
 
 		// Ensure parent directory exists
 		if err := os.MkdirAll(filepath.Dir(f.output), 0o750); err != nil {
@@ -96,16 +109,15 @@ func exportRun(
 			return err
 		}
 
-		// Prepare base reader from existing output file if present for diff calculation
-		var base io.Reader
-		if outputFile, err := os.Open(f.output); err == nil {
-			defer func() { _ = outputFile.Close() }()
-			base = outputFile
-		} else if !os.IsNotExist(err) {
-			// Non-ENOENT error opening base file; log and continue without base
-			logger.Warn("Failed to open existing output as base", "error", err)
+		// Read the existing output file as base for diff calculation; writing checks it is unchanged
+		original, err := safewrite.Open(f.output)
+		if err != nil {
+			logger.Error("Failed to read existing output", "error", err)
+			return err
+		}
+		if original.Exists() {
+			opts.OriginalConfig = bytes.NewReader(original.Content())
 		}
-		opts.OriginalConfig = base
 		opts.DiffType = exporterapi.DiffTypeASCII
 		opts.FilePath = f.output
 
@@ -142,30 +154,21 @@ func exportRun(
 
 		// Backup existing file if requested
 		if f.backup {
-			if backupPath, err := backupIfExists(f.output); err != nil {
+			if backupID, err := backupIfExists(f.to, f.output, "export"); err != nil {
 				logger.Warn("Failed to backup existing file", "path", f.output, "error", err)
-			} else if backupPath != "" {
-				logger.Info("Created backup of existing config", "backup", backupPath)
+			} else if backupID != "" {
+				logger.Info("Created backup of existing config", "backup", backupID)
 			}
 		}
 
 		// Write buffer to the target file
-		outputFile, err := os.OpenFile(f.output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
-		if err != nil {
-			logger.Error("Failed to create output file", "error", err)
-			return err
-		}
-		defer func() {
-			if err := outputFile.Close(); err != nil {
-				logger.Error("Failed to close output file", "error", err)
-			}
-		}()
-		if _, err := outputFile.Write(mem.Bytes()); err != nil {
+		if err := original.Write(mem.Bytes()); err != nil {
 			logger.Error("Failed to write to output file", "error", err)
-			return err
+			return writeError(err)
 		}
 
 		logger.Info("Successfully exported keymap", "to", f.to, "output", f.output)
+		commitVersion(logger, "export to "+f.to, history.Summary{}, versionFile{editor: f.to, path: f.output})
 		return nil
 	}
 }
@@ -223,6 +226,8 @@ func printExportSummary(cmd *cobra.Command, report *exporterapi.ExportReport) {
 	} else {
 		cmd.Println("  \u2717 0 actions skipped")
 	}
+
+	printModifierCollisions(cmd, report.Translation)
 }
 
 func handleInteractiveExportFlags(
@@ -293,7 +298,7 @@ func prepareExportInputFlags(
 			f.output = configPath
 			logger.Info("Using keymap path from config", "editor", f.to, "path", configPath)
 		} else {
-			if v, _, err := p.ConfigDetect(pluginapi.ConfigDetectOptions{}); err == nil {
+			if v, _, err := p.ConfigDetect(pluginapi.ConfigDetectOptions{Platform: f.platform}); err == nil {
 				f.output = v[0]
 			}
 		}
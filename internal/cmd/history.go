@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/xinnjie/onekeymap-cli/internal/cliconfig"
+	"github.com/xinnjie/onekeymap-cli/internal/gitrepo"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+)
+
+type historyFlags struct {
+	input string
+}
+
+func NewCmdHistory() *cobra.Command {
+	f := historyFlags{}
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the versions of onekeymap.json written by import, sync and undo",
+		Long: `List the versions of onekeymap.json recorded in its history journal, oldest first.
+The journal (onekeymap.history.jsonl) lives next to the config; the current version is marked with *.
+Use "history show <n>" to see what a version changed and "undo" to go back to the previous one.
+With git.enabled in config.yaml, the commits of the git repository are listed instead and
+"history show <commit>" shows a commit.`,
+		RunE: historyRun(&f),
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVar(&f.input, "input", "", "Path to the onekeymap.json file (defaults to config value)")
+
+	return cmd
+}
+
+func historyRun(f *historyFlags) func(cmd *cobra.Command, _ []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		input := historyInput(f.input)
+		repo, err := openGitRepo()
+		if err != nil {
+			return err
+		}
+		if repo != nil {
+			return printGitHistory(cmd, repo, input)
+		}
+
+		entries, err := history.List(history.Path(input))
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			cmd.Println("No history recorded yet.")
+			return nil
+		}
+
+		current := history.Current(entries)
+		cmd.Printf("  %4s  %-19s  %-8s  %-12s  %s\n", "#", "TIME", "COMMAND", "EDITOR", "CHANGES")
+		for _, e := range entries {
+			marker := " "
+			if e.Seq == current {
+				marker = "*"
+			}
+			changes := fmt.Sprintf("+%d ~%d -%d", len(e.Changes.Added), len(e.Changes.Updated), len(e.Changes.Removed))
+			if e.Command == history.CommandUndo {
+				changes = fmt.Sprintf("restores #%d", e.Restores)
+			}
+			cmd.Printf("%s %4d  %-19s  %-8s  %-12s  %s\n",
+				marker, e.Seq, e.Time.In(time.Local).Format(time.DateTime), e.Command, e.Editor, changes)
+		}
+		return nil
+	}
+}
+
+func printGitHistory(cmd *cobra.Command, repo *gitrepo.Repo, input string) error {
+	names, err := gitVersionNames(repo, input)
+	if err != nil {
+		return err
+	}
+	commits, err := repo.Log(names...)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		cmd.Printf("No commits in %s yet.\n", repo.Root())
+		return nil
+	}
+
+	cmd.Printf("%-7s  %-19s  %s\n", "COMMIT", "TIME", "SUBJECT")
+	for _, c := range commits {
+		cmd.Printf("%-7s  %-19s  %s\n", c.Hash[:7], c.Time.In(time.Local).Format(time.DateTime), c.Subject)
+	}
+	return nil
+}
+
+// gitVersionNames returns the names in repo of onekeymap.json and the editor keymaps committed
+// alongside it.
+func gitVersionNames(repo *gitrepo.Repo, input string) ([]string, error) {
+	var cfg cliconfig.Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	names := []string{repo.Name(input, versionMirror("", input)), repo.MirrorName("editors")}
+	for editor, c := range cfg.Editors {
+		if c.KeymapPath != "" {
+			names = append(names, repo.Name(c.KeymapPath, versionMirror(editor, c.KeymapPath)))
+		}
+	}
+	return names, nil
+}
+
+// historyInput returns the onekeymap.json whose history is used: input, or the configured one.
+func historyInput(input string) string {
+	if input != "" {
+		return input
+	}
+	return viper.GetString("onekeymap")
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xinnjie/onekeymap-cli/internal/gitrepo"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/merge"
+)
+
+type historyShowFlags struct {
+	input string
+}
+
+func NewCmdHistoryShow() *cobra.Command {
+	f := historyShowFlags{}
+	cmd := &cobra.Command{
+		Use:   "show <n>",
+		Short: "Show the actions a version added, changed and removed",
+		Long: `Show the actions version <n> of onekeymap.json added, changed and removed.
+With git.enabled in config.yaml, <n> is a commit instead, and the files it changed are listed too.`,
+		RunE: historyShowRun(&f),
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().StringVar(&f.input, "input", "", "Path to the onekeymap.json file (defaults to config value)")
+
+	return cmd
+}
+
+func historyShowRun(f *historyShowFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		input := historyInput(f.input)
+		repo, err := openGitRepo()
+		if err != nil {
+			return err
+		}
+		if repo != nil {
+			return showGitVersion(cmd, repo, input, args[0])
+		}
+
+		seq, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+		if err != nil {
+			return fmt.Errorf("invalid version %q: expected a number from \"history\"", args[0])
+		}
+		entries, err := history.List(history.Path(input))
+		if err != nil {
+			return err
+		}
+		entry, ok := history.Get(entries, seq)
+		if !ok {
+			return fmt.Errorf("version %d not found in the history of %s", seq, input)
+		}
+		// An entry is compared with the version it replaced, which an undo does not change.
+		var before history.Entry
+		if seq > 1 {
+			before, _ = history.Get(entries, seq-1)
+		}
+		changes, err := historyChanges(input, before.Content, entry.Content)
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Version %d: %s", entry.Seq, entry.Command)
+		if entry.Editor != "" {
+			cmd.Printf(" from %s", entry.Editor)
+		}
+		if entry.Command == history.CommandUndo {
+			cmd.Printf(", restoring version %d", entry.Restores)
+		}
+		cmd.Printf(" at %s\n", entry.Time.In(time.Local).Format(time.DateTime))
+		if !changes.HasChanges() {
+			cmd.Println("  No action changes.")
+			return nil
+		}
+		printKeymapChanges(cmd, changes)
+		return nil
+	}
+}
+
+func showGitVersion(cmd *cobra.Command, repo *gitrepo.Repo, input, rev string) error {
+	commit, err := repo.Resolve(rev)
+	if err != nil {
+		return err
+	}
+	files, err := repo.Files(commit.Hash)
+	if err != nil {
+		return err
+	}
+	name := repo.Name(input, versionMirror("", input))
+	before, _, err := repo.Show(commit.Hash+"^", name)
+	if err != nil {
+		return err
+	}
+	after, _, err := repo.Show(commit.Hash, name)
+	if err != nil {
+		return err
+	}
+	changes, err := historyChanges(input, string(before), string(after))
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Commit %s: %s at %s\n", commit.Hash[:7], commit.Subject, commit.Time.In(time.Local).Format(time.DateTime))
+	for _, f := range files {
+		cmd.Printf("  %s\n", f)
+	}
+	if changes.HasChanges() {
+		cmd.Printf("Changes to %s:\n", name)
+		printKeymapChanges(cmd, changes)
+	}
+	return nil
+}
+
+// historyChanges returns the action-level changes between two recorded contents of the
+// onekeymap.json at path. Empty content is an empty keymap.
+func historyChanges(path, before, after string) (*importerapi.KeymapChanges, error) {
+	from, err := loadHistoryContent(path, before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := loadHistoryContent(path, after)
+	if err != nil {
+		return nil, err
+	}
+	return merge.Changes(from, to), nil
+}
+
+func loadHistoryContent(path, content string) (keymap.Keymap, error) {
+	if strings.TrimSpace(content) == "" {
+		return keymap.Keymap{}, nil
+	}
+	setting, err := keymap.Load(strings.NewReader(content), keymap.LoadOptions{Dir: filepath.Dir(path)})
+	if err != nil {
+		return keymap.Keymap{}, fmt.Errorf("failed to parse recorded version: %w", err)
+	}
+	return setting, nil
+}
@@ -14,6 +14,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+	"github.com/xinnjie/onekeymap-cli/internal/safewrite"
 	"github.com/xinnjie/onekeymap-cli/internal/views"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
@@ -29,6 +31,23 @@ type importFlags struct {
 	output      string
 	interactive bool
 	backup      bool
+	platform    platform.Platform
+	// modifierStrategy and keymapPlatform translate modifiers, e.g. cmd+c to ctrl+c.
+	modifierStrategy keymap.ModifierStrategy
+	keymapPlatform   platform.Platform
+}
+
+// outputPlatform is the platform the imported onekeymap.json is written for: --keymap-platform,
+// else --platform, else the current platform.
+func (f *importFlags) outputPlatform() platform.Platform {
+	switch {
+	case f.keymapPlatform != "":
+		return f.keymapPlatform
+	case f.platform != "":
+		return f.platform
+	default:
+		return platform.Current()
+	}
 }
 
 //nolint:dupl // Import/Export command constructors are intentionally symmetrical; limited duplication keeps each isolated and clearer
@@ -48,7 +67,9 @@ func NewCmdImport() *cobra.Command {
 	cmd.Flags().
 		StringVar(&f.input, "input", "", "Optional: Path to the source editor's config file (overrides env vars)")
 	cmd.Flags().BoolVar(&f.interactive, "interactive", true, "Run in interactive mode")
-	cmd.Flags().BoolVar(&f.backup, "backup", false, "Create a backup of the target editor's keymap")
+	cmd.Flags().BoolVar(&f.backup, "backup", false, "Create a backup of the existing onekeymap.json before writing")
+	addPlatformFlag(cmd, &f.platform, "Platform the source editor's keymap was written on")
+	addModifierFlags(cmd, &f.modifierStrategy, &f.keymapPlatform)
 
 	// Add completion for 'from' flag
 	_ = cmd.RegisterFlagCompletionFunc(
@@ -133,12 +154,15 @@ func executeImportInteractive(
 		}
 	}
 
-	baseConfig := loadBaseConfig(f.output, onekeymapConfig, logger)
+	baseConfig, original := loadBaseConfig(f.output, onekeymapConfig, logger)
 
 	opts := importerapi.ImportOptions{
-		EditorType:  pluginapi.EditorType(f.from),
-		InputStream: file,
-		Base:        baseConfig,
+		EditorType:       pluginapi.EditorType(f.from),
+		InputStream:      file,
+		Base:             baseConfig,
+		SourcePlatform:   f.platform,
+		ModifierStrategy: f.modifierStrategy,
+		KeymapPlatform:   f.keymapPlatform,
 	}
 
 	result, err := importService.Import(cmd.Context(), opts)
@@ -164,7 +188,7 @@ func executeImportInteractive(
 		cmd.Println("No changes to import - file will not be updated")
 	}
 
-	return saveImportResult(f.output, result, logger)
+	return saveImportResult(f, original, result, logger)
 }
 
 func executeImportNonInteractive(
@@ -193,12 +217,15 @@ func executeImportNonInteractive(
 		}
 	}
 
-	baseConfig := loadBaseConfig(f.output, onekeymapConfig, logger)
+	baseConfig, original := loadBaseConfig(f.output, onekeymapConfig, logger)
 
 	opts := importerapi.ImportOptions{
-		EditorType:  pluginapi.EditorType(f.from),
-		InputStream: file,
-		Base:        baseConfig,
+		EditorType:       pluginapi.EditorType(f.from),
+		InputStream:      file,
+		Base:             baseConfig,
+		SourcePlatform:   f.platform,
+		ModifierStrategy: f.modifierStrategy,
+		KeymapPlatform:   f.keymapPlatform,
 	}
 
 	result, err := importService.Import(cmd.Context(), opts)
@@ -211,60 +238,90 @@ func executeImportNonInteractive(
 		printImportSummary(cmd, result)
 	}
 
-	return saveImportResult(f.output, result, logger)
+	return saveImportResult(f, original, result, logger)
 }
 
-func loadBaseConfig(outputPath, onekeymapConfig string, logger *slog.Logger) keymap.Keymap {
+// loadBaseConfig loads the keymap imported changes are merged into. It also returns the file it was
+// read from, so that saving can detect edits made to it in the meantime; nil if there is none.
+func loadBaseConfig(outputPath, onekeymapConfig string, logger *slog.Logger) (keymap.Keymap, *safewrite.File) {
 	basePath := outputPath
 	if basePath == "" {
 		basePath = onekeymapConfig
 	}
 	if basePath == "" {
-		return keymap.Keymap{}
+		return keymap.Keymap{}, nil
 	}
 
-	baseConfigFile, err := os.Open(basePath)
+	original, err := safewrite.Open(basePath)
 	if err != nil {
+		logger.Warn("Failed to read base config file, skip loading base config", "path", basePath, "error", err)
+		return keymap.Keymap{}, nil
+	}
+	if !original.Exists() {
 		logger.Debug("Base config file not found, skip loading base config", "path", basePath)
-		return keymap.Keymap{}
+		return keymap.Keymap{}, original
 	}
-	defer func() { _ = baseConfigFile.Close() }()
 
-	cfg, lerr := keymap.Load(baseConfigFile, keymap.LoadOptions{})
+	cfg, lerr := keymap.Load(bytes.NewReader(original.Content()), keymap.LoadOptions{Dir: filepath.Dir(basePath)})
 	if lerr != nil {
 		logger.Warn("Failed to load base keymap, treat as no base config", "error", lerr)
-		return keymap.Keymap{}
+		return keymap.Keymap{}, original
 	}
 
-	return cfg
+	return cfg, original
 }
 
-func saveImportResult(outputPath string, result *importerapi.ImportResult, logger *slog.Logger) error {
-	if err := os.MkdirAll(filepath.Dir(outputPath), 0o750); err != nil {
-		logger.Error("Failed to create output directory", "dir", filepath.Dir(outputPath), "error", err)
-		return err
-	}
-	outputFile, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
-	if err != nil {
-		logger.Error("Failed to create output file", "error", err)
-		return err
-	}
-	defer func() {
-		_ = outputFile.Close()
-	}()
-
-	if result == nil || len(result.Setting.Actions) == 0 {
+// saveImportResult writes the imported keymap to f.output and records it in the history journal.
+// When original was read from f.output, the write is refused if the file changed since.
+func saveImportResult(
+	f *importFlags,
+	original *safewrite.File,
+	result *importerapi.ImportResult,
+	logger *slog.Logger,
+) error {
+	if result == nil || (len(result.Setting.Actions) == 0 && len(result.Setting.Unbind) == 0) {
 		logger.Warn("No keymaps imported; nothing to save")
 		return nil
 	}
 
-	// Use new API Save
-	saveOpt := keymap.SaveOptions{Platform: platform.PlatformMacOS}
-	if err := keymap.Save(outputFile, result.Setting, saveOpt); err != nil {
+	// Write modifiers the way the platform onekeymap.json is written for names them.
+	var buf bytes.Buffer
+	saveOpt := keymap.SaveOptions{Platform: f.outputPlatform()}
+	if err := keymap.Save(&buf, result.Setting, saveOpt); err != nil {
 		logger.Error("Failed to save config file", "error", err)
 		return err
 	}
 
+	outputPath := f.output
+	if f.backup {
+		if backupID, err := backupIfExists(backupEditorOnekeymap, outputPath, "import"); err != nil {
+			logger.Warn("Failed to backup existing file", "path", outputPath, "error", err)
+		} else if backupID != "" {
+			logger.Info("Created backup of existing config", "backup", backupID)
+		}
+	}
+	var before []byte
+	var err error
+	if original != nil && original.Path() == outputPath {
+		before = original.Content()
+		err = original.Write(buf.Bytes())
+	} else {
+		before, _ = os.ReadFile(outputPath)
+		err = safewrite.WriteFile(outputPath, buf.Bytes())
+	}
+	if err != nil {
+		logger.Error("Failed to write output file", "path", outputPath, "error", err)
+		return writeError(err)
+	}
+	if !bytes.Equal(before, buf.Bytes()) {
+		recordHistory(logger, outputPath, before, history.Entry{
+			Command: "import",
+			Editor:  f.from,
+			Changes: history.Summarize(result.Changes),
+			Content: buf.String(),
+		})
+	}
+
 	logger.Info("Successfully imported keymap", "output", outputPath)
 	if result.Report != nil {
 		logger.Debug("Import report", "report", result.Report)
@@ -363,6 +420,7 @@ func printImportSummary(cmd *cobra.Command, result *importerapi.ImportResult) {
 
 	cmd.Println()
 	cmd.Print(buf.String())
+	printModifierCollisions(cmd, result.Translation)
 }
 
 // renderValidationIssueInline renders a single validation issue in a compact textual form,
@@ -482,7 +540,7 @@ func prepareInteractiveImportFlags(
 			f.input = configPath
 			logger.Info("Using keymap path from config", "editor", f.from, "path", configPath)
 		} else {
-			v, _, err := p.ConfigDetect(pluginapi.ConfigDetectOptions{})
+			v, _, err := p.ConfigDetect(pluginapi.ConfigDetectOptions{Platform: f.platform})
 			if err != nil {
 				logger.Error("Failed to get default config path", "error", err)
 				return err
@@ -518,7 +576,7 @@ func prepareNonInteractiveImportFlags(
 			f.input = configPath
 			logger.Info("Using keymap path from config", "editor", f.from, "path", configPath)
 		} else {
-			v, _, err := p.ConfigDetect(pluginapi.ConfigDetectOptions{})
+			v, _, err := p.ConfigDetect(pluginapi.ConfigDetectOptions{Platform: f.platform})
 			if err != nil {
 				logger.Error("Failed to get default config path", "error", err)
 				return err
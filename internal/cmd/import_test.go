@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+func TestSaveImportResult_UsesSelectedPlatform(t *testing.T) {
+	kb, err := keybinding.NewKeybinding("meta+k", keybinding.ParseOption{Separator: "+"})
+	require.NoError(t, err)
+	result := &importerapi.ImportResult{Setting: keymap.Keymap{Actions: []keymap.Action{
+		{Name: "actions.file.save", Bindings: []keybinding.Keybinding{kb}},
+	}}}
+	output := filepath.Join(t.TempDir(), "onekeymap.json")
+	f := &importFlags{output: output, platform: platform.PlatformWindows}
+
+	require.NoError(t, saveImportResult(f, nil, result, slog.New(slog.NewTextHandler(io.Discard, nil))))
+	got, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `"win+k"`)
+}
@@ -3,16 +3,18 @@ package cmd
 import (
 	"bytes"
 	"errors"
-	"io"
 	"log/slog"
 	"os"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+	"github.com/xinnjie/onekeymap-cli/internal/safewrite"
 	"github.com/xinnjie/onekeymap-cli/internal/views"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/exporterapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/registry"
 )
@@ -24,6 +26,7 @@ type migrateFlags struct {
 	output      string
 	interactive bool
 	backup      bool
+	platform    platform.Platform
 }
 
 func NewCmdMigrate() *cobra.Command {
@@ -43,6 +46,7 @@ func NewCmdMigrate() *cobra.Command {
 	cmd.Flags().StringVar(&f.output, "output", "", "Path to target editor config")
 	cmd.Flags().BoolVar(&f.interactive, "interactive", true, "Run in interactive mode")
 	cmd.Flags().BoolVar(&f.backup, "backup", false, "Create a backup of the target editor's keymap")
+	addPlatformFlag(cmd, &f.platform, "Platform of both editors' keymaps")
 
 	return cmd
 }
@@ -86,7 +90,7 @@ func migrateRun(
 		}
 
 		if f.input == "" {
-			v, _, err := inputPlugin.ConfigDetect(pluginapi.ConfigDetectOptions{})
+			v, _, err := inputPlugin.ConfigDetect(pluginapi.ConfigDetectOptions{Platform: f.platform})
 			if err != nil {
 				logger.Error("failed to get default config path", "error", err)
 				return err
@@ -95,7 +99,7 @@ func migrateRun(
 		}
 
 		if f.output == "" {
-			v, _, err := outputPlugin.ConfigDetect(pluginapi.ConfigDetectOptions{})
+			v, _, err := outputPlugin.ConfigDetect(pluginapi.ConfigDetectOptions{Platform: f.platform})
 			if err != nil {
 				logger.Error("failed to get default config path", "error", err)
 				return err
@@ -111,8 +115,9 @@ func migrateRun(
 		defer func() { _ = inputStream.Close() }()
 
 		importOpts := importerapi.ImportOptions{
-			EditorType:  pluginapi.EditorType(f.from),
-			InputStream: inputStream,
+			EditorType:     pluginapi.EditorType(f.from),
+			InputStream:    inputStream,
+			SourcePlatform: f.platform,
 		}
 		importResult, err := importService.Import(ctx, importOpts)
 		if err != nil {
@@ -125,23 +130,27 @@ func migrateRun(
 			logger.Debug("Import Report", "report", importResult.Report)
 		}
 
-		if len(importResult.Setting.Actions) == 0 {
+		if len(importResult.Setting.Actions) == 0 && len(importResult.Setting.Unbind) == 0 {
 			logger.Warn("No imported keymaps to export; aborting migrate")
 			return nil
 		}
 
-		// Prepare base reader from existing output file if present for diff calculation
-		var base io.Reader
-		if file, err := os.Open(f.output); err == nil {
-			defer func() { _ = file.Close() }()
-			base = file
-		} else if !os.IsNotExist(err) {
-			logger.Warn("Failed to open existing output as base", "error", err)
+		// Read the existing output file as base for diff calculation; writing checks it is unchanged
+		original, err := safewrite.Open(f.output)
+		if err != nil {
+			logger.Error("failed to read existing output", "error", err)
+			return err
 		}
 
 		// Export to memory buffer first for preview, optional confirmation, and then write
 		var mem bytes.Buffer
-		exportOpts := exporterapi.ExportOptions{EditorType: pluginapi.EditorType(f.to), OriginalConfig: base}
+		exportOpts := exporterapi.ExportOptions{
+			EditorType:     pluginapi.EditorType(f.to),
+			TargetPlatform: f.platform,
+		}
+		if original.Exists() {
+			exportOpts.OriginalConfig = bytes.NewReader(original.Content())
+		}
 		exportReport, err := exportService.Export(ctx, &mem, importResult.Setting, exportOpts)
 		if err != nil {
 			logger.Error("migrate failed during export step", "error", err)
@@ -167,26 +176,22 @@ func migrateRun(
 
 		// Backup existing file if requested
 		if f.backup {
-			if backupPath, err := backupIfExists(f.output); err != nil {
+			if backupID, err := backupIfExists(f.to, f.output, "migrate"); err != nil {
 				logger.Warn("Failed to backup existing file", "path", f.output, "error", err)
-			} else if backupPath != "" {
-				logger.Info("Created backup of existing config", "backup", backupPath)
+			} else if backupID != "" {
+				logger.Info("Created backup of existing config", "backup", backupID)
 			}
 		}
 
 		// Write buffer to the target file
-		outputStream, err := os.OpenFile(f.output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
-		if err != nil {
-			logger.Error("failed to create output file", "error", err)
-			return err
-		}
-		defer func() { _ = outputStream.Close() }()
-		if _, err := outputStream.Write(mem.Bytes()); err != nil {
+		if err := original.Write(mem.Bytes()); err != nil {
 			logger.Error("failed to write to output file", "error", err)
-			return err
+			return writeError(err)
 		}
 
 		logger.Info("Migration complete!")
+		commitVersion(logger, "migrate from "+f.from+" to "+f.to, history.Summary{},
+			versionFile{editor: f.to, path: f.output})
 
 		return nil
 	}
@@ -35,6 +35,7 @@ var (
 	cmdImportService  importerapi.Importer
 	cmdExportService  exporterapi.Exporter
 	cmdLogger         *slog.Logger
+	cmdLogLevel       = new(slog.LevelVar)
 	cmdRecorder       metrics.Recorder
 	cmdMappingConfig  *mappings.MappingConfig
 	cmdUpdateMsgChan  <-chan string // Channel for async update check result
@@ -126,8 +127,9 @@ func rootPersistentPreRun(f *rootFlags) func(cmd *cobra.Command, _ []string) {
 		}
 
 		var handler slog.Handler
+		cmdLogLevel.Set(logLevel)
 		handlerOpts := &slog.HandlerOptions{
-			Level: logLevel,
+			Level: cmdLogLevel,
 			ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
 				if a.Key == slog.TimeKey {
 					return slog.Attr{}
@@ -172,6 +174,17 @@ func Execute() {
 	rootCmd.AddCommand(NewCmdMigrate())
 	rootCmd.AddCommand(NewCmdImport())
 	rootCmd.AddCommand(NewCmdExport())
+	rootCmd.AddCommand(NewCmdSync())
+	rootCmd.AddCommand(NewCmdWatch())
+	backupCmd := NewCmdBackup()
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(NewCmdBackupList())
+	backupCmd.AddCommand(NewCmdBackupShow())
+	backupCmd.AddCommand(NewCmdBackupRestore())
+	historyCmd := NewCmdHistory()
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(NewCmdHistoryShow())
+	rootCmd.AddCommand(NewCmdUndo())
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
@@ -0,0 +1,521 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/xinnjie/onekeymap-cli/internal/cliconfig"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+	"github.com/xinnjie/onekeymap-cli/internal/safewrite"
+	"github.com/xinnjie/onekeymap-cli/internal/syncstate"
+	"github.com/xinnjie/onekeymap-cli/internal/views"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/exporterapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/merge"
+	"github.com/xinnjie/onekeymap-cli/pkg/registry"
+)
+
+type syncFlags struct {
+	input       string
+	dryRun      bool
+	backup      bool
+	interactive bool
+	platform    platform.Platform
+}
+
+func NewCmdSync() *cobra.Command {
+	f := syncFlags{}
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Merge editor changes and export the universal keymap to every editor with sync_enabled",
+		Long: "Sync exports onekeymap.json to every editor with sync_enabled in config.yaml.\n" +
+			"Changes made directly in an editor since its last sync are merged into onekeymap.json first;\n" +
+			"entries changed differently in several places are resolved interactively before anything is written.",
+		RunE: syncRun(&f, func() (*slog.Logger, *registry.Registry, importerapi.Importer, exporterapi.Exporter) {
+			return cmdLogger, cmdPluginRegistry, cmdImportService, cmdExportService
+		}),
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVar(&f.input, "input", "", "Path to the source onekeymap.json file (defaults to config value)")
+	cmd.Flags().BoolVar(&f.dryRun, "dry-run", false, "Show the merge and the combined diff without writing anything")
+	cmd.Flags().BoolVar(&f.backup, "backup", false, "Create a backup of each editor's keymap before writing")
+	cmd.Flags().BoolVar(&f.interactive, "interactive", true, "Resolve conflicting changes interactively")
+	addPlatformFlag(cmd, &f.platform, "Platform to sync the editors' keymaps for")
+
+	return cmd
+}
+
+// syncTarget is one editor that sync exports to, with the outcome of each step.
+type syncTarget struct {
+	editor string
+	path   string
+
+	// snapshot is the editor's state after its last sync; hasSnapshot is false on the first sync.
+	snapshot    syncstate.Snapshot
+	hasSnapshot bool
+	// current is the editor's keymap as it imports now. It is only read when there is a snapshot.
+	current keymap.Keymap
+	// original is the editor's keymap file as read before merging; writes fail if it changes.
+	original *safewrite.File
+
+	report *exporterapi.ExportReport
+	backup string
+	err    error
+}
+
+// errSyncCanceled is returned when the user quits the conflict resolver.
+var errSyncCanceled = errors.New("sync canceled")
+
+func syncRun(
+	f *syncFlags,
+	dependencies func() (*slog.Logger, *registry.Registry, importerapi.Importer, exporterapi.Exporter),
+) func(cmd *cobra.Command, _ []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		logger, pluginRegistry, importService, exportService := dependencies()
+		// Failures are per editor and reported in the summary; usage would only bury it.
+		cmd.SilenceUsage = true
+
+		var cfg cliconfig.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+		editors := cfg.SyncEditors()
+		if len(editors) == 0 {
+			return errors.New("no editor has sync_enabled set in config.yaml")
+		}
+
+		input := f.input
+		if input == "" {
+			input = viper.GetString("onekeymap")
+		}
+		setting, original, err := loadKeymapFile(input)
+		if err != nil {
+			return err
+		}
+		snapshotDir := syncstate.Dir(input)
+
+		targets := make([]*syncTarget, len(editors))
+		for i, editor := range editors {
+			targets[i] = &syncTarget{editor: editor, path: cfg.Editors[editor].KeymapPath}
+		}
+		forEachTarget(targets, func(t *syncTarget) error {
+			return readEditor(cmd.Context(), t, f.platform, snapshotDir, pluginRegistry, importService)
+		})
+
+		merged, err := mergeEditorChanges(cmd, f, setting, targets)
+		if errors.Is(err, errSyncCanceled) {
+			cmd.Println("Sync canceled; nothing was written.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		changes := merge.Changes(setting, merged)
+		if !f.dryRun && (changes.HasChanges() || merge.DiffUnbind(setting, merged).HasChanges() ||
+			layersChanged(setting.Platforms, merged.Platforms) || layersChanged(setting.Editors, merged.Editors)) {
+			previous := original.Content()
+			if err := saveKeymapFile(original, merged); err != nil {
+				return err
+			}
+			recordHistory(logger, input, previous, history.Entry{
+				Command: "sync",
+				Editor:  strings.Join(changedEditors(targets), ","),
+				Changes: history.Summarize(changes),
+				Content: string(original.Content()),
+			})
+			logger.Info("Merged editor changes into onekeymap config", "path", input)
+		}
+
+		forEachTarget(targets, func(t *syncTarget) error {
+			return syncEditor(cmd.Context(), t, f, merged, snapshotDir, importService, exportService, logger)
+		})
+		if !f.dryRun {
+			commitSyncedEditors(logger, "sync", targets)
+		}
+
+		return printSyncResult(cmd, targets, f.dryRun)
+	}
+}
+
+// forEachTarget runs step in parallel for every target that has not failed yet, recording its error.
+func forEachTarget(targets []*syncTarget, step func(t *syncTarget) error) {
+	var wg sync.WaitGroup
+	for _, t := range targets {
+		if t.err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.err = step(t)
+		}()
+	}
+	wg.Wait()
+}
+
+// loadKeymapFile loads a onekeymap.json, returning the file too so that saveKeymapFile can detect
+// edits made to it in the meantime.
+func loadKeymapFile(path string) (keymap.Keymap, *safewrite.File, error) {
+	original, err := safewrite.Open(path)
+	if err != nil {
+		return keymap.Keymap{}, nil, fmt.Errorf("failed to open onekeymap config: %w", err)
+	}
+	if !original.Exists() {
+		return keymap.Keymap{}, nil, fmt.Errorf("failed to open onekeymap config: %w", os.ErrNotExist)
+	}
+
+	setting, err := keymap.Load(bytes.NewReader(original.Content()), keymap.LoadOptions{Dir: filepath.Dir(path)})
+	if err != nil {
+		return keymap.Keymap{}, nil, fmt.Errorf("failed to parse onekeymap config: %w", err)
+	}
+	return setting, original, nil
+}
+
+func saveKeymapFile(original *safewrite.File, setting keymap.Keymap) error {
+	var buf bytes.Buffer
+	if err := keymap.Save(&buf, setting, keymap.SaveOptions{Platform: platform.PlatformMacOS}); err != nil {
+		return fmt.Errorf("failed to encode onekeymap config: %w", err)
+	}
+	if err := original.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write onekeymap config: %w", writeError(err))
+	}
+	return nil
+}
+
+// commitSyncedEditors commits the keymaps that were written to editors, with git versioning on.
+func commitSyncedEditors(logger *slog.Logger, command string, targets []*syncTarget) {
+	var editors []string
+	var files []versionFile
+	for _, t := range targets {
+		if t.err == nil && t.report != nil && strings.TrimSpace(t.report.Diff) != "" {
+			editors = append(editors, t.editor)
+			files = append(files, versionFile{editor: t.editor, path: t.path})
+		}
+	}
+	if len(files) > 0 {
+		commitVersion(logger, command+" to "+strings.Join(editors, ", "), history.Summary{}, files...)
+	}
+}
+
+// layersChanged reports whether any override layer differs between before and after.
+func layersChanged[K comparable](before, after map[K]keymap.Overrides) bool {
+	changed := func(a, b keymap.Overrides) bool {
+		x, y := keymap.Keymap{Actions: a.Actions, Unbind: a.Unbind}, keymap.Keymap{Actions: b.Actions, Unbind: b.Unbind}
+		return merge.Changes(x, y).HasChanges() || merge.DiffUnbind(x, y).HasChanges()
+	}
+	for k, o := range after {
+		if changed(before[k], o) {
+			return true
+		}
+	}
+	for k, o := range before {
+		if _, ok := after[k]; !ok && changed(o, keymap.Overrides{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// changedEditors returns the editors with changes since their last sync.
+func changedEditors(targets []*syncTarget) []string {
+	var editors []string
+	for _, t := range targets {
+		if t.err == nil && t.changedSinceSync() {
+			editors = append(editors, t.editor)
+		}
+	}
+	return editors
+}
+
+// changedSinceSync reports whether the editor's keymap was changed since its last sync.
+func (t *syncTarget) changedSinceSync() bool {
+	return t.hasSnapshot && (merge.Changes(t.snapshot.Editor, t.current).HasChanges() ||
+		merge.DiffUnbind(t.snapshot.Editor, t.current).HasChanges())
+}
+
+// readEditor resolves the editor's keymap path on p and, when the editor was synced before, reads
+// its snapshot and current keymap.
+func readEditor(
+	ctx context.Context,
+	t *syncTarget,
+	p platform.Platform,
+	snapshotDir string,
+	pluginRegistry *registry.Registry,
+	importService importerapi.Importer,
+) error {
+	if err := resolveEditorPath(t, p, pluginRegistry); err != nil {
+		return err
+	}
+	return readSnapshot(ctx, t, p, snapshotDir, importService)
+}
+
+// readSnapshot reads the editor's keymap file and, when the editor was synced before, its snapshot
+// and current keymap.
+func readSnapshot(
+	ctx context.Context,
+	t *syncTarget,
+	p platform.Platform,
+	snapshotDir string,
+	importService importerapi.Importer,
+) error {
+	var err error
+	if t.original, err = safewrite.Open(t.path); err != nil {
+		return fmt.Errorf("failed to read existing keymap: %w", err)
+	}
+	t.snapshot, t.hasSnapshot, err = syncstate.Load(snapshotDir, t.editor)
+	if err != nil || !t.hasSnapshot {
+		return err
+	}
+	if !t.original.Exists() {
+		// The keymap was deleted; there is nothing to merge back and the export recreates it.
+		t.hasSnapshot = false
+		return nil
+	}
+	t.current, err = importKeymap(ctx, importService, t.editor, p, t.original.Content())
+	return err
+}
+
+// resolveEditorPath detects the editor's keymap path on platform p unless config.yaml sets it.
+func resolveEditorPath(t *syncTarget, p platform.Platform, pluginRegistry *registry.Registry) error {
+	plugin, ok := pluginRegistry.Get(pluginapi.EditorType(t.editor))
+	if !ok {
+		return fmt.Errorf("editor %s not found", t.editor)
+	}
+	if t.path != "" {
+		return nil
+	}
+	paths, _, err := plugin.ConfigDetect(pluginapi.ConfigDetectOptions{Platform: p})
+	if err != nil {
+		return fmt.Errorf("failed to detect keymap path: %w", err)
+	}
+	if len(paths) == 0 {
+		return errors.New("no keymap path detected; set keymap_path in config.yaml")
+	}
+	t.path = paths[0]
+	return nil
+}
+
+func importKeymap(
+	ctx context.Context,
+	importService importerapi.Importer,
+	editor string,
+	p platform.Platform,
+	data []byte,
+) (keymap.Keymap, error) {
+	res, err := importService.Import(ctx, importerapi.ImportOptions{
+		EditorType:     pluginapi.EditorType(editor),
+		InputStream:    bytes.NewReader(data),
+		SourcePlatform: p,
+	})
+	if err != nil {
+		return keymap.Keymap{}, fmt.Errorf("failed to import current keymap: %w", err)
+	}
+	return res.Setting, nil
+}
+
+// mergeEditorChanges merges the changes made in editors since their last sync into setting, asking
+// the user to resolve conflicts.
+func mergeEditorChanges(
+	cmd *cobra.Command,
+	f *syncFlags,
+	setting keymap.Keymap,
+	targets []*syncTarget,
+) (keymap.Keymap, error) {
+	p := f.platform
+	if p == "" {
+		p = platform.Current()
+	}
+	var edits []merge.Edits
+	for _, t := range targets {
+		if t.err != nil || !t.hasSnapshot {
+			continue
+		}
+		edits = append(edits,
+			merge.Edits{Source: merge.SourceOnekeymap, Changes: merge.Changes(t.snapshot.Keymap, setting)},
+			merge.Edits{
+				Source:   t.editor,
+				Changes:  merge.Changes(t.snapshot.Editor, t.current),
+				Unbind:   merge.DiffUnbind(t.snapshot.Editor, t.current),
+				Platform: p,
+			},
+		)
+	}
+	if len(edits) == 0 {
+		return setting, nil
+	}
+
+	result := merge.ThreeWay(setting, edits)
+	if result.Applied.HasChanges() || result.AppliedUnbind.HasChanges() {
+		cmd.Println("Merging changes made in editors since the last sync:")
+		printKeymapChanges(cmd, result.Applied)
+		printUnbindChanges(cmd, result.AppliedUnbind)
+	}
+	if len(result.Conflicts) == 0 {
+		return result.Keymap, nil
+	}
+
+	switch {
+	case f.dryRun:
+		cmd.Printf("%d entries were changed differently in several places and need resolving:\n", len(result.Conflicts))
+		for _, c := range result.Conflicts {
+			cmd.Printf("  ! %s\n", c.Name)
+		}
+		return result.Keymap, nil
+	case !f.interactive:
+		return keymap.Keymap{}, fmt.Errorf(
+			"%d entries were changed differently in several places; run sync interactively to resolve them",
+			len(result.Conflicts),
+		)
+	}
+
+	choices := make([]int, len(result.Conflicts))
+	confirmed := false
+	m := views.NewSyncConflictsModel(result.Conflicts, choices, &confirmed)
+	if _, err := tea.NewProgram(m).Run(); err != nil {
+		return keymap.Keymap{}, fmt.Errorf("failed to run conflict resolver: %w", err)
+	}
+	if !confirmed {
+		return keymap.Keymap{}, errSyncCanceled
+	}
+	return result.Resolve(choices), nil
+}
+
+// printUnbindChanges prints one line per key added to (+) or removed from (-) the keymap-level unbinds.
+func printUnbindChanges(cmd *cobra.Command, changes merge.UnbindChanges) {
+	format := keybinding.FormatOption{Platform: platform.PlatformMacOS, Separator: "+"}
+	for _, kb := range changes.Add {
+		cmd.Printf("  + unbind %s\n", kb.String(format))
+	}
+	for _, kb := range changes.Remove {
+		cmd.Printf("  - unbind %s\n", kb.String(format))
+	}
+}
+
+// syncEditor exports setting to one editor and, unless this is a dry run, writes it and records
+// the editor's new snapshot.
+func syncEditor(
+	ctx context.Context,
+	t *syncTarget,
+	f *syncFlags,
+	setting keymap.Keymap,
+	snapshotDir string,
+	importService importerapi.Importer,
+	exportService exporterapi.Exporter,
+	logger *slog.Logger,
+) error {
+	var err error
+	if t.original == nil {
+		if t.original, err = safewrite.Open(t.path); err != nil {
+			return fmt.Errorf("failed to read existing keymap: %w", err)
+		}
+	}
+	opts := exporterapi.ExportOptions{
+		EditorType:     pluginapi.EditorType(t.editor),
+		DiffType:       exporterapi.DiffTypeASCII,
+		FilePath:       t.path,
+		TargetPlatform: f.platform,
+	}
+	if t.original.Exists() {
+		opts.OriginalConfig = bytes.NewReader(t.original.Content())
+	}
+
+	var mem bytes.Buffer
+	t.report, err = exportService.Export(ctx, &mem, setting, opts)
+	if err != nil {
+		return err
+	}
+	if f.dryRun {
+		return nil
+	}
+
+	if f.backup {
+		if t.backup, err = backupIfExists(t.editor, t.path, "sync"); err != nil {
+			logger.Warn("Failed to backup existing file", "editor", t.editor, "path", t.path, "error", err)
+		}
+	}
+	if err := t.original.Write(mem.Bytes()); err != nil {
+		return fmt.Errorf("failed to write keymap: %w", writeError(err))
+	}
+
+	// Without a snapshot the next sync exports one way only, so a failure here is not fatal.
+	exported, err := importKeymap(ctx, importService, t.editor, f.platform, mem.Bytes())
+	if err != nil {
+		logger.Warn("Failed to read back exported keymap", "editor", t.editor, "error", err)
+		return nil
+	}
+	if err := syncstate.Save(snapshotDir, t.editor, syncstate.Snapshot{Keymap: setting, Editor: exported}); err != nil {
+		logger.Warn("Failed to save sync snapshot", "editor", t.editor, "error", err)
+	}
+	return nil
+}
+
+// printSyncResult prints the diff of every editor followed by a combined summary, and returns an
+// error when any editor failed.
+func printSyncResult(cmd *cobra.Command, targets []*syncTarget, dryRun bool) error {
+	for _, t := range targets {
+		if t.report == nil {
+			continue
+		}
+		cmd.Printf("================ %s (%s) ================\n", t.editor, t.path)
+		if strings.TrimSpace(t.report.Diff) != "" {
+			cmd.Println(t.report.Diff)
+		} else {
+			cmd.Println("(no changes)")
+		}
+	}
+
+	cmd.Println()
+	if dryRun {
+		cmd.Println("Sync Summary (dry run, nothing written):")
+	} else {
+		cmd.Println("Sync Summary:")
+	}
+	var failed int
+	for _, t := range targets {
+		if t.err != nil {
+			failed++
+			cmd.Printf("  ✗ %s: %v\n", t.editor, t.err)
+			continue
+		}
+		cov := t.report.Coverage
+		line := fmt.Sprintf("  ✓ %s: %d/%d actions fully exported", t.editor, cov.FullyExported, cov.TotalActions)
+		if n := len(cov.PartiallyExported); n > 0 {
+			line += fmt.Sprintf(", %d partially", n)
+		}
+		if n := countSkippedActions(t.report); n > 0 {
+			line += fmt.Sprintf(", %d skipped", n)
+		}
+		if t.backup != "" {
+			line += fmt.Sprintf(" (backup: %s)", t.backup)
+		}
+		cmd.Println(line)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("sync failed for %d of %d editors", failed, len(targets))
+	}
+	return nil
+}
+
+func countSkippedActions(report *exporterapi.ExportReport) int {
+	seen := make(map[string]struct{}, len(report.SkipActions))
+	for _, sk := range report.SkipActions {
+		seen[sk.Action] = struct{}{}
+	}
+	return len(seen)
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/exporterapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/exporter"
+	"github.com/xinnjie/onekeymap-cli/pkg/importer"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+	"github.com/xinnjie/onekeymap-cli/pkg/registry"
+)
+
+const syncTestKeymap = `{
+  "version": "1.0",
+  "keymaps": [
+    {"id": "actions.clipboard.copy", "keybinding": "cmd+shift+c"},
+    {"id": "actions.file.save", "keybinding": "cmd+s"}
+  ]
+}`
+
+// testServices are the real plugin registry and services, as the commands get them from root.
+type testServices struct {
+	logger        *slog.Logger
+	mappingConfig *mappings.MappingConfig
+	registry      *registry.Registry
+	importer      importerapi.Importer
+	exporter      exporterapi.Exporter
+}
+
+func newTestServices(t *testing.T) testServices {
+	t.Helper()
+	mappingConfig, err := mappings.NewMappingConfig()
+	require.NoError(t, err)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	reg := registry.NewRegistryWithPlugins(mappingConfig, logger, metrics.NewNoop())
+	return testServices{
+		logger:        logger,
+		mappingConfig: mappingConfig,
+		registry:      reg,
+		importer:      importer.NewImporter(reg, mappingConfig, logger, metrics.NewNoop()),
+		exporter:      exporter.NewExporter(reg, mappingConfig, logger, metrics.NewNoop()),
+	}
+}
+
+// setupSync writes onekeymap.json and configures vscode and zed for sync in a temporary directory.
+func setupSync(t *testing.T) (input, vscodePath, zedPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	input = filepath.Join(dir, "onekeymap.json")
+	require.NoError(t, os.WriteFile(input, []byte(syncTestKeymap), 0o600))
+	vscodePath = filepath.Join(dir, "keybindings.json")
+	zedPath = filepath.Join(dir, "zed", "keymap.json")
+
+	t.Cleanup(viper.Reset)
+	viper.Set("onekeymap", input)
+	viper.Set("editors", map[string]any{
+		"vscode": map[string]any{"keymap_path": vscodePath, "sync_enabled": true},
+		"zed":    map[string]any{"keymap_path": zedPath, "sync_enabled": true},
+	})
+	return input, vscodePath, zedPath
+}
+
+func runSync(t *testing.T, f syncFlags) (string, error) {
+	t.Helper()
+	s := newTestServices(t)
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	run := syncRun(&f, func() (*slog.Logger, *registry.Registry, importerapi.Importer, exporterapi.Exporter) {
+		return s.logger, s.registry, s.importer, s.exporter
+	})
+	err := run(cmd, nil)
+	return out.String(), err
+}
+
+func TestSync_DryRun(t *testing.T) {
+	_, vscodePath, zedPath := setupSync(t)
+
+	out, err := runSync(t, syncFlags{dryRun: true})
+	require.NoError(t, err)
+	assert.Contains(t, out, "dry run, nothing written")
+	assert.NoFileExists(t, vscodePath)
+	assert.NoFileExists(t, zedPath)
+}
+
+func TestSync_FailsWhenOneEditorFails(t *testing.T) {
+	_, vscodePath, zedPath := setupSync(t)
+	// The zed keymap cannot be read: its directory is a file.
+	require.NoError(t, os.WriteFile(filepath.Dir(zedPath), nil, 0o600))
+
+	out, err := runSync(t, syncFlags{})
+	require.Error(t, err)
+	assert.Contains(t, out, "✗ zed")
+	assert.FileExists(t, vscodePath, "the other editors are still synced")
+}
+
+func TestSync_NonInteractiveConflict(t *testing.T) {
+	input, vscodePath, _ := setupSync(t)
+	_, err := runSync(t, syncFlags{})
+	require.NoError(t, err)
+
+	// Change the copy shortcut differently in onekeymap.json and in VSCode.
+	exported, err := os.ReadFile(vscodePath)
+	require.NoError(t, err)
+	require.Contains(t, string(exported), "shift+c")
+	edited := strings.ReplaceAll(string(exported), "shift+c", "alt+c")
+	require.NoError(t, os.WriteFile(vscodePath, []byte(edited), 0o600))
+	require.NoError(t, os.WriteFile(input,
+		[]byte(strings.ReplaceAll(syncTestKeymap, "cmd+shift+c", "cmd+ctrl+c")), 0o600))
+
+	_, err = runSync(t, syncFlags{})
+	require.ErrorContains(t, err, "changed differently in several places")
+
+	got, err := os.ReadFile(vscodePath)
+	require.NoError(t, err)
+	assert.Equal(t, edited, string(got), "nothing is written while conflicts are unresolved")
+}
+
+func TestSync_EditorOverrideStaysInItsLayer(t *testing.T) {
+	input, vscodePath, zedPath := setupSync(t)
+	layered := strings.Replace(syncTestKeymap, "\n}", `,
+  "editors": {"zed": {"keymaps": [{"id": "actions.clipboard.copy", "keybinding": "cmd+alt+c"}]}}
+}`, 1)
+	require.NoError(t, os.WriteFile(input, []byte(layered), 0o600))
+	_, err := runSync(t, syncFlags{platform: platform.PlatformMacOS})
+	require.NoError(t, err)
+	vscodeBefore, err := os.ReadFile(vscodePath)
+	require.NoError(t, err)
+
+	// A sync without edits leaves onekeymap.json as it is.
+	_, err = runSync(t, syncFlags{platform: platform.PlatformMacOS})
+	require.NoError(t, err)
+	got, err := os.ReadFile(input)
+	require.NoError(t, err)
+	assert.Equal(t, layered, string(got))
+
+	// Change the overridden copy shortcut in Zed.
+	exported, err := os.ReadFile(zedPath)
+	require.NoError(t, err)
+	require.Contains(t, string(exported), `"cmd-alt-c"`)
+	edited := strings.ReplaceAll(string(exported), `"cmd-alt-c"`, `"cmd-ctrl-c"`)
+	require.NoError(t, os.WriteFile(zedPath, []byte(edited), 0o600))
+	_, err = runSync(t, syncFlags{platform: platform.PlatformMacOS})
+	require.NoError(t, err)
+
+	setting, _, err := loadKeymapFile(input)
+	require.NoError(t, err)
+	copyKeys := func(actions []keymap.Action) string {
+		for _, a := range actions {
+			if a.Name == "actions.clipboard.copy" {
+				return formatActionKeys(a)
+			}
+		}
+		return ""
+	}
+	assert.Equal(t, "cmd+shift+c", copyKeys(setting.Actions), "the top level is unchanged")
+	assert.Equal(t, "cmd+ctrl+c", copyKeys(setting.Editors["zed"].Actions))
+	vscodeAfter, err := os.ReadFile(vscodePath)
+	require.NoError(t, err)
+	assert.Equal(t, string(vscodeBefore), string(vscodeAfter), "other editors are not affected")
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+	"github.com/xinnjie/onekeymap-cli/internal/safewrite"
+)
+
+type undoFlags struct {
+	input       string
+	interactive bool
+}
+
+func NewCmdUndo() *cobra.Command {
+	f := undoFlags{}
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Revert onekeymap.json to its previous version",
+		Long: `Revert onekeymap.json to the version recorded before the current one in its history journal.
+Repeated undos walk further back. Edits made to the file outside onekeymap-cli are recorded
+first, so they stay in the history.`,
+		RunE: undoRun(&f, func() *slog.Logger {
+			return cmdLogger
+		}),
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVar(&f.input, "input", "", "Path to the onekeymap.json file (defaults to config value)")
+	cmd.Flags().BoolVar(&f.interactive, "interactive", true, "Confirm before overwriting the file")
+
+	return cmd
+}
+
+func undoRun(f *undoFlags, dependencies func() *slog.Logger) func(cmd *cobra.Command, _ []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		logger := dependencies()
+		input := historyInput(f.input)
+		journal := history.Path(input)
+
+		original, err := safewrite.Open(input)
+		if err != nil {
+			return fmt.Errorf("failed to open onekeymap config: %w", err)
+		}
+		entries, err := history.List(journal)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			cmd.Printf("Nothing to undo: no history recorded for %s\n", input)
+			return nil
+		}
+
+		// Edits made outside onekeymap-cli become a pending version, recorded with the undo only once
+		// it is confirmed, so that canceling leaves the journal untouched.
+		var pending []history.Entry
+		current, _ := history.Get(entries, history.Current(entries))
+		if !bytes.Equal(original.Content(), []byte(current.Content)) {
+			changes, err := historyChanges(input, current.Content, string(original.Content()))
+			if err != nil {
+				return err
+			}
+			pending = append(pending, history.Entry{
+				Seq:     entries[len(entries)-1].Seq + 1,
+				Command: history.CommandEdit,
+				Changes: history.Summarize(changes),
+				Content: string(original.Content()),
+			})
+		}
+
+		prev, err := history.Previous(append(entries, pending...))
+		if errors.Is(err, history.ErrNothingToUndo) {
+			cmd.Println("Nothing to undo: the current version is the oldest recorded.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		changes, err := historyChanges(input, string(original.Content()), prev.Content)
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Reverting %s to version %d (%s):\n", input, prev.Seq, prev.Command)
+		if changes.HasChanges() {
+			printKeymapChanges(cmd, changes)
+		} else {
+			cmd.Println("  No action changes.")
+		}
+		if f.interactive {
+			if !confirm(cmd, input) {
+				cmd.Println("Undo canceled; no changes were written.")
+				return nil
+			}
+		}
+
+		for _, edit := range pending {
+			commitVersion(logger, historySubject(edit), edit.Changes, versionFile{path: input})
+		}
+		if err := original.Write([]byte(prev.Content)); err != nil {
+			return fmt.Errorf("failed to write onekeymap config: %w", writeError(err))
+		}
+		recorded, err := history.Append(journal, append(pending, history.Entry{
+			Command:  history.CommandUndo,
+			Changes:  history.Summarize(changes),
+			Restores: prev.Seq,
+			Content:  prev.Content,
+		})...)
+		if err != nil {
+			return fmt.Errorf("reverted %s but failed to record it in the history: %w", input, err)
+		}
+		entry := recorded[len(recorded)-1]
+		logger.Info("Reverted onekeymap config", "path", input, "version", prev.Seq, "history", entry.Seq)
+		commitVersion(logger, historySubject(entry), entry.Changes, versionFile{path: input})
+		cmd.Printf("Reverted to version %d\n", prev.Seq)
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+)
+
+func testKeymapJSON(key string) string {
+	return `{"version": "1.0", "keymaps": [{"id": "actions.file.save", "keybinding": "` + key + `"}]}`
+}
+
+// setupUndo writes a onekeymap.json whose journal holds a baseline (cmd+s) and an import (cmd+shift+s),
+// and then edits the file by hand (alt+s).
+func setupUndo(t *testing.T) (string, string) {
+	t.Helper()
+	input := filepath.Join(t.TempDir(), "onekeymap.json")
+	journal := history.Path(input)
+	_, err := history.Record(journal, []byte(testKeymapJSON("cmd+s")),
+		history.Entry{Command: "import", Content: testKeymapJSON("cmd+shift+s")})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(input, []byte(testKeymapJSON("alt+s")), 0o600))
+	return input, journal
+}
+
+func runUndo(t *testing.T, f undoFlags, stdin string) string {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	run := undoRun(&f, func() *slog.Logger { return slog.New(slog.NewTextHandler(io.Discard, nil)) })
+	require.NoError(t, run(cmd, nil))
+	return out.String()
+}
+
+func TestUndo_Canceled(t *testing.T) {
+	input, journal := setupUndo(t)
+	before, err := os.ReadFile(journal)
+	require.NoError(t, err)
+
+	out := runUndo(t, undoFlags{input: input, interactive: true}, "n\n")
+	assert.Contains(t, out, "Undo canceled")
+
+	after, err := os.ReadFile(journal)
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after), "canceling leaves the journal untouched")
+	got, err := os.ReadFile(input)
+	require.NoError(t, err)
+	assert.Equal(t, testKeymapJSON("alt+s"), string(got))
+}
+
+func TestUndo_Confirmed(t *testing.T) {
+	input, journal := setupUndo(t)
+
+	out := runUndo(t, undoFlags{input: input, interactive: true}, "y\n")
+	assert.Contains(t, out, "Reverted to version 2")
+
+	entries, err := history.List(journal)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, history.CommandEdit, entries[2].Command, "the hand edit is kept in the history")
+	assert.Equal(t, history.CommandUndo, entries[3].Command)
+	assert.Equal(t, 2, entries[3].Restores)
+	got, err := os.ReadFile(input)
+	require.NoError(t, err)
+	assert.Equal(t, testKeymapJSON("cmd+shift+s"), string(got))
+
+	// Undoing again goes back past the import, not to the hand edit.
+	runUndo(t, undoFlags{input: input}, "")
+	got, err = os.ReadFile(input)
+	require.NoError(t, err)
+	assert.Equal(t, testKeymapJSON("cmd+s"), string(got))
+}
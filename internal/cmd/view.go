@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -13,11 +14,15 @@ import (
 	"github.com/spf13/viper"
 	"github.com/xinnjie/onekeymap-cli/internal/views"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
 	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
 )
 
 type viewFlags struct {
-	file string
+	file      string
+	effective bool
+	platform  platform.Platform
+	editor    string
 }
 
 func NewCmdView() *cobra.Command {
@@ -32,6 +37,16 @@ func NewCmdView() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&f.file, "file", "", "Path to onekeymap.json (defaults to config value)")
+	cmd.Flags().BoolVar(&f.effective, "effective", false,
+		"Print the fully resolved keymap (extends and overrides applied) as JSON and exit")
+	addPlatformFlag(cmd, &f.platform, "Platform whose overrides --effective applies")
+	cmd.Flags().StringVar(&f.editor, "editor", "", "Editor whose overrides --effective applies (default none)")
+	_ = cmd.RegisterFlagCompletionFunc(
+		"editor",
+		func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return cmdPluginRegistry.GetNames(), cobra.ShellCompDirectiveNoFileComp
+		},
+	)
 
 	return cmd
 }
@@ -40,8 +55,11 @@ func viewRun(
 	f *viewFlags,
 	dependencies func() (*mappings.MappingConfig, *slog.Logger),
 ) func(_ *cobra.Command, _ []string) error {
-	return func(_ *cobra.Command, _ []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
 		mappingConfig, logger := dependencies()
+		if !f.effective && (f.platform != "" || f.editor != "") {
+			return errors.New("--platform and --editor only apply to --effective")
+		}
 		path := f.file
 		if path == "" {
 			path = viper.GetString("onekeymap")
@@ -69,11 +87,19 @@ func viewRun(
 		}
 		defer func() { _ = file.Close() }()
 
-		setting, err := keymap.Load(file, keymap.LoadOptions{})
+		setting, err := keymap.Load(file, keymap.LoadOptions{Dir: filepath.Dir(absPath)})
 		if err != nil {
 			return fmt.Errorf("failed to parse onekeymap config: %w", err)
 		}
 
+		if f.effective {
+			p := f.platform
+			if p == "" {
+				p = platform.Current()
+			}
+			return keymap.Save(cmd.OutOrStdout(), setting.Resolve(p, f.editor), keymap.SaveOptions{Platform: p})
+		}
+
 		m := views.NewKeymapViewModel(setting, mappingConfig, absPath)
 		p := tea.NewProgram(m)
 
@@ -85,13 +111,29 @@ func viewRun(
 }
 
 // watchFile monitors the file for changes and sends messages to the tea program.
-// It watches the parent directory to handle atomic writes (used by editors like Helix, Vim, etc.)
-// where the file is replaced via rename operations.
 func watchFile(logger *slog.Logger, path string, p *tea.Program) {
+	err := watchFileChanges(context.Background(), logger, path,
+		func(name string) { p.Send(views.FileChangedMsg{Path: name}) },
+		func(err error) { p.Send(views.FileErrorMsg{Err: err}) },
+	)
+	if err != nil {
+		p.Send(views.FileErrorMsg{Err: err})
+	}
+}
+
+// watchFileChanges calls onChange for every change to the file until ctx is done, and onError for
+// watcher errors. It watches the parent directory to handle atomic writes (used by editors like
+// Helix, Vim, etc.) where the file is replaced via rename operations.
+func watchFileChanges(
+	ctx context.Context,
+	logger *slog.Logger,
+	path string,
+	onChange func(name string),
+	onError func(err error),
+) error {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
-		p.Send(views.FileErrorMsg{Err: fmt.Errorf("failed to create watcher: %w", err)})
-		return
+		return fmt.Errorf("failed to create watcher: %w", err)
 	}
 	defer watcher.Close()
 
@@ -101,15 +143,16 @@ func watchFile(logger *slog.Logger, path string, p *tea.Program) {
 	logger.Debug("watching config file", "file", path, "dir", dir)
 
 	if err := watcher.Add(dir); err != nil {
-		p.Send(views.FileErrorMsg{Err: fmt.Errorf("failed to watch directory: %w", err)})
-		return
+		return fmt.Errorf("failed to watch directory: %w", err)
 	}
 
 	for {
 		select {
+		case <-ctx.Done():
+			return nil
 		case event, ok := <-watcher.Events:
 			if !ok {
-				return
+				return nil
 			}
 
 			// Only process events for our target file
@@ -127,14 +170,14 @@ func watchFile(logger *slog.Logger, path string, p *tea.Program) {
 				logger.Debug("file event detected",
 					"path", event.Name,
 					"op", event.Op.String())
-				p.Send(views.FileChangedMsg{Path: event.Name})
+				onChange(event.Name)
 			}
 		case err, ok := <-watcher.Errors:
 			if !ok {
-				return
+				return nil
 			}
 			logger.Error("file watcher error", "error", err)
-			p.Send(views.FileErrorMsg{Err: err})
+			onError(err)
 		}
 	}
 }
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+func TestView_EffectiveAppliesPlatformAndEditor(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "onekeymap.json")
+	require.NoError(t, os.WriteFile(input, []byte(`{
+  "keymaps": [{"id": "actions.edit.copy", "keybinding": "meta+c"}],
+  "platforms": {"linux": {"keymaps": [{"id": "actions.edit.copy", "keybinding": "ctrl+c"}]}},
+  "editors": {"zed": {"keymaps": [{"id": "actions.edit.save", "keybinding": "ctrl+s"}]}}
+}`), 0o600))
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	f := viewFlags{file: input, effective: true, platform: platform.PlatformLinux, editor: "zed"}
+	run := viewRun(&f, func() (*mappings.MappingConfig, *slog.Logger) {
+		s := newTestServices(t)
+		return s.mappingConfig, s.logger
+	})
+	require.NoError(t, run(cmd, nil))
+	assert.JSONEq(t, `{
+  "version": "1.0",
+  "keymaps": [
+    {"id": "actions.edit.copy", "keybinding": "ctrl+c"},
+    {"id": "actions.edit.save", "keybinding": "ctrl+s"}
+  ]
+}`, out.String())
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/xinnjie/onekeymap-cli/internal/cliconfig"
+	"github.com/xinnjie/onekeymap-cli/internal/syncstate"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/exporterapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/validateapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/registry"
+	"github.com/xinnjie/onekeymap-cli/pkg/validate"
+)
+
+type watchFlags struct {
+	input    string
+	editors  []string
+	debounce time.Duration
+	backup   bool
+	platform platform.Platform
+}
+
+func NewCmdWatch() *cobra.Command {
+	f := watchFlags{}
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Re-export the universal keymap to the configured editors whenever it changes",
+		Long: "Watch onekeymap.json and, on every valid save, export it to every editor with sync_enabled\n" +
+			"in config.yaml (or the editors given with --editor). Saves that fail to parse or validate are skipped,\n" +
+			"and so are editors whose keymap was changed since the last sync until sync merges the change.",
+		RunE: watchRun(&f, func() (
+			*slog.Logger,
+			*slog.LevelVar,
+			*registry.Registry,
+			*mappings.MappingConfig,
+			importerapi.Importer,
+			exporterapi.Exporter,
+		) {
+			return cmdLogger, cmdLogLevel, cmdPluginRegistry, cmdMappingConfig, cmdImportService, cmdExportService
+		}),
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVar(&f.input, "input", "", "Path to the source onekeymap.json file (defaults to config value)")
+	cmd.Flags().StringSliceVar(&f.editors, "editor", nil,
+		"Editor to export to; repeat for several (defaults to editors with sync_enabled)")
+	cmd.Flags().DurationVar(&f.debounce, "debounce", 500*time.Millisecond,
+		"Wait this long after the last change before re-exporting")
+	cmd.Flags().BoolVar(&f.backup, "backup", false, "Create a backup of each editor's keymap before writing")
+	addPlatformFlag(cmd, &f.platform, "Platform to export the editors' keymaps for")
+
+	return cmd
+}
+
+func watchRun(
+	f *watchFlags,
+	dependencies func() (
+		*slog.Logger,
+		*slog.LevelVar,
+		*registry.Registry,
+		*mappings.MappingConfig,
+		importerapi.Importer,
+		exporterapi.Exporter,
+	),
+) func(cmd *cobra.Command, _ []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		logger, logLevel, pluginRegistry, mappingConfig, importService, exportService := dependencies()
+		cmd.SilenceUsage = true
+		// The log is all watch prints, so report re-exports unless --verbose or --quiet chose a level.
+		if logLevel.Level() == slog.LevelWarn {
+			logLevel.Set(slog.LevelInfo)
+		}
+
+		var cfg cliconfig.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+		editors := f.editors
+		if len(editors) == 0 {
+			editors = cfg.SyncEditors()
+		}
+		if len(editors) == 0 {
+			return errors.New("no editor to export to; pass --editor or set sync_enabled in config.yaml")
+		}
+
+		input := f.input
+		if input == "" {
+			input = viper.GetString("onekeymap")
+		}
+		absPath, err := filepath.Abs(input)
+		if err != nil {
+			return fmt.Errorf("failed to resolve absolute path: %w", err)
+		}
+
+		paths := make(map[string]string, len(editors))
+		for _, editor := range editors {
+			t := &syncTarget{editor: editor, path: cfg.Editors[editor].KeymapPath}
+			if err := resolveEditorPath(t, f.platform, pluginRegistry); err != nil {
+				return fmt.Errorf("%s: %w", editor, err)
+			}
+			paths[editor] = t.path
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		changed := make(chan struct{}, 1)
+		watchErr := make(chan error, 1)
+		go func() {
+			watchErr <- watchFileChanges(ctx, logger, absPath,
+				func(string) {
+					select {
+					case changed <- struct{}{}:
+					default:
+					}
+				},
+				func(error) {}, // already logged by watchFileChanges
+			)
+		}()
+
+		w := &watcher{
+			path:          absPath,
+			editors:       editors,
+			paths:         paths,
+			flags:         syncFlags{backup: f.backup, platform: f.platform},
+			validator:     newWatchValidator(mappingConfig),
+			importService: importService,
+			exportService: exportService,
+			logger:        logger,
+		}
+		logger.Info("Watching onekeymap config", "path", absPath, "editors", editors)
+
+		// Editors usually save in several steps; export once the file has been quiet for the debounce delay.
+		timer := time.NewTimer(f.debounce)
+		timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Stopped watching onekeymap config", "path", absPath)
+				return nil
+			case err := <-watchErr:
+				return err
+			case <-changed:
+				timer.Reset(f.debounce)
+			case <-timer.C:
+				w.reexport(ctx)
+			}
+		}
+	}
+}
+
+func newWatchValidator(mappingConfig *mappings.MappingConfig) *validateapi.Validator {
+	return validateapi.NewValidator(
+		validate.NewKeybindConflictRule(),
+		validate.NewDanglingActionRule(mappingConfig),
+	)
+}
+
+// watcher re-exports a onekeymap.json to a fixed set of editors.
+type watcher struct {
+	path    string
+	editors []string
+	// paths holds the keymap path of each editor.
+	paths map[string]string
+	flags syncFlags
+
+	validator     *validateapi.Validator
+	importService importerapi.Importer
+	exportService exporterapi.Exporter
+	logger        *slog.Logger
+}
+
+// reexport exports the current content of the file to every editor whose resolved keymap is
+// valid, and logs one record per editor. Exports also refresh the sync snapshots, so a later
+// sync does not mistake them for changes made in the editors. Editors changed since their last
+// sync are left alone: exporting would overwrite the change and hide it from the next sync.
+func (w *watcher) reexport(ctx context.Context) {
+	start := time.Now()
+	setting, _, err := loadKeymapFile(w.path)
+	if err != nil {
+		w.logger.Warn("Skipped re-export", "path", w.path, "error", err)
+		return
+	}
+
+	p := w.flags.platform
+	if p == "" {
+		p = platform.Current()
+	}
+	targets := make([]*syncTarget, len(w.editors))
+	for i, editor := range w.editors {
+		targets[i] = &syncTarget{editor: editor, path: w.paths[editor]}
+		resolved := setting.Resolve(p, editor)
+		report, err := w.validator.Validate(ctx, resolved, pluginapi.EditorType(editor))
+		switch {
+		case err != nil:
+			targets[i].err = fmt.Errorf("failed to validate keymap: %w", err)
+		case len(report.Issues) > 0:
+			issues := make([]string, len(report.Issues))
+			for j, issue := range report.Issues {
+				issues[j] = renderValidationIssueInline(issue)
+			}
+			targets[i].err = fmt.Errorf("keymap has %d validation issues: %s",
+				len(report.Issues), strings.Join(issues, "; "))
+		}
+	}
+
+	snapshotDir := syncstate.Dir(w.path)
+	forEachTarget(targets, func(t *syncTarget) error {
+		if err := readSnapshot(ctx, t, w.flags.platform, snapshotDir, w.importService); err != nil {
+			return err
+		}
+		if t.changedSinceSync() {
+			return errors.New("keymap was changed in the editor since the last sync; run sync to merge the change")
+		}
+		return syncEditor(ctx, t, &w.flags, setting, snapshotDir, w.importService, w.exportService, w.logger)
+	})
+	commitSyncedEditors(w.logger, "watch: re-export", targets)
+
+	for _, t := range targets {
+		if t.err != nil {
+			w.logger.Error("Re-export failed", "editor", t.editor, "path", t.path, "error", t.err)
+			continue
+		}
+		cov := t.report.Coverage
+		attrs := []any{
+			"editor", t.editor,
+			"path", t.path,
+			"changed", strings.TrimSpace(t.report.Diff) != "",
+			"fully_exported", cov.FullyExported,
+			"partially_exported", len(cov.PartiallyExported),
+			"skipped", countSkippedActions(t.report),
+			"total", cov.TotalActions,
+			"duration", time.Since(start),
+		}
+		if t.backup != "" {
+			attrs = append(attrs, "backup", t.backup)
+		}
+		w.logger.Info("Re-exported keymap", attrs...)
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/internal/syncstate"
+)
+
+func TestWatcher_SkipsInvalidSaves(t *testing.T) {
+	s := newTestServices(t)
+	dir := t.TempDir()
+	input := filepath.Join(dir, "onekeymap.json")
+	zedPath := filepath.Join(dir, "zed", "keymap.json")
+	var logs bytes.Buffer
+	w := &watcher{
+		path:          input,
+		editors:       []string{"zed"},
+		paths:         map[string]string{"zed": zedPath},
+		validator:     newWatchValidator(s.mappingConfig),
+		importService: s.importer,
+		exportService: s.exporter,
+		logger:        slog.New(slog.NewTextHandler(&logs, nil)),
+	}
+
+	// A save that does not parse is skipped.
+	require.NoError(t, os.WriteFile(input, []byte(`{"keymaps": [`), 0o600))
+	w.reexport(context.Background())
+	assert.Contains(t, logs.String(), "Skipped re-export")
+	assert.NoFileExists(t, zedPath)
+
+	// So is one that fails validation.
+	logs.Reset()
+	dangling := strings.Replace(syncTestKeymap, "actions.file.save", "actions.does.not.exist", 1)
+	require.NoError(t, os.WriteFile(input, []byte(dangling), 0o600))
+	w.reexport(context.Background())
+	assert.Contains(t, logs.String(), "Re-export failed")
+	assert.NoFileExists(t, zedPath)
+
+	logs.Reset()
+	require.NoError(t, os.WriteFile(input, []byte(syncTestKeymap), 0o600))
+	w.reexport(context.Background())
+	assert.Contains(t, logs.String(), "Re-exported keymap")
+	assert.FileExists(t, zedPath)
+}
+
+func TestWatcher_SkipsEditorsChangedByHand(t *testing.T) {
+	s := newTestServices(t)
+	dir := t.TempDir()
+	input := filepath.Join(dir, "onekeymap.json")
+	zedPath := filepath.Join(dir, "zed", "keymap.json")
+	var logs bytes.Buffer
+	w := &watcher{
+		path:          input,
+		editors:       []string{"zed"},
+		paths:         map[string]string{"zed": zedPath},
+		validator:     newWatchValidator(s.mappingConfig),
+		importService: s.importer,
+		exportService: s.exporter,
+		logger:        slog.New(slog.NewTextHandler(&logs, nil)),
+	}
+	require.NoError(t, os.WriteFile(input, []byte(syncTestKeymap), 0o600))
+	w.reexport(context.Background())
+	require.Contains(t, logs.String(), "Re-exported keymap")
+
+	// Change the save shortcut in Zed, then save onekeymap.json again.
+	exported, err := os.ReadFile(zedPath)
+	require.NoError(t, err)
+	require.Contains(t, string(exported), `"meta-s"`)
+	edited := strings.Replace(string(exported), `"meta-s"`, `"meta-alt-s"`, 1)
+	require.NoError(t, os.WriteFile(zedPath, []byte(edited), 0o600))
+	snapshot := filepath.Join(syncstate.Dir(input), "zed.json")
+	before, err := os.ReadFile(snapshot)
+	require.NoError(t, err)
+
+	logs.Reset()
+	require.NoError(t, os.WriteFile(input,
+		[]byte(strings.ReplaceAll(syncTestKeymap, "cmd+shift+c", "cmd+ctrl+c")), 0o600))
+	w.reexport(context.Background())
+	assert.Contains(t, logs.String(), "run sync to merge the change")
+
+	got, err := os.ReadFile(zedPath)
+	require.NoError(t, err)
+	assert.Equal(t, edited, string(got), "the change made in Zed is not overwritten")
+	after, err := os.ReadFile(snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after), "the snapshot still sees the change made in Zed")
+}
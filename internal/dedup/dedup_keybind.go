@@ -7,6 +7,9 @@ import (
 )
 
 func mergeIntoExistingActionStruct(existing *keymap.Action, kb keymap.Action) {
+	existing.Metadata = existing.Metadata.Merge(kb.Metadata)
+	existing.Unbind = keymap.UnionKeybindings(existing.Unbind, kb.Unbind)
+	existing.UnbindAll = existing.UnbindAll || kb.UnbindAll
 	// Merge bindings into existing
 	for _, b := range kb.Bindings {
 		if len(b.KeyChords) == 0 {
@@ -27,25 +30,33 @@ func mergeIntoExistingActionStruct(existing *keymap.Action, kb keymap.Action) {
 	}
 }
 
-// Actions removes duplicate keybindings based on (Action, KeyChords)
+// Actions removes duplicate keybindings based on (Action, Scope, KeyChords)
 // using a deterministic signature. The first occurrence is kept and order is preserved.
 func Actions(actions []keymap.Action) []keymap.Action {
 	if len(actions) == 0 {
 		return actions
 	}
-	// Merge by action ID, concatenating unique bindings while preserving first metadata and order
-	idxByID := make(map[string]int, len(actions))
+	// Merge by action ID and scope, concatenating unique bindings while preserving first metadata and order
+	type actionKey struct {
+		Name  string
+		Scope keymap.Scope
+	}
+	idxByID := make(map[actionKey]int, len(actions))
 	out := make([]keymap.Action, 0, len(actions))
 
 	for _, kb := range actions {
-		id := kb.Name
+		id := actionKey{Name: kb.Name, Scope: kb.Scope}
 		if pos, ok := idxByID[id]; ok {
 			mergeIntoExistingActionStruct(&out[pos], kb)
 			continue
 		}
 		// First occurrence: create a fresh ActionBinding and deduplicate its own bindings
 		fresh := keymap.Action{
-			Name: kb.Name,
+			Name:      kb.Name,
+			Scope:     kb.Scope,
+			Metadata:  kb.Metadata,
+			Unbind:    keymap.UnionKeybindings(nil, kb.Unbind),
+			UnbindAll: kb.UnbindAll,
 		}
 
 		hadBindings := len(kb.Bindings) > 0
@@ -67,7 +78,7 @@ func Actions(actions []keymap.Action) []keymap.Action {
 			}
 		}
 		// If there were explicit bindings but all were invalid/empty -> drop this action entirely
-		if len(fresh.Bindings) == 0 && hadBindings {
+		if len(fresh.Bindings) == 0 && hadBindings && !fresh.HasUnbind() {
 			continue
 		}
 		idxByID[id] = len(out)
@@ -30,6 +30,21 @@ func newAction(name string, keys ...string) keymap.Action {
 	}
 }
 
+func withScope(action keymap.Action, scope keymap.Scope) keymap.Action {
+	action.Scope = scope
+	return action
+}
+
+func withMetadata(action keymap.Action, metadata keymap.Metadata) keymap.Action {
+	action.Metadata = metadata
+	return action
+}
+
+func withUnbind(action keymap.Action, keys ...string) keymap.Action {
+	action.Unbind = newAction("", keys...).Bindings
+	return action
+}
+
 func TestActions_Table(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -58,6 +73,40 @@ func TestActions_Table(t *testing.T) {
 				newAction("actions.copy", "k"),
 			},
 		},
+		{
+			name: "SameActionDifferentScopesStayApart",
+			input: []keymap.Action{
+				newAction("actions.copy", "k"),
+				withScope(newAction("actions.copy", "k"), "vimNormal"),
+				withScope(newAction("actions.copy", "y"), "vimNormal"),
+			},
+			expected: []keymap.Action{
+				newAction("actions.copy", "k"),
+				withScope(newAction("actions.copy", "k", "y"), "vimNormal"),
+			},
+		},
+		{
+			name: "MergesMetadata",
+			input: []keymap.Action{
+				withMetadata(newAction("actions.copy", "k"), keymap.Metadata{Comment: "note"}),
+				withMetadata(newAction("actions.copy", "y"), keymap.Metadata{Tags: []string{"vim"}}),
+			},
+			expected: []keymap.Action{
+				withMetadata(newAction("actions.copy", "k", "y"), keymap.Metadata{Comment: "note", Tags: []string{"vim"}}),
+			},
+		},
+		{
+			name: "MergesUnbind",
+			input: []keymap.Action{
+				withUnbind(newAction("actions.copy", "k"), "y"),
+				withUnbind(newAction("actions.copy"), "y", "z"),
+				{Name: "actions.paste", UnbindAll: true},
+			},
+			expected: []keymap.Action{
+				withUnbind(newAction("actions.copy", "k"), "y", "z"),
+				{Name: "actions.paste", UnbindAll: true},
+			},
+		},
 		{
 			name: "KeepsOrderAndSkipsNil",
 			input: []keymap.Action{
@@ -88,11 +88,14 @@ func (m *Marker) Report() pluginapi.ExportSkipReport {
 			continue
 		}
 		// iterate each binding
+		bindingKeys := make(map[string]bool, len(act.Bindings))
+		actionReasonUsed := false
 		for _, kb := range act.Bindings {
 			if len(kb.KeyChords) == 0 {
 				continue
 			}
 			key := kb.String(keybinding.FormatOption{Platform: platform.PlatformMacOS, Separator: " "})
+			bindingKeys[key] = true
 			// exported? skip
 			if expForAct, ok := m.exported[id]; ok {
 				if expForAct[key] {
@@ -109,15 +112,43 @@ func (m *Marker) Report() pluginapi.ExportSkipReport {
 			// action-level skip reason?
 			if err, ok := m.skippedAction[id]; ok {
 				result = append(result, pluginapi.ExportSkipAction{Action: id, Error: err})
+				actionReasonUsed = true
 				continue
 			}
 			// default
 			result = append(result, pluginapi.ExportSkipAction{Action: id, Error: pluginapi.ErrActionNotSupported})
 		}
+		result = append(result, m.unbindSkips(act, bindingKeys, actionReasonUsed)...)
 	}
 	return pluginapi.ExportSkipReport{SkipActions: result}
 }
 
+// unbindSkips reports the skips of an action's removals, which have no binding to attach to: per-key
+// reasons for its unbind keys, and the action-level reason if no binding was reported with it.
+func (m *Marker) unbindSkips(
+	act *keymap.Action,
+	bindingKeys map[string]bool,
+	actionReasonUsed bool,
+) []pluginapi.ExportSkipAction {
+	if !act.HasUnbind() {
+		return nil
+	}
+	var result []pluginapi.ExportSkipAction
+	for _, kb := range act.Unbind {
+		key := kb.String(keybinding.FormatOption{Platform: platform.PlatformMacOS, Separator: " "})
+		if bindingKeys[key] {
+			continue
+		}
+		if err, ok := m.skippedKeys[act.Name][key]; ok {
+			result = append(result, pluginapi.ExportSkipAction{Action: act.Name, Error: err})
+		}
+	}
+	if err, ok := m.skippedAction[act.Name]; ok && !actionReasonUsed {
+		result = append(result, pluginapi.ExportSkipAction{Action: act.Name, Error: err})
+	}
+	return result
+}
+
 // ExportedReport generates a report of what was requested vs what was exported for each action.
 func (m *Marker) ExportedReport() pluginapi.ExportedReport {
 	actions := m.keymap.Actions
@@ -219,3 +219,23 @@ func TestMarker_ExportedReport_MultipleActions(t *testing.T) {
 	assert.Len(t, rep.Actions[1].Requested, 2)
 	assert.Len(t, rep.Actions[1].Exported, 1)
 }
+
+func TestMarker_UnbindSkips(t *testing.T) {
+	kb, err := keybinding.NewKeybinding("cmd+x", keybinding.ParseOption{Separator: "+"})
+	require.NoError(t, err)
+	km := keymap.Keymap{Actions: []keymap.Action{
+		{Name: "actions.test.unbind", Unbind: []keybinding.Keybinding{kb}},
+		{Name: "actions.test.unbindAll", UnbindAll: true},
+	}}
+	m := exp.NewMarker(&km)
+
+	m.MarkSkippedForReason("actions.test.unbind", &kb, &pluginapi.UnsupportedExportActionError{Note: "key"})
+	m.MarkSkippedForReason("actions.test.unbindAll", nil, &pluginapi.UnsupportedExportActionError{Note: "all"})
+
+	rep := m.Report()
+	require.Len(t, rep.SkipActions, 2)
+	assert.Equal(t, "actions.test.unbind", rep.SkipActions[0].Action)
+	require.ErrorContains(t, rep.SkipActions[0].Error, "key")
+	assert.Equal(t, "actions.test.unbindAll", rep.SkipActions[1].Action)
+	require.ErrorContains(t, rep.SkipActions[1].Error, "all")
+}
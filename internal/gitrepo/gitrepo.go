@@ -0,0 +1,262 @@
+// Package gitrepo commits keymap files to a local git repository, so that every version of them
+// can be browsed with git. It runs the git executable and never needs a remote.
+package gitrepo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Identity used when git has no user.name/user.email configured, e.g. in a fresh container.
+const (
+	fallbackName  = "onekeymap-cli"
+	fallbackEmail = "onekeymap-cli@localhost"
+)
+
+// ErrInvalidRevision is returned for a revision git would read as an option.
+var ErrInvalidRevision = errors.New("invalid revision")
+
+// logFormat separates the fields of a commit with the unit separator.
+const logFormat = "--format=%H%x1f%cI%x1f%s"
+
+// File is a file to commit.
+type File struct {
+	// Path is the file as written by onekeymap-cli.
+	Path string
+	// Name is the slash-separated path of the file in the repository. When it differs from Path,
+	// the content of Path is copied there before committing.
+	Name string
+}
+
+// Commit is a commit of the repository.
+type Commit struct {
+	Hash    string
+	Time    time.Time
+	Subject string
+}
+
+// Repo is a local git repository.
+type Repo struct {
+	// root is the top-level directory of the work tree.
+	root string
+	// dir is the directory the repository was opened with, inside root.
+	dir string
+}
+
+// Open opens the repository containing dir, so that a dotfiles repository is used as is. If dir is
+// not inside one, a new repository is initialised in dir.
+func Open(dir string) (*Repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git versioning needs git installed: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+	out, err := run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		if _, err := run(dir, "init", "--quiet"); err != nil {
+			return nil, err
+		}
+		return &Repo{root: dir, dir: dir}, nil
+	}
+	root, err := filepath.EvalSymlinks(strings.TrimSpace(string(out)))
+	if err != nil {
+		return nil, err
+	}
+	return &Repo{root: root, dir: dir}, nil
+}
+
+// Root returns the top-level directory of the work tree.
+func (r *Repo) Root() string { return r.root }
+
+// Name returns the name of the file at p in the repository: its own path if it lies in the work
+// tree, and otherwise mirror, a slash-separated path relative to the directory the repository was
+// opened with.
+func (r *Repo) Name(p, mirror string) string {
+	resolved, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		resolved = p
+	}
+	if abs, err := filepath.Abs(resolved); err == nil {
+		if rel, err := filepath.Rel(r.root, abs); err == nil && filepath.IsLocal(rel) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return r.MirrorName(mirror)
+}
+
+// MirrorName returns the name in the repository of mirror, a slash-separated path relative to the
+// directory the repository was opened with.
+func (r *Repo) MirrorName(mirror string) string {
+	rel, err := filepath.Rel(r.root, r.dir)
+	if err != nil {
+		return mirror
+	}
+	return path.Join(filepath.ToSlash(rel), mirror)
+}
+
+// Commit commits files with message. Other changes in the work tree, staged or not, are left
+// alone. It returns false when none of the files changed since the last commit.
+func (r *Repo) Commit(message string, files ...File) (Commit, bool, error) {
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		if err := r.copyIn(f); err != nil {
+			return Commit{}, false, err
+		}
+		names = append(names, f.Name)
+	}
+	if len(names) == 0 {
+		return Commit{}, false, nil
+	}
+
+	if _, err := run(r.root, append([]string{"add", "--"}, names...)...); err != nil {
+		return Commit{}, false, err
+	}
+	if _, err := run(r.root, append([]string{"diff", "--cached", "--quiet", "--"}, names...)...); err == nil {
+		return Commit{}, false, nil
+	}
+	args := r.identity()
+	args = append(args, "commit", "--quiet", "--message", message, "--")
+	if _, err := run(r.root, append(args, names...)...); err != nil {
+		return Commit{}, false, err
+	}
+	c, err := r.Resolve("HEAD")
+	return c, err == nil, err
+}
+
+// Log returns the commits touching any of names, newest first. A repository without commits has
+// an empty log.
+func (r *Repo) Log(names ...string) ([]Commit, error) {
+	if _, err := run(r.root, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return nil, nil
+	}
+	out, err := run(r.root, append([]string{"log", logFormat, "--"}, names...)...)
+	if err != nil {
+		return nil, err
+	}
+	var commits []Commit
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		if line == "" {
+			continue
+		}
+		c, err := parseCommit(line)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// Resolve returns the commit rev names, e.g. a hash prefix or HEAD~2.
+func (r *Repo) Resolve(rev string) (Commit, error) {
+	if err := checkRevision(rev); err != nil {
+		return Commit{}, err
+	}
+	out, err := run(r.root, "log", "-1", logFormat, "--end-of-options", rev+"^{commit}", "--")
+	if err != nil {
+		return Commit{}, fmt.Errorf("unknown commit %q: %w", rev, err)
+	}
+	return parseCommit(strings.TrimSpace(string(out)))
+}
+
+// Show returns the content of name at rev, and false if it did not exist there.
+func (r *Repo) Show(rev, name string) ([]byte, bool, error) {
+	if err := checkRevision(rev); err != nil {
+		return nil, false, err
+	}
+	object := rev + ":" + name
+	if _, err := run(r.root, "cat-file", "-e", object); err != nil {
+		return nil, false, nil
+	}
+	out, err := run(r.root, "show", object)
+	return out, err == nil, err
+}
+
+// Files returns the names of the files commit rev changed.
+func (r *Repo) Files(rev string) ([]string, error) {
+	if err := checkRevision(rev); err != nil {
+		return nil, err
+	}
+	out, err := run(r.root, "diff-tree", "--root", "--no-commit-id", "--name-only", "-r", rev)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// copyIn copies a file that lives outside the work tree to its name in the repository.
+func (r *Repo) copyIn(f File) error {
+	dst := filepath.Join(r.root, filepath.FromSlash(f.Name))
+	src, err := filepath.EvalSymlinks(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if abs, err := filepath.Abs(src); err == nil && abs == dst {
+		return nil
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0o600)
+}
+
+// identity returns the options setting the committer when git has none configured.
+func (r *Repo) identity() []string {
+	if _, err := run(r.root, "config", "user.email"); err == nil {
+		return nil
+	}
+	return []string{"-c", "user.name=" + fallbackName, "-c", "user.email=" + fallbackEmail}
+}
+
+// checkRevision rejects revisions starting with "-", which git would parse as options.
+func checkRevision(rev string) error {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return fmt.Errorf("%w: %q", ErrInvalidRevision, rev)
+	}
+	return nil
+}
+
+func parseCommit(line string) (Commit, error) {
+	fields := strings.SplitN(line, "\x1f", 3)
+	if len(fields) != 3 {
+		return Commit{}, fmt.Errorf("unexpected git log output %q", line)
+	}
+	t, err := time.Parse(time.RFC3339, fields[1])
+	if err != nil {
+		return Commit{}, err
+	}
+	return Commit{Hash: fields[0], Time: t, Subject: fields[2]}, nil
+}
+
+func run(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
@@ -0,0 +1,74 @@
+package gitrepo_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/internal/gitrepo"
+)
+
+func TestRepo_Commit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	dir := t.TempDir()
+	repo, err := gitrepo.Open(filepath.Join(dir, "onekeymap"))
+	require.NoError(t, err)
+
+	onekeymap := filepath.Join(repo.Root(), "onekeymap.json")
+	require.NoError(t, os.WriteFile(onekeymap, []byte("v1"), 0o600))
+	editor := filepath.Join(dir, "zed", "keymap.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(editor), 0o750))
+	require.NoError(t, os.WriteFile(editor, []byte("zed v1"), 0o600))
+
+	files := []gitrepo.File{
+		{Path: onekeymap, Name: repo.Name(onekeymap, "onekeymap.json")},
+		{Path: editor, Name: repo.Name(editor, "editors/zed/keymap.json")},
+	}
+	assert.Equal(t, "editors/zed/keymap.json", files[1].Name, "files outside the work tree are mirrored")
+
+	first, ok, err := repo.Commit("import from zed", files...)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "import from zed", first.Subject)
+
+	_, ok, err = repo.Commit("nothing changed", files...)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, os.WriteFile(onekeymap, []byte("v2"), 0o600))
+	second, ok, err := repo.Commit("sync", files...)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	log, err := repo.Log("onekeymap.json")
+	require.NoError(t, err)
+	require.Len(t, log, 2)
+	assert.Equal(t, second.Hash, log[0].Hash)
+
+	content, ok, err := repo.Show(first.Hash, "onekeymap.json")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "v1", string(content))
+	_, ok, err = repo.Show(first.Hash+"^", "onekeymap.json")
+	require.NoError(t, err)
+	assert.False(t, ok, "the first commit has no parent")
+
+	changed, err := repo.Files(first.Hash)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"onekeymap.json", "editors/zed/keymap.json"}, changed)
+
+	// Revisions are never read as options, e.g. --output writing to a file.
+	out := filepath.Join(dir, "out")
+	_, err = repo.Resolve("--output=" + out)
+	require.ErrorIs(t, err, gitrepo.ErrInvalidRevision)
+	_, _, err = repo.Show("--output="+out, "onekeymap.json")
+	require.ErrorIs(t, err, gitrepo.ErrInvalidRevision)
+	assert.NoFileExists(t, out)
+}
@@ -0,0 +1,230 @@
+// Package history keeps an append-only journal of the versions of a onekeymap.json.
+//
+// The journal is a JSON Lines file next to the config. Every entry holds the full content of a
+// version, so any version can be shown or restored, along with what produced it.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xinnjie/onekeymap-cli/internal/safewrite"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
+)
+
+// Commands recorded in the journal besides the CLI commands that write onekeymap.json.
+const (
+	// CommandBaseline records the content the config had when the journal was started.
+	CommandBaseline = "baseline"
+	// CommandUndo records a version restored by undo.
+	CommandUndo = "undo"
+	// CommandEdit records changes made to the config outside onekeymap-cli, found before an undo.
+	CommandEdit = "edit"
+)
+
+// maxLineSize bounds a journal line, which holds a whole onekeymap.json.
+const maxLineSize = 64 << 20
+
+// ErrNothingToUndo is returned by Previous when there is no earlier version.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// Summary lists the action IDs a version added, updated and removed.
+type Summary struct {
+	Added   []string `json:"added,omitempty"`
+	Updated []string `json:"updated,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// Entry is one version of the config.
+type Entry struct {
+	// Seq numbers entries from 1 in the order they were recorded.
+	Seq     int       `json:"seq"`
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+	// Editor is the source editor of the change, if any.
+	Editor  string  `json:"editor,omitempty"`
+	Changes Summary `json:"changes"`
+	// Restores is the Seq of the version an undo went back to.
+	Restores int `json:"restores,omitempty"`
+	// Content is the config file as written.
+	Content string `json:"content"`
+}
+
+// Path returns the journal of the config at onekeymapPath, e.g. onekeymap.history.jsonl next to
+// onekeymap.json.
+func Path(onekeymapPath string) string {
+	ext := filepath.Ext(onekeymapPath)
+	return strings.TrimSuffix(onekeymapPath, ext) + ".history.jsonl"
+}
+
+// Summarize lists the action IDs of changes, sorted and without duplicates.
+func Summarize(changes *importerapi.KeymapChanges) Summary {
+	var s Summary
+	if changes == nil {
+		return s
+	}
+	for _, a := range changes.Add {
+		s.Added = append(s.Added, a.Name)
+	}
+	for _, d := range changes.Update {
+		s.Updated = append(s.Updated, d.After.Name)
+	}
+	for _, a := range changes.Remove {
+		s.Removed = append(s.Removed, a.Name)
+	}
+	s.Added, s.Updated, s.Removed = uniqueSorted(s.Added), uniqueSorted(s.Updated), uniqueSorted(s.Removed)
+	return s
+}
+
+// Record appends a version to the journal at path. before is the content the config had before
+// this version, or nil if it did not exist; when the journal is empty it is recorded first as a
+// baseline, so the first change can be undone.
+func Record(path string, before []byte, e Entry) (Entry, error) {
+	recorded, err := record(path, before, []Entry{e})
+	if err != nil {
+		return Entry{}, err
+	}
+	return recorded[0], nil
+}
+
+// Append appends several versions to the journal at path at once, e.g. an edit and the undo
+// reverting it.
+func Append(path string, entries ...Entry) ([]Entry, error) {
+	return record(path, nil, entries)
+}
+
+func record(path string, before []byte, entries []Entry) ([]Entry, error) {
+	unlock, err := safewrite.Lock(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	existing, err := List(path)
+	if err != nil {
+		return nil, err
+	}
+	var lines []Entry
+	if len(existing) == 0 && before != nil {
+		lines = append(lines, Entry{Seq: 1, Time: time.Now().UTC(), Command: CommandBaseline, Content: string(before)})
+	}
+	recorded := make([]Entry, len(entries))
+	for i, e := range entries {
+		e.Seq = len(existing) + len(lines) + 1
+		if e.Time.IsZero() {
+			e.Time = time.Now().UTC()
+		}
+		lines = append(lines, e)
+		recorded[i] = e
+	}
+
+	var buf []byte
+	for _, line := range lines {
+		data, err := json.Marshal(line)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(buf, data...), '\n')
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(buf); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return recorded, f.Close()
+}
+
+// List returns the entries of the journal at path, oldest first. A missing journal is empty.
+func List(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("failed to parse %s line %d: %w", filepath.Base(path), line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	return entries, nil
+}
+
+// Get returns the entry with the given Seq.
+func Get(entries []Entry, seq int) (Entry, bool) {
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].Seq >= seq })
+	if i < len(entries) && entries[i].Seq == seq {
+		return entries[i], true
+	}
+	return Entry{}, false
+}
+
+// Current returns the Seq of the version the last entry holds: for an undo, the version it restored.
+func Current(entries []Entry) int {
+	if len(entries) == 0 {
+		return 0
+	}
+	last := entries[len(entries)-1]
+	if last.Command == CommandUndo {
+		return last.Restores
+	}
+	return last.Seq
+}
+
+// Previous returns the version undo goes back to: the one that was current when the current
+// version was recorded. Repeated undos therefore walk back through history, skipping versions that
+// were already undone.
+func Previous(entries []Entry) (Entry, error) {
+	cur := Current(entries)
+	if cur <= 1 {
+		return Entry{}, ErrNothingToUndo
+	}
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].Seq >= cur })
+	prev, ok := Get(entries, Current(entries[:i]))
+	if !ok {
+		return Entry{}, ErrNothingToUndo
+	}
+	return prev, nil
+}
+
+func uniqueSorted(names []string) []string {
+	sort.Strings(names)
+	out := names[:0]
+	for i, n := range names {
+		if i == 0 || n != names[i-1] {
+			out = append(out, n)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package history_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/internal/history"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+)
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("cfg", "onekeymap.history.jsonl"), history.Path(filepath.Join("cfg", "onekeymap.json")))
+}
+
+func TestSummarize(t *testing.T) {
+	s := history.Summarize(&importerapi.KeymapChanges{
+		Add:    []keymap.Action{{Name: "b"}, {Name: "a"}, {Name: "a"}},
+		Update: []importerapi.KeymapDiff{{After: keymap.Action{Name: "c"}}},
+	})
+	assert.Equal(t, history.Summary{Added: []string{"a", "b"}, Updated: []string{"c"}}, s)
+}
+
+func TestRecordAndUndo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "onekeymap.history.jsonl")
+
+	entries, err := history.List(path)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	e, err := history.Record(path, []byte("v0"), history.Entry{Command: "import", Editor: "vscode", Content: "v1"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, e.Seq, "the previous content is recorded as a baseline")
+	_, err = history.Record(path, []byte("v1"), history.Entry{Command: "sync", Content: "v2"})
+	require.NoError(t, err)
+
+	entries, err = history.List(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, history.CommandBaseline, entries[0].Command)
+	assert.Equal(t, "v0", entries[0].Content)
+	assert.Equal(t, "vscode", entries[1].Editor)
+	assert.Equal(t, 3, history.Current(entries))
+
+	// Each undo goes one version further back.
+	prev, err := history.Previous(entries)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", prev.Content)
+	_, err = history.Record(path, nil, history.Entry{Command: history.CommandUndo, Restores: prev.Seq, Content: prev.Content})
+	require.NoError(t, err)
+
+	entries, err = history.List(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, history.Current(entries))
+	prev, err = history.Previous(entries)
+	require.NoError(t, err)
+	assert.Equal(t, "v0", prev.Content)
+	_, err = history.Record(path, nil, history.Entry{Command: history.CommandUndo, Restores: prev.Seq, Content: prev.Content})
+	require.NoError(t, err)
+
+	entries, err = history.List(path)
+	require.NoError(t, err)
+	_, err = history.Previous(entries)
+	require.ErrorIs(t, err, history.ErrNothingToUndo)
+
+	// A change made after undoing goes back to the version it replaced, not to an undone one.
+	_, err = history.Record(path, nil, history.Entry{Command: history.CommandEdit, Content: "v3"})
+	require.NoError(t, err)
+	entries, err = history.List(path)
+	require.NoError(t, err)
+	prev, err = history.Previous(entries)
+	require.NoError(t, err)
+	assert.Equal(t, "v0", prev.Content)
+}
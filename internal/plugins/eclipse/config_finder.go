@@ -0,0 +1,144 @@
+package eclipse
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+)
+
+const (
+	// workbenchPrefsPath is where a workspace keeps its workbench preferences, relative to the workspace root.
+	workbenchPrefsPath = ".metadata/.plugins/org.eclipse.core.runtime/.settings/org.eclipse.ui.workbench.prefs"
+	// ideLauncherPrefsPath is where an installation records its recently used workspaces.
+	ideLauncherPrefsPath = "configuration/.settings/org.eclipse.ui.ide.prefs"
+	recentWorkspacesKey  = "RECENT_WORKSPACES"
+)
+
+// ConfigDetect returns the workbench preference files of the Eclipse workspaces found on this machine,
+// most recently used first. Workspaces are looked up in the recent workspace list of each installation
+// and in the default workspace locations; a directory counts as a workspace when it has a .metadata folder.
+func (p *eclipsePlugin) ConfigDetect(opts pluginapi.ConfigDetectOptions) (paths []string, installed bool, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, false, err
+	}
+	switch runtime.GOOS {
+	case "darwin", "linux", "windows":
+	default:
+		return nil, false, fmt.Errorf(
+			"automatic path discovery is only supported on macOS, Linux, and Windows, %w",
+			pluginapi.ErrNotSupported,
+		)
+	}
+
+	workspaces := findWorkspaces(home, runtime.GOOS)
+	if len(workspaces) == 0 {
+		return nil, false, errors.New("could not locate an Eclipse workspace (a directory containing .metadata)")
+	}
+	for _, ws := range workspaces {
+		paths = append(paths, filepath.Join(ws, filepath.FromSlash(workbenchPrefsPath)))
+	}
+
+	if opts.Sandbox {
+		installed = false
+	} else {
+		_, err = exec.LookPath("eclipse")
+		installed = err == nil
+		if !installed && runtime.GOOS == "darwin" {
+			_, statErr := os.Stat("/Applications/Eclipse.app")
+			installed = statErr == nil
+		}
+	}
+
+	return paths, installed, nil
+}
+
+// findWorkspaces lists workspace directories, deduplicated, in order of preference.
+func findWorkspaces(home, goos string) []string {
+	var candidates []string
+	for _, prefsPath := range launcherPrefsFiles(home, goos) {
+		candidates = append(candidates, recentWorkspaces(prefsPath)...)
+	}
+	for _, dir := range []string{"eclipse-workspace", "workspace"} {
+		candidates = append(candidates, filepath.Join(home, dir), filepath.Join(home, "Documents", dir))
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		c = filepath.Clean(c)
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		if fi, err := os.Stat(filepath.Join(c, ".metadata")); err == nil && fi.IsDir() {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// launcherPrefsFiles returns the org.eclipse.ui.ide.prefs of every installation found, newest first.
+func launcherPrefsFiles(home, goos string) []string {
+	installDirs := []string{
+		// Shared installations keep per-user configuration here
+		filepath.Join(home, ".eclipse", "*"),
+	}
+	switch goos {
+	case "darwin":
+		installDirs = append(installDirs,
+			filepath.Join("/Applications", "Eclipse*.app", "Contents", "Eclipse"),
+			// Eclipse Installer (Oomph) default location
+			filepath.Join(home, "eclipse", "*", "Eclipse.app", "Contents", "Eclipse"),
+		)
+	default:
+		installDirs = append(installDirs,
+			filepath.Join(home, "eclipse"),
+			filepath.Join(home, "eclipse", "*", "eclipse"),
+		)
+	}
+
+	var files []string
+	for _, dir := range installDirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, filepath.FromSlash(ideLauncherPrefsPath)))
+		files = append(files, matches...)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		fi, errI := os.Stat(files[i])
+		fj, errJ := os.Stat(files[j])
+		return errI == nil && errJ == nil && fi.ModTime().After(fj.ModTime())
+	})
+	return files
+}
+
+// recentWorkspaces reads the newline separated RECENT_WORKSPACES preference, most recent first.
+func recentWorkspaces(prefsPath string) []string {
+	f, err := os.Open(prefsPath)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	prefs, err := parsePrefs(f)
+	if err != nil {
+		return nil
+	}
+	value, ok := prefs.Get(recentWorkspacesKey)
+	if !ok {
+		return nil
+	}
+	var result []string
+	for _, ws := range strings.Split(value, "\n") {
+		if ws = strings.TrimSpace(ws); ws != "" {
+			result = append(result, ws)
+		}
+	}
+	return result
+}
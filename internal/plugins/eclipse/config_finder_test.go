@@ -0,0 +1,37 @@
+package eclipse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindWorkspaces(t *testing.T) {
+	home := t.TempDir()
+	projects := filepath.Join(home, "projects", "ws")
+	for _, dir := range []string{
+		filepath.Join(projects, ".metadata"),
+		filepath.Join(home, "eclipse-workspace", ".metadata"),
+		// Not a workspace: no .metadata
+		filepath.Join(home, "workspace"),
+	} {
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+	}
+
+	settings := filepath.Join(home, ".eclipse", "org.eclipse.platform_4.30.0_1473617060", "configuration", ".settings")
+	require.NoError(t, os.MkdirAll(settings, 0o755))
+	prefs := &prefsFile{}
+	prefs.Set(recentWorkspacesKey, projects+"\n"+filepath.Join(home, "deleted")+"\n"+filepath.Join(home, "eclipse-workspace"))
+	f, err := os.Create(filepath.Join(settings, "org.eclipse.ui.ide.prefs"))
+	require.NoError(t, err)
+	require.NoError(t, prefs.write(f))
+	require.NoError(t, f.Close())
+
+	assert.Equal(t,
+		[]string{projects, filepath.Join(home, "eclipse-workspace")},
+		findWorkspaces(home, "linux"),
+	)
+}
@@ -0,0 +1,39 @@
+package eclipse
+
+import (
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+var _ pluginapi.Plugin = (*eclipsePlugin)(nil)
+
+// eclipsePlugin implements the plugins.Plugin interface for the Eclipse IDE.
+type eclipsePlugin struct {
+	mappingConfig *mappings.MappingConfig
+	importer      pluginapi.PluginImporter
+	exporter      pluginapi.PluginExporter
+	logger        *slog.Logger
+}
+
+// New creates a new Eclipse plugin instance.
+func New(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) pluginapi.Plugin {
+	return &eclipsePlugin{
+		mappingConfig: mappingConfig,
+		importer:      newImporter(mappingConfig, logger, recorder),
+		exporter:      newExporter(mappingConfig, logger, diff.NewJSONASCIIDiffer()),
+		logger:        logger,
+	}
+}
+
+// EditorType returns the unique identifier for Eclipse.
+func (p *eclipsePlugin) EditorType() pluginapi.EditorType { return pluginapi.EditorTypeEclipse }
+
+// Importer returns the importer for this plugin.
+func (p *eclipsePlugin) Importer() (pluginapi.PluginImporter, error) { return p.importer, nil }
+
+// Exporter returns the exporter for this plugin.
+func (p *eclipsePlugin) Exporter() (pluginapi.PluginExporter, error) { return p.exporter, nil }
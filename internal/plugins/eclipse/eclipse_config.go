@@ -0,0 +1,104 @@
+package eclipse
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+const (
+	// commandsPrefKey holds the user's key bindings as an XML document in org.eclipse.ui.workbench.prefs.
+	commandsPrefKey = "org.eclipse.ui.commands"
+	// prefsVersionKey is written by Eclipse at the top of every preference file.
+	prefsVersionKey = "eclipse.preferences.version"
+
+	defaultKeyConfigurationID = "org.eclipse.ui.defaultAcceleratorConfiguration"
+
+	commandsXMLHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`
+)
+
+// eclipseCommands is the document stored under the org.eclipse.ui.commands preference.
+type eclipseCommands struct {
+	XMLName     xml.Name            `xml:"org.eclipse.ui.commands"`
+	KeyBindings []eclipseKeyBinding `xml:"keyBinding"`
+	// Others keeps elements other than keyBinding, e.g. activeKeyConfiguration, so they survive an export.
+	Others []eclipseRawElement `xml:",any"`
+}
+
+// eclipseKeyBinding is a user-defined key binding. An empty CommandID removes a default binding.
+type eclipseKeyBinding struct {
+	CommandID          string             `xml:"commandId,attr"          json:"commandId"`
+	ContextID          string             `xml:"contextId,attr"          json:"contextId"`
+	KeyConfigurationID string             `xml:"keyConfigurationId,attr" json:"keyConfigurationId"`
+	KeySequence        string             `xml:"keySequence,attr"        json:"keySequence"`
+	Locale             string             `xml:"locale,attr"             json:"locale,omitempty"`
+	Platform           string             `xml:"platform,attr"           json:"platform,omitempty"`
+	Parameters         []eclipseParameter `xml:"parameter"               json:"parameters,omitempty"`
+}
+
+type eclipseParameter struct {
+	ID    string `xml:"id,attr"    json:"id"`
+	Value string `xml:"value,attr" json:"value"`
+}
+
+type eclipseRawElement struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Inner   string     `xml:",innerxml"`
+}
+
+func parseCommands(content string) (eclipseCommands, error) {
+	var commands eclipseCommands
+	if strings.TrimSpace(content) == "" {
+		return commands, nil
+	}
+	if err := xml.Unmarshal([]byte(content), &commands); err != nil {
+		return eclipseCommands{}, fmt.Errorf("failed to parse %s: %w", commandsPrefKey, err)
+	}
+	return commands, nil
+}
+
+// format renders the document the way Eclipse stores it: one element per line, keyBinding attributes in a fixed order.
+func (c eclipseCommands) format() (string, error) {
+	var sb strings.Builder
+	sb.WriteString(commandsXMLHeader)
+	sb.WriteString("\n<org.eclipse.ui.commands>\n")
+	for _, o := range c.Others {
+		raw, err := xml.Marshal(o)
+		if err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", commandsPrefKey, err)
+		}
+		sb.Write(raw)
+		sb.WriteString("\n")
+	}
+	for _, kb := range c.KeyBindings {
+		sb.WriteString("<keyBinding")
+		writeXMLAttr(&sb, "commandId", kb.CommandID)
+		writeXMLAttr(&sb, "contextId", kb.ContextID)
+		writeXMLAttr(&sb, "keyConfigurationId", kb.KeyConfigurationID)
+		writeXMLAttr(&sb, "keySequence", kb.KeySequence)
+		writeXMLAttr(&sb, "locale", kb.Locale)
+		writeXMLAttr(&sb, "platform", kb.Platform)
+		if len(kb.Parameters) == 0 {
+			sb.WriteString("/>\n")
+			continue
+		}
+		sb.WriteString(">\n")
+		for _, p := range kb.Parameters {
+			sb.WriteString("<parameter")
+			writeXMLAttr(&sb, "id", p.ID)
+			writeXMLAttr(&sb, "value", p.Value)
+			sb.WriteString("/>\n")
+		}
+		sb.WriteString("</keyBinding>\n")
+	}
+	sb.WriteString("</org.eclipse.ui.commands>\n")
+	return sb.String(), nil
+}
+
+func writeXMLAttr(sb *strings.Builder, name, value string) {
+	var escaped bytes.Buffer
+	_ = xml.EscapeText(&escaped, []byte(value))
+	fmt.Fprintf(sb, ` %s="%s"`, name, escaped.String())
+}
@@ -0,0 +1,9 @@
+package eclipse
+
+import "errors"
+
+var (
+	ErrNotSupportKeyChords = errors.New("key chord is not supported by eclipse")
+	// ErrParameterizedCommand is reported for bindings that pass parameters to their command.
+	ErrParameterizedCommand = errors.New("parameterized eclipse commands are not supported")
+)
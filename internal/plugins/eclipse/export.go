@@ -0,0 +1,196 @@
+package eclipse
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/internal/export"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+var _ pluginapi.PluginExporter = (*eclipseExporter)(nil)
+
+type eclipseExporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	differ        diff.Differ
+}
+
+func newExporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	differ diff.Differ,
+) pluginapi.PluginExporter {
+	return &eclipseExporter{mappingConfig: mappingConfig, logger: logger, differ: differ}
+}
+
+// Export writes the keymap into the org.eclipse.ui.commands preference of org.eclipse.ui.workbench.prefs.
+// All other preferences, and key bindings that are not managed by onekeymap, are preserved.
+func (e *eclipseExporter) Export(
+	ctx context.Context,
+	destination io.Writer,
+	setting keymap.Keymap,
+	opts pluginapi.PluginExportOption,
+) (*pluginapi.PluginExportReport, error) {
+	prefs := &prefsFile{}
+	if opts.ExistingConfig != nil {
+		var err error
+		prefs, err = parsePrefs(opts.ExistingConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	content, _ := prefs.Get(commandsPrefKey)
+	existing, err := parseCommands(content)
+	if err != nil {
+		e.logger.WarnContext(
+			ctx,
+			"Failed to parse existing key bindings, proceeding with destructive export",
+			"error",
+			err,
+		)
+		existing = eclipseCommands{}
+	}
+
+	var unmanaged []eclipseKeyBinding
+	for _, kb := range existing.KeyBindings {
+		if !isManagedKeybinding(e.mappingConfig, kb) {
+			unmanaged = append(unmanaged, kb)
+		}
+	}
+
+	marker := export.NewMarker(&setting)
+	managed := e.identifyManagedKeybindings(ctx, &setting, marker)
+
+	final := eclipseCommands{
+		Others:      existing.Others,
+		KeyBindings: e.nonDestructiveMerge(ctx, managed, unmanaged, opts.TargetPlatform),
+	}
+	formatted, err := final.format()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prefs.Entries) == 0 {
+		prefs.Set(prefsVersionKey, "1")
+	}
+	prefs.Set(commandsPrefKey, formatted)
+	if err := prefs.write(destination); err != nil {
+		return nil, err
+	}
+
+	return &pluginapi.PluginExportReport{
+		BaseEditorConfig:   existing.KeyBindings,
+		ExportEditorConfig: final.KeyBindings,
+		SkipReport:         marker.Report(),
+		ExportedReport:     marker.ExportedReport(),
+	}, nil
+}
+
+// identifyManagedKeybindings generates Eclipse key bindings from KeymapSetting.
+func (e *eclipseExporter) identifyManagedKeybindings(
+	ctx context.Context,
+	setting *keymap.Keymap,
+	marker *export.Marker,
+) []eclipseKeyBinding {
+	var result []eclipseKeyBinding
+	seen := make(map[string]struct{})
+
+	for _, km := range setting.Actions {
+		mapping, usedFallback := e.mappingConfig.GetExportAction(km.Name, pluginapi.EditorTypeEclipse)
+		if mapping == nil || mapping.Eclipse.CommandID == "" {
+			for _, b := range km.Bindings {
+				if len(b.KeyChords) > 0 {
+					marker.MarkSkippedForReason(km.Name, &b, pluginapi.ErrActionNotSupported)
+				}
+			}
+			continue
+		}
+
+		if usedFallback {
+			e.logger.InfoContext(ctx, "Action not directly supported, using fallback action",
+				"originalAction", km.Name,
+				"fallbackAction", mapping.ID,
+			)
+		}
+
+		for _, b := range km.Bindings {
+			if len(b.KeyChords) == 0 {
+				continue
+			}
+			sequence, err := formatKeybinding(b)
+			if err != nil {
+				e.logger.DebugContext(ctx, "Skipping keybinding with un-formattable key", "action", km.Name, "error", err)
+				marker.MarkSkippedForReason(km.Name, &b, &pluginapi.UnsupportedExportActionError{Note: err.Error()})
+				continue
+			}
+			marker.MarkExported(km.Name, b)
+
+			kb := eclipseKeyBinding{
+				CommandID:          mapping.Eclipse.CommandID,
+				ContextID:          mapping.Eclipse.EffectiveContextID(),
+				KeyConfigurationID: defaultKeyConfigurationID,
+				KeySequence:        sequence,
+			}
+			key := kb.CommandID + "|" + conflictKey(kb.KeySequence, kb.ContextID)
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			result = append(result, kb)
+		}
+	}
+
+	return result
+}
+
+// nonDestructiveMerge keeps unmanaged key bindings after the managed ones, dropping those that bind
+// the same key sequence in the same context. Removals of default bindings are always kept.
+func (e *eclipseExporter) nonDestructiveMerge(
+	ctx context.Context,
+	managed, unmanaged []eclipseKeyBinding,
+	targetPlatform platform.Platform,
+) []eclipseKeyBinding {
+	if targetPlatform == "" {
+		targetPlatform = platform.Current()
+	}
+	managedKeys := make(map[string]bool, len(managed))
+	for _, kb := range managed {
+		managedKeys[conflictKey(kb.KeySequence, kb.ContextID)] = true
+	}
+
+	result := make([]eclipseKeyBinding, 0, len(managed)+len(unmanaged))
+	result = append(result, managed...)
+
+	for _, kb := range unmanaged {
+		if kb.CommandID == "" || !matchesPlatform(kb.Platform, targetPlatform) {
+			result = append(result, kb)
+			continue
+		}
+		sequence := kb.KeySequence
+		// Normalize e.g. "M1+C" so that it matches a managed "CTRL+C"
+		if parsed, err := parseKeybinding(kb.KeySequence, targetPlatform); err == nil {
+			if formatted, err := formatKeybinding(parsed); err == nil {
+				sequence = formatted
+			}
+		}
+		if managedKeys[conflictKey(sequence, kb.ContextID)] {
+			e.logger.DebugContext(ctx, "Conflict resolved: managed keybinding takes priority",
+				"keySequence", kb.KeySequence, "unmanaged_command", kb.CommandID)
+			continue
+		}
+		result = append(result, kb)
+	}
+
+	return result
+}
+
+func conflictKey(keySequence, contextID string) string {
+	return keySequence + "|" + contextID
+}
@@ -0,0 +1,134 @@
+package eclipse
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func newAction(actionName string, keybindings ...string) keymap.Action {
+	var bindings []keybinding.Keybinding
+	for _, kb := range keybindings {
+		b, err := keybinding.NewKeybinding(kb, keybinding.ParseOption{Separator: "+"})
+		if err != nil {
+			panic(err)
+		}
+		bindings = append(bindings, b)
+	}
+	return keymap.Action{
+		Name:     actionName,
+		Bindings: bindings,
+	}
+}
+
+func TestExportEclipseKeyBindings(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	const (
+		copyCtrlC = `<keyBinding commandId="org.eclipse.ui.edit.copy" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+C" locale="" platform=""/>`
+		childKS   = `<keyBinding commandId="test.childSupported" contextId="org.eclipse.ui.textEditorScope" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+K CTRL+S" locale="" platform=""/>`
+
+		otherInEditor = `<keyBinding commandId="org.example.other" contextId="org.eclipse.ui.textEditorScope" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+C" locale="" platform=""/>`
+		removeCtrlC   = `<keyBinding commandId="" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+C" locale="" platform=""/>`
+		unknownCtrlU  = `<keyBinding commandId="org.example.unknown" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+U" locale="" platform=""/>`
+	)
+
+	tests := []struct {
+		name           string
+		setting        keymap.Keymap
+		existing       string
+		targetPlatform platform.Platform
+		expected       string
+		wantSkipped    []string
+	}{
+		{
+			name: "new file",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+c", "ctrl+c"),
+				newAction("actions.test.parentNotSupported", "ctrl+k ctrl+s"),
+				newAction("actions.test.withArgs", "ctrl+e"),
+			}},
+			expected:    workbenchPrefs(copyCtrlC, childKS),
+			wantSkipped: []string{"actions.test.withArgs"},
+		},
+		{
+			name: "preserves other preferences and unmanaged bindings",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+c"),
+			}},
+			targetPlatform: platform.PlatformLinux,
+			existing: "eclipse.preferences.version=1\n" +
+				"ENABLE_ANIMATIONS=false\n" +
+				commandsPref(
+					// Replaced: managed command
+					`<keyBinding commandId="org.eclipse.ui.edit.copy" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+INSERT" locale="" platform=""/>`,
+					// Dropped: M1+C is CTRL+C on Linux, in the same context
+					`<keyBinding commandId="org.example.other" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="M1+C" locale="" platform=""/>`,
+					// Kept: different context, removal of a default binding, unknown command
+					otherInEditor, removeCtrlC, unknownCtrlU,
+				) +
+				"UIActivities.org.eclipse.team.cvs=false\n",
+			expected: "eclipse.preferences.version=1\n" +
+				"ENABLE_ANIMATIONS=false\n" +
+				commandsPref(copyCtrlC, otherInEditor, removeCtrlC, unknownCtrlU) +
+				"UIActivities.org.eclipse.team.cvs=false\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			exporter, err := p.Exporter()
+			require.NoError(t, err)
+
+			opts := pluginapi.PluginExportOption{TargetPlatform: tt.targetPlatform}
+			if tt.existing != "" {
+				opts.ExistingConfig = strings.NewReader(tt.existing)
+			}
+			var sb strings.Builder
+			report, err := exporter.Export(context.Background(), &sb, tt.setting, opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sb.String())
+
+			var skipped []string
+			for _, s := range report.SkipReport.SkipActions {
+				skipped = append(skipped, s.Action)
+			}
+			assert.ElementsMatch(t, tt.wantSkipped, skipped)
+		})
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "meta+c", "ctrl+k ctrl+/"),
+		newAction("actions.test.childSupported", "alt+ctrl+shift+f5"),
+	}}
+	var sb strings.Builder
+	_, err = exporter.Export(context.Background(), &sb, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	result, err := importer.Import(context.Background(), strings.NewReader(sb.String()), pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, setting.Actions, result.Keymap.Actions)
+}
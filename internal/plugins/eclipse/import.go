@@ -0,0 +1,108 @@
+package eclipse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/dedup"
+	"github.com/xinnjie/onekeymap-cli/internal/imports"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+type eclipseImporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	reporter      *metrics.UnknownActionReporter
+}
+
+func newImporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) *eclipseImporter {
+	return &eclipseImporter{
+		mappingConfig: mappingConfig,
+		logger:        logger,
+		reporter:      metrics.NewUnknownActionReporter(recorder),
+	}
+}
+
+// Import reads an org.eclipse.ui.workbench.prefs file and converts the key bindings stored in its
+// org.eclipse.ui.commands preference into the universal KeymapSetting format.
+func (i *eclipseImporter) Import(
+	ctx context.Context,
+	source io.Reader,
+	opts pluginapi.PluginImportOption,
+) (pluginapi.PluginImportResult, error) {
+	prefs, err := parsePrefs(source)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, err
+	}
+	content, _ := prefs.Get(commandsPrefKey)
+	commands, err := parseCommands(content)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, fmt.Errorf("failed to parse eclipse config: %w", err)
+	}
+
+	plat := opts.SourcePlatform
+	if plat == "" {
+		plat = platform.Current()
+	}
+
+	setting := keymap.Keymap{}
+	marker := imports.NewMarker()
+	for _, b := range commands.KeyBindings {
+		if b.CommandID == "" {
+			// Removal of a default binding; there is nothing to import
+			continue
+		}
+		if !matchesPlatform(b.Platform, plat) {
+			i.logger.DebugContext(ctx, "skipping binding for another platform", "command", b.CommandID,
+				"platform", b.Platform)
+			continue
+		}
+
+		kb, err := parseKeybinding(b.KeySequence, plat)
+		if err != nil {
+			i.logger.DebugContext(ctx, "failed to parse eclipse key sequence", "keySequence", b.KeySequence,
+				"error", err)
+			marker.MarkSkipped(
+				b.CommandID,
+				nil,
+				fmt.Errorf("failed to parse key sequence '%s': %w", b.KeySequence, err),
+			)
+			continue
+		}
+		if len(b.Parameters) > 0 {
+			marker.MarkSkipped(b.CommandID, &kb, ErrParameterizedCommand)
+			continue
+		}
+
+		mapping := findMappingByEclipse(i.mappingConfig, b.CommandID, b.ContextID)
+		if mapping == nil {
+			i.logger.DebugContext(ctx, "failed to find action", "command", b.CommandID, "context", b.ContextID)
+			i.reporter.ReportUnknownCommand(ctx, pluginapi.EditorTypeEclipse, b.CommandID)
+			marker.MarkSkipped(b.CommandID, &kb, pluginapi.ErrActionNotSupported)
+			continue
+		}
+
+		setting.Actions = append(setting.Actions, keymap.Action{
+			Name:     mapping.ID,
+			Bindings: []keybinding.Keybinding{kb},
+		})
+		marker.MarkImported(mapping.ID, b.CommandID, kb, kb)
+	}
+
+	setting.Actions = dedup.Actions(setting.Actions)
+	result := pluginapi.PluginImportResult{Keymap: setting}
+	result.Report.SkipReport = marker.Report()
+	result.Report.ImportedReport = marker.ImportedReport()
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package eclipse
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+// workbenchPrefs builds an org.eclipse.ui.workbench.prefs file whose org.eclipse.ui.commands holds keyBindings.
+func workbenchPrefs(keyBindings ...string) string {
+	return "eclipse.preferences.version=1\n" + commandsPref(keyBindings...)
+}
+
+// commandsPref renders the org.eclipse.ui.commands preference line the way the exporter writes it.
+func commandsPref(keyBindings ...string) string {
+	prefs := &prefsFile{}
+	prefs.Set(commandsPrefKey, commandsXMLHeader+"\n<org.eclipse.ui.commands>\n"+
+		strings.Join(keyBindings, "\n")+"\n</org.eclipse.ui.commands>\n")
+	var sb strings.Builder
+	_ = prefs.write(&sb)
+	return sb.String()
+}
+
+func TestImportEclipseKeyBindings(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		input          string
+		sourcePlatform platform.Platform
+		expected       []keymap.Action
+		wantSkipped    int
+	}{
+		{
+			name: "bindings across contexts",
+			input: workbenchPrefs(
+				`<keyBinding commandId="org.eclipse.ui.edit.copy" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+INSERT" locale="" platform=""/>`,
+				`<keyBinding commandId="test.childSupported" contextId="org.eclipse.ui.textEditorScope" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+K CTRL+S" locale="" platform=""/>`,
+				// Same command in another context still resolves to the action
+				`<keyBinding commandId="org.eclipse.ui.edit.copy" contextId="org.eclipse.ui.textEditorScope" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="ALT+C" locale="" platform=""/>`,
+			),
+			expected: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+insert", "alt+c"),
+				newAction("actions.test.childSupported", "ctrl+k ctrl+s"),
+			},
+		},
+		{
+			name:           "platform independent modifiers",
+			sourcePlatform: platform.PlatformMacOS,
+			input: workbenchPrefs(
+				`<keyBinding commandId="org.eclipse.ui.edit.copy" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="M1+M2+C" locale="" platform=""/>`,
+				`<keyBinding commandId="org.eclipse.ui.edit.copy" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="ALT+Y" locale="" platform="cocoa"/>`,
+				`<keyBinding commandId="org.eclipse.ui.edit.copy" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="ALT+Z" locale="" platform="gtk"/>`,
+			),
+			expected: []keymap.Action{newAction("actions.edit.copy", "meta+shift+c", "alt+y")},
+		},
+		{
+			name: "removals, unknown and parameterized commands",
+			input: workbenchPrefs(
+				`<keyBinding commandId="" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+Q" locale="" platform=""/>`,
+				`<keyBinding commandId="org.example.unknown" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+U" locale="" platform=""/>`,
+				`<keyBinding commandId="org.eclipse.ui.views.showView" contextId="org.eclipse.ui.contexts.window" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="ALT+SHIFT+Q P" locale="" platform="">
+<parameter id="org.eclipse.ui.views.showView.viewId" value="org.eclipse.jdt.ui.PackageExplorer"/>
+</keyBinding>`,
+				`<keyBinding commandId="test.childSupported" contextId="org.eclipse.ui.textEditorScope" keyConfigurationId="org.eclipse.ui.defaultAcceleratorConfiguration" keySequence="CTRL+BREAK" locale="" platform=""/>`,
+			),
+			expected:    nil,
+			wantSkipped: 3,
+		},
+		{
+			name:        "no key bindings",
+			input:       "eclipse.preferences.version=1\nPLUGINS_NOT_ACTIVATED_ON_STARTUP=;\n",
+			expected:    nil,
+			wantSkipped: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			importer, err := p.Importer()
+			require.NoError(t, err)
+
+			result, err := importer.Import(context.Background(), strings.NewReader(tt.input), pluginapi.PluginImportOption{
+				SourcePlatform: tt.sourcePlatform,
+			})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, result.Keymap.Actions)
+			assert.Len(t, result.Report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestImportEclipseInvalidCommandsXML(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	_, err = importer.Import(
+		context.Background(),
+		strings.NewReader("org.eclipse.ui.commands=<org.eclipse.ui.commands><keyBinding\n"),
+		pluginapi.PluginImportOption{},
+	)
+	require.Error(t, err)
+}
@@ -0,0 +1,216 @@
+package eclipse
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/internal/bimap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keychord"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keycode"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+const (
+	eclipseKeyStrokeSeparator = "+"
+	eclipseKeySequenceSep     = " "
+)
+
+//nolint:gochecknoglobals // static lookup tables shared across parse/format functions; initialized once and read-only
+var (
+	// COMMAND is ⌘ on macOS and the Super key elsewhere, i.e. our meta modifier.
+	eclipseModifierMapping = bimap.NewBiMapFromMap(map[string]keycode.KeyModifier{
+		"ALT":     keycode.KeyModifierAlt,
+		"COMMAND": keycode.KeyModifierMeta,
+		"CTRL":    keycode.KeyModifierCtrl,
+		"SHIFT":   keycode.KeyModifierShift,
+	})
+
+	// eclipseModifierOrder is the order of Eclipse's formal key formatter.
+	eclipseModifierOrder = []keycode.KeyModifier{
+		keycode.KeyModifierAlt, keycode.KeyModifierMeta, keycode.KeyModifierCtrl, keycode.KeyModifierShift,
+	}
+
+	// eclipseKeyMapping lists keys whose formal Eclipse name differs from ours. Letters are written in
+	// upper case; digits, punctuation and F1-F20 share the same name.
+	eclipseKeyMapping = bimap.NewBiMapFromMap(map[string]keycode.KeyCode{
+		"ARROW_UP":        keycode.KeyCodeUp,
+		"ARROW_DOWN":      keycode.KeyCodeDown,
+		"ARROW_LEFT":      keycode.KeyCodeLeft,
+		"ARROW_RIGHT":     keycode.KeyCodeRight,
+		"PAGE_UP":         keycode.KeyCodePageUp,
+		"PAGE_DOWN":       keycode.KeyCodePageDown,
+		"HOME":            keycode.KeyCodeHome,
+		"END":             keycode.KeyCodeEnd,
+		"INSERT":          keycode.KeyCodeInsert,
+		"DEL":             keycode.KeyCodeDelete,
+		"BS":              keycode.KeyCodeBackspace,
+		"CR":              keycode.KeyCodeEnter,
+		"ESC":             keycode.KeyCodeEscape,
+		"SPACE":           keycode.KeyCodeSpace,
+		"TAB":             keycode.KeyCodeTab,
+		"CAPS_LOCK":       keycode.KeyCodeCapsLock,
+		"NUMPAD_0":        keycode.KeyCodeNumpad0,
+		"NUMPAD_1":        keycode.KeyCodeNumpad1,
+		"NUMPAD_2":        keycode.KeyCodeNumpad2,
+		"NUMPAD_3":        keycode.KeyCodeNumpad3,
+		"NUMPAD_4":        keycode.KeyCodeNumpad4,
+		"NUMPAD_5":        keycode.KeyCodeNumpad5,
+		"NUMPAD_6":        keycode.KeyCodeNumpad6,
+		"NUMPAD_7":        keycode.KeyCodeNumpad7,
+		"NUMPAD_8":        keycode.KeyCodeNumpad8,
+		"NUMPAD_9":        keycode.KeyCodeNumpad9,
+		"NUMPAD_ADD":      keycode.KeyCodeNumpadAdd,
+		"NUMPAD_SUBTRACT": keycode.KeyCodeNumpadSubtract,
+		"NUMPAD_MULTIPLY": keycode.KeyCodeNumpadMultiply,
+		"NUMPAD_DIVIDE":   keycode.KeyCodeNumpadDivide,
+		"NUMPAD_DECIMAL":  keycode.KeyCodeNumpadDecimal,
+		"NUMPAD_ENTER":    keycode.KeyCodeNumpadEnter,
+		"NUMPAD_EQUAL":    keycode.KeyCodeNumpadEquals,
+	})
+
+	// eclipseKeyAliases are informal key names Eclipse also accepts; they are never written.
+	eclipseKeyAliases = map[string]keycode.KeyCode{
+		"ENTER":     keycode.KeyCodeEnter,
+		"RETURN":    keycode.KeyCodeEnter,
+		"ESCAPE":    keycode.KeyCodeEscape,
+		"DELETE":    keycode.KeyCodeDelete,
+		"BACKSPACE": keycode.KeyCodeBackspace,
+	}
+
+	// eclipseUnsupportedKeys have no Eclipse key name.
+	eclipseUnsupportedKeys = []keycode.KeyCode{
+		keycode.KeyCodeFn, keycode.KeyCodeShift, keycode.KeyCodeCtrl, keycode.KeyCodeAlt,
+		keycode.KeyCodeCmd, keycode.KeyCodeRightCmd, keycode.KeyCodeRightAlt, keycode.KeyCodeRightCtrl,
+		keycode.KeyCodeRightShift, keycode.KeyCodeMute, keycode.KeyCodeVolumeUp, keycode.KeyCodeVolumeDown,
+		keycode.KeyCodeNumpadClear,
+	}
+)
+
+// formatKeybinding formats a keybinding as an Eclipse key sequence, e.g. "CTRL+SHIFT+F" or "CTRL+K CTRL+S".
+func formatKeybinding(kb keybinding.Keybinding) (string, error) {
+	strokes := make([]string, 0, len(kb.KeyChords))
+	for _, chord := range kb.KeyChords {
+		if chord.KeyCode == "" || slices.Contains(eclipseUnsupportedKeys, chord.KeyCode) {
+			return "", fmt.Errorf("%w: %q", ErrNotSupportKeyChords, chord.String(keychord.FormatOption{
+				Separator: eclipseKeyStrokeSeparator,
+			}))
+		}
+
+		var parts []string
+		for _, mod := range eclipseModifierOrder {
+			if slices.Contains(chord.Modifiers, mod) {
+				name, _ := eclipseModifierMapping.GetInverse(mod)
+				parts = append(parts, name)
+			}
+		}
+
+		key := strings.ToUpper(string(chord.KeyCode))
+		if name, ok := eclipseKeyMapping.GetInverse(chord.KeyCode); ok {
+			key = name
+		}
+		parts = append(parts, key)
+		strokes = append(strokes, strings.Join(parts, eclipseKeyStrokeSeparator))
+	}
+	return strings.Join(strokes, eclipseKeySequenceSep), nil
+}
+
+// parseKeybinding parses an Eclipse key sequence. The platform-independent modifiers M1-M4
+// resolve against plat, which defaults to the current platform.
+func parseKeybinding(sequence string, plat platform.Platform) (keybinding.Keybinding, error) {
+	strokes := strings.Fields(sequence)
+	if len(strokes) == 0 {
+		return keybinding.Keybinding{}, errors.New("cannot parse empty key sequence")
+	}
+	if plat == "" {
+		plat = platform.Current()
+	}
+
+	chords := make([]keychord.KeyChord, 0, len(strokes))
+	for _, s := range strokes {
+		chord, err := parseKeyStroke(s, plat)
+		if err != nil {
+			return keybinding.Keybinding{}, err
+		}
+		chords = append(chords, chord)
+	}
+	return keybinding.Keybinding{KeyChords: chords}, nil
+}
+
+// parseKeyStroke parses a single key stroke like "CTRL+SHIFT+F", "M1+M2+R" or "CTRL++".
+func parseKeyStroke(s string, plat platform.Platform) (keychord.KeyChord, error) {
+	var modifierParts []string
+	var key string
+	switch {
+	case s == eclipseKeyStrokeSeparator:
+		key = eclipseKeyStrokeSeparator
+	case strings.HasSuffix(s, "++"):
+		modifierParts = strings.Split(strings.TrimSuffix(s, "++"), eclipseKeyStrokeSeparator)
+		key = eclipseKeyStrokeSeparator
+	default:
+		parts := strings.Split(s, eclipseKeyStrokeSeparator)
+		modifierParts = parts[:len(parts)-1]
+		key = parts[len(parts)-1]
+	}
+
+	chord := keychord.KeyChord{}
+	for _, part := range modifierParts {
+		mod, err := parseModifier(strings.ToUpper(part), plat)
+		if err != nil {
+			return keychord.KeyChord{}, fmt.Errorf("%w in %q", err, s)
+		}
+		if !slices.Contains(chord.Modifiers, mod) {
+			chord.Modifiers = append(chord.Modifiers, mod)
+		}
+	}
+
+	kc, err := fromEclipseKey(key)
+	if err != nil {
+		return keychord.KeyChord{}, fmt.Errorf("%w: %q", err, s)
+	}
+	chord.KeyCode = kc
+	return chord, nil
+}
+
+// parseModifier resolves a modifier name. M1 is Command on macOS and Ctrl elsewhere, M2 is Shift,
+// M3 is Alt and M4 is Ctrl on macOS; see org.eclipse.jface.bindings.keys.KeyLookupFactory.
+func parseModifier(name string, plat platform.Platform) (keycode.KeyModifier, error) {
+	isMac := plat == platform.PlatformMacOS
+	switch name {
+	case "M1":
+		if isMac {
+			return keycode.KeyModifierMeta, nil
+		}
+		return keycode.KeyModifierCtrl, nil
+	case "M2":
+		return keycode.KeyModifierShift, nil
+	case "M3":
+		return keycode.KeyModifierAlt, nil
+	case "M4":
+		if isMac {
+			return keycode.KeyModifierCtrl, nil
+		}
+		return "", errors.New("modifier M4 is only defined on macOS")
+	}
+	if mod, ok := eclipseModifierMapping.Get(name); ok {
+		return mod, nil
+	}
+	return "", fmt.Errorf("unknown eclipse modifier %q", name)
+}
+
+func fromEclipseKey(key string) (keycode.KeyCode, error) {
+	upper := strings.ToUpper(key)
+	if kc, ok := eclipseKeyMapping.Get(upper); ok {
+		return kc, nil
+	}
+	if kc, ok := eclipseKeyAliases[upper]; ok {
+		return kc, nil
+	}
+	kc := keycode.KeyCode(strings.ToLower(key))
+	if kc.IsValid() && !slices.Contains(eclipseUnsupportedKeys, kc) && !eclipseKeyMapping.ExistsInverse(kc) {
+		return kc, nil
+	}
+	return "", errors.New("unsupported eclipse key")
+}
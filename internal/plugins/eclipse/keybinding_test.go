@@ -0,0 +1,82 @@
+package eclipse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+func TestFormatKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "Letter", in: "ctrl+c", want: "CTRL+C"},
+		{name: "MetaIsCommand", in: "meta+shift+p", want: "COMMAND+SHIFT+P"},
+		{name: "ModifierOrder", in: "shift+ctrl+meta+alt+f5", want: "ALT+COMMAND+CTRL+SHIFT+F5"},
+		{name: "MultiChord", in: "ctrl+k ctrl+s", want: "CTRL+K CTRL+S"},
+		{name: "NamedKeys", in: "alt+up", want: "ALT+ARROW_UP"},
+		{name: "Enter", in: "ctrl+enter", want: "CTRL+CR"},
+		{name: "Numpad", in: "ctrl+numpad_add", want: "CTRL+NUMPAD_ADD"},
+		{name: "Punctuation", in: "ctrl+/", want: "CTRL+/"},
+		{name: "Plus", in: "ctrl++", want: "CTRL++"},
+		{name: "Unsupported", in: "ctrl+volumeup", wantErr: true},
+		{name: "ModifierOnly", in: "shift", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := keybinding.NewKeybinding(tc.in, keybinding.ParseOption{Separator: "+"})
+			require.NoError(t, err)
+			out, err := formatKeybinding(kb)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestParseKeybinding(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		platform platform.Platform
+		want     string
+		wantErr  bool
+	}{
+		{name: "Simple", in: "CTRL+SHIFT+F", want: "ctrl+shift+f"},
+		{name: "MultiChord", in: "CTRL+K CTRL+S", want: "ctrl+k ctrl+s"},
+		{name: "CaseInsensitive", in: "Ctrl+Shift+r", want: "ctrl+shift+r"},
+		{name: "M1OnMacOS", in: "M1+M2+R", platform: platform.PlatformMacOS, want: "meta+shift+r"},
+		{name: "M1OnLinux", in: "M1+M2+R", platform: platform.PlatformLinux, want: "ctrl+shift+r"},
+		{name: "M3", in: "M3+ARROW_DOWN", platform: platform.PlatformWindows, want: "alt+down"},
+		{name: "M4OnMacOS", in: "M4+SPACE", platform: platform.PlatformMacOS, want: "ctrl+space"},
+		{name: "M4OnWindows", in: "M4+SPACE", platform: platform.PlatformWindows, wantErr: true},
+		{name: "Alias", in: "CTRL+ENTER", want: "ctrl+enter"},
+		{name: "Function", in: "F3", want: "f3"},
+		{name: "Plus", in: "CTRL++", want: "ctrl++"},
+		{name: "UnknownModifier", in: "HYPER+K", wantErr: true},
+		{name: "UnknownKey", in: "CTRL+BREAK", wantErr: true},
+		{name: "Empty", in: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := parseKeybinding(tc.in, tc.platform)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, kb.String(keybinding.FormatOption{Separator: "+"}))
+		})
+	}
+}
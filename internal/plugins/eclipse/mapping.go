@@ -0,0 +1,72 @@
+package eclipse
+
+import (
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+// findMappingByEclipse finds the action for an Eclipse command bound in the given context.
+// Configs whose context also matches are preferred over those with a different context;
+// ties are broken by action ID to keep the result deterministic.
+func findMappingByEclipse(
+	mappingConfig *mappings.MappingConfig,
+	commandID, contextID string,
+) *mappings.ActionMappingConfig {
+	var exact, ignoreContext []string
+	for id, mapping := range mappingConfig.Mappings {
+		ec := mapping.Eclipse
+		if ec.DisableImport || ec.CommandID == "" || ec.CommandID != commandID {
+			continue
+		}
+		if ec.EffectiveContextID() == contextID {
+			exact = append(exact, id)
+		} else {
+			ignoreContext = append(ignoreContext, id)
+		}
+	}
+
+	candidates := exact
+	if len(candidates) == 0 {
+		candidates = ignoreContext
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Strings(candidates)
+	m := mappingConfig.Mappings[candidates[0]]
+	return &m
+}
+
+// isManagedKeybinding reports whether a key binding from an existing preference file
+// corresponds exactly to one of our action mappings, including export-only ones.
+func isManagedKeybinding(mappingConfig *mappings.MappingConfig, kb eclipseKeyBinding) bool {
+	if kb.CommandID == "" || len(kb.Parameters) > 0 {
+		return false
+	}
+	for _, mapping := range mappingConfig.Mappings {
+		ec := mapping.Eclipse
+		if ec.CommandID == kb.CommandID && ec.EffectiveContextID() == kb.ContextID {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPlatform reports whether a binding restricted to an SWT windowing system applies to plat.
+// Bindings without a platform apply everywhere.
+func matchesPlatform(swtPlatform string, plat platform.Platform) bool {
+	switch swtPlatform {
+	case "":
+		return true
+	case "cocoa", "carbon":
+		return plat == platform.PlatformMacOS
+	case "win32", "wpf":
+		return plat == platform.PlatformWindows
+	case "gtk", "motif", "photon":
+		return plat == platform.PlatformLinux
+	default:
+		return false
+	}
+}
@@ -0,0 +1,227 @@
+package eclipse
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// prefsEntry is one logical line of a Java properties file. Raw keeps the original text,
+// including line continuations, so untouched entries are written back byte for byte.
+type prefsEntry struct {
+	Raw   string
+	Key   string
+	Value string
+	// IsProperty is false for blank and comment lines
+	IsProperty bool
+}
+
+// prefsFile is an Eclipse preference file such as org.eclipse.ui.workbench.prefs,
+// which uses the java.util.Properties format.
+type prefsFile struct {
+	Entries []prefsEntry
+}
+
+func parsePrefs(reader io.Reader) (*prefsFile, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read eclipse preferences: %w", err)
+	}
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return &prefsFile{}, nil
+	}
+
+	physical := strings.Split(content, "\n")
+	prefs := &prefsFile{}
+	for i := 0; i < len(physical); i++ {
+		start := i
+		logical := strings.TrimLeft(physical[i], " \t\f")
+		if logical == "" || logical[0] == '#' || logical[0] == '!' {
+			prefs.Entries = append(prefs.Entries, prefsEntry{Raw: physical[i]})
+			continue
+		}
+		// A line ending in an odd number of backslashes continues on the next line
+		for endsWithContinuation(logical) && i+1 < len(physical) {
+			i++
+			logical = logical[:len(logical)-1] + strings.TrimLeft(physical[i], " \t\f")
+		}
+		key, value := splitProperty(logical)
+		prefs.Entries = append(prefs.Entries, prefsEntry{
+			Raw:        strings.Join(physical[start:i+1], "\n"),
+			Key:        unescapeProperty(key),
+			Value:      unescapeProperty(value),
+			IsProperty: true,
+		})
+	}
+	return prefs, nil
+}
+
+// Get returns the decoded value of key.
+func (p *prefsFile) Get(key string) (string, bool) {
+	for _, e := range p.Entries {
+		if e.IsProperty && e.Key == key {
+			return e.Value, true
+		}
+	}
+	return "", false
+}
+
+// Set replaces the value of key in place, or appends the key when it is not present yet.
+func (p *prefsFile) Set(key, value string) {
+	entry := prefsEntry{
+		Raw:        escapePropertyKey(key) + "=" + escapePropertyValue(value),
+		Key:        key,
+		Value:      value,
+		IsProperty: true,
+	}
+	for i, e := range p.Entries {
+		if e.IsProperty && e.Key == key {
+			p.Entries[i] = entry
+			return
+		}
+	}
+	p.Entries = append(p.Entries, entry)
+}
+
+func (p *prefsFile) write(w io.Writer) error {
+	var sb strings.Builder
+	for _, e := range p.Entries {
+		sb.WriteString(e.Raw)
+		sb.WriteString("\n")
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("failed to write eclipse preferences: %w", err)
+	}
+	return nil
+}
+
+func endsWithContinuation(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty splits a logical line at the first unescaped '=', ':' or whitespace.
+func splitProperty(line string) (string, string) {
+	keyEnd := len(line)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			keyEnd = i
+			break
+		}
+	}
+	rest := strings.TrimLeft(line[keyEnd:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return line[:keyEnd], rest
+}
+
+func unescapeProperty(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var units []uint16
+	var sb strings.Builder
+	flush := func() {
+		if len(units) > 0 {
+			sb.WriteString(string(utf16.Decode(units)))
+			units = nil
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			flush()
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			flush()
+			sb.WriteByte('\t')
+		case 'n':
+			flush()
+			sb.WriteByte('\n')
+		case 'r':
+			flush()
+			sb.WriteByte('\r')
+		case 'f':
+			flush()
+			sb.WriteByte('\f')
+		case 'u':
+			if i+4 < len(s) {
+				if v, err := strconv.ParseUint(s[i+1:i+5], 16, 16); err == nil {
+					// Surrogate pairs are written as two consecutive escapes
+					units = append(units, uint16(v))
+					i += 4
+					continue
+				}
+			}
+			flush()
+			sb.WriteByte('u')
+		default:
+			flush()
+			sb.WriteByte(s[i])
+		}
+	}
+	flush()
+	return sb.String()
+}
+
+func escapePropertyKey(s string) string {
+	return escapeProperty(s, true)
+}
+
+func escapePropertyValue(s string) string {
+	return escapeProperty(s, false)
+}
+
+// escapeProperty escapes s the way java.util.Properties#store does.
+func escapeProperty(s string, isKey bool) string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case ' ':
+			if i == 0 || isKey {
+				sb.WriteString(`\ `)
+			} else {
+				sb.WriteByte(' ')
+			}
+		default:
+			if r < 0x20 || r > 0x7e {
+				for _, u := range utf16.Encode([]rune{r}) {
+					fmt.Fprintf(&sb, `\u%04X`, u)
+				}
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
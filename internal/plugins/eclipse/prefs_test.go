@@ -0,0 +1,65 @@
+package eclipse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrefs(t *testing.T) {
+	input := "eclipse.preferences.version=1\n" +
+		"# comment\n" +
+		"RECENT_WORKSPACES=C\\:\\\\Users\\\\me\\\\ws\\n/home/me/ws2\n" +
+		"key\\ with\\ spaces = value\n" +
+		"multi=first \\\n    second\n" +
+		"unicode=caf\\u00E9 \\uD83D\\uDE00\n"
+
+	prefs, err := parsePrefs(strings.NewReader(input))
+	require.NoError(t, err)
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "eclipse.preferences.version", want: "1"},
+		{key: "RECENT_WORKSPACES", want: "C:\\Users\\me\\ws\n/home/me/ws2"},
+		{key: "key with spaces", want: "value"},
+		{key: "multi", want: "first second"},
+		{key: "unicode", want: "café 😀"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.key, func(t *testing.T) {
+			got, ok := prefs.Get(tc.key)
+			require.True(t, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	// Untouched entries are written back as they were
+	var sb strings.Builder
+	require.NoError(t, prefs.write(&sb))
+	assert.Equal(t, input, sb.String())
+}
+
+func TestPrefsSetRoundTrip(t *testing.T) {
+	prefs, err := parsePrefs(strings.NewReader("a=1\nb=2\n"))
+	require.NoError(t, err)
+
+	value := "<?xml version=\"1.0\"?>\n<root a=\"x:y\">#!\tcafé</root>"
+	prefs.Set("a", value)
+	prefs.Set("c", "3")
+
+	var sb strings.Builder
+	require.NoError(t, prefs.write(&sb))
+	assert.Equal(t,
+		"a=<?xml version\\=\"1.0\"?>\\n<root a\\=\"x\\:y\">\\#\\!\\tcaf\\u00E9</root>\nb=2\nc=3\n",
+		sb.String(),
+	)
+
+	reparsed, err := parsePrefs(strings.NewReader(sb.String()))
+	require.NoError(t, err)
+	got, _ := reparsed.Get("a")
+	assert.Equal(t, value, got)
+}
@@ -0,0 +1,57 @@
+package emacs
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+)
+
+const bindingsFileName = "onekeymap-bindings.el"
+
+// ConfigDetect returns the path of the generated onekeymap-bindings.el inside the user's Emacs directory.
+// ~/.emacs.d is used unless only the XDG location ~/.config/emacs exists, mirroring Emacs' own lookup.
+func (p *emacsPlugin) ConfigDetect(opts pluginapi.ConfigDetectOptions) (paths []string, installed bool, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var configDir string
+	switch runtime.GOOS {
+	case "darwin", "linux":
+		configDir = filepath.Join(home, ".emacs.d")
+		xdgDir := filepath.Join(home, ".config", "emacs")
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			xdgDir = filepath.Join(xdg, "emacs")
+		}
+		if _, statErr := os.Stat(configDir); statErr != nil {
+			if _, statErr := os.Stat(xdgDir); statErr == nil {
+				configDir = xdgDir
+			}
+		}
+	case "windows":
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			return nil, false, fmt.Errorf("APPDATA environment variable not set, %w", pluginapi.ErrNotSupported)
+		}
+		configDir = filepath.Join(appData, ".emacs.d")
+	default:
+		return nil, false, fmt.Errorf(
+			"automatic path discovery is only supported on macOS, Linux, and Windows, %w",
+			pluginapi.ErrNotSupported,
+		)
+	}
+
+	if opts.Sandbox {
+		installed = false
+	} else {
+		_, err = exec.LookPath("emacs")
+		installed = err == nil
+	}
+
+	return []string{filepath.Join(configDir, bindingsFileName)}, installed, nil
+}
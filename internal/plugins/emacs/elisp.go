@@ -0,0 +1,394 @@
+package emacs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+const elispFileHeader = `;;; onekeymap-bindings.el --- Keybindings generated by onekeymap  -*- lexical-binding: t; -*-
+
+;; Generated by onekeymap. Do not edit: this file is overwritten on every export.
+;; Load it from your init file with: (load (locate-user-emacs-file "onekeymap-bindings"))
+
+;;; Code:
+`
+
+const elispFileFooter = `
+(provide 'onekeymap-bindings)
+;;; onekeymap-bindings.el ends here
+`
+
+// elispEnsurePrefixDefun defines the helpers for multi-chord bindings such as "C-k C-s": an unbound
+// first key is made a prefix key, while one bound to a command (C-k runs kill-line) keeps its
+// binding, and the sequences that start with it are skipped with a warning.
+const elispEnsurePrefixDefun = `
+;; Key sequences such as "C-k C-s" need their leading keys to be prefix keys. A leading key that
+;; is already bound to a command keeps its binding: sequences that start with it are not defined
+;; and a warning names the key. Unbind the key in your init file to use those sequences.
+
+(defun onekeymap--ensure-prefix (map key)
+  "Make KEY a prefix key in MAP unless it is bound to a command."
+  (let ((binding (lookup-key map (kbd key))))
+    (cond
+     ;; A number means a shorter prefix of KEY is bound to a command; it has been warned about.
+     ((or (keymapp binding) (numberp binding)))
+     ((null binding) (define-key map (kbd key) (make-sparse-keymap)))
+     (t (display-warning 'onekeymap
+                         (format "%s is bound to %s, skipping key sequences that start with it"
+                                 key binding))))))
+
+(defun onekeymap--prefix-p (map key)
+  "Return non-nil if KEY is a prefix key in MAP."
+  (keymapp (lookup-key map (kbd key))))
+`
+
+// emacsBinding is a single key binding in an Emacs keymap.
+type emacsBinding struct {
+	Keymap  string `json:"keymap"`
+	Keys    string `json:"keys"`
+	Command string `json:"command"`
+}
+
+// writeElispBindings renders bindings as a standalone elisp file. Global bindings use
+// keymap-global-set, bindings in other keymaps use define-key. A multi-chord binding is only
+// defined when its prefix is a prefix key once the file has run onekeymap--ensure-prefix.
+func writeElispBindings(w io.Writer, bindings []emacsBinding) error {
+	var sb strings.Builder
+	sb.WriteString(elispFileHeader)
+
+	prefixes := prefixKeys(bindings)
+	if len(prefixes) > 0 {
+		sb.WriteString(elispEnsurePrefixDefun)
+		sb.WriteString("\n")
+		for _, p := range prefixes {
+			fmt.Fprintf(&sb, "(onekeymap--ensure-prefix %s %s)\n", p.Keymap, elispQuote(p.Keys))
+		}
+	}
+
+	sb.WriteString("\n")
+	for _, b := range bindings {
+		var form string
+		if b.Keymap == mappings.EmacsGlobalMap {
+			form = fmt.Sprintf("(keymap-global-set %s #'%s)", elispQuote(b.Keys), b.Command)
+		} else {
+			form = fmt.Sprintf("(define-key %s (kbd %s) #'%s)", b.Keymap, elispQuote(b.Keys), b.Command)
+		}
+		if chords := strings.Fields(b.Keys); len(chords) > 1 {
+			prefix := strings.Join(chords[:len(chords)-1], " ")
+			form = fmt.Sprintf("(when (onekeymap--prefix-p %s %s)\n  %s)", b.Keymap, elispQuote(prefix), form)
+		}
+		sb.WriteString(form + "\n")
+	}
+	sb.WriteString(elispFileFooter)
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("failed to write elisp bindings: %w", err)
+	}
+	return nil
+}
+
+// prefixKeys returns every proper prefix of the bindings' key sequences, in first-seen order
+// with shorter prefixes first, as (keymap, keys) pairs with an empty command.
+func prefixKeys(bindings []emacsBinding) []emacsBinding {
+	var result []emacsBinding
+	seen := make(map[emacsBinding]struct{})
+	for _, b := range bindings {
+		chords := strings.Fields(b.Keys)
+		for i := 1; i < len(chords); i++ {
+			p := emacsBinding{Keymap: b.Keymap, Keys: strings.Join(chords[:i], " ")}
+			if _, ok := seen[p]; ok {
+				continue
+			}
+			seen[p] = struct{}{}
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func elispQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// parseElispBindings extracts key bindings from global-set-key, keymap-global-set,
+// keymap-set and define-key forms anywhere in the source. Forms whose keymap, key or command
+// is not a literal (e.g. a lambda command or a vector key) are ignored. Keys given as raw
+// event strings (like "\C-ca") are returned verbatim and fail to parse later.
+func parseElispBindings(reader io.Reader) ([]emacsBinding, error) {
+	src, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read elisp config: %w", err)
+	}
+	forms, err := readSexps(string(src))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []emacsBinding
+	var walk func(s sexp)
+	walk = func(s sexp) {
+		if !s.isList() {
+			return
+		}
+		if b, ok := bindingFromForm(s); ok {
+			result = append(result, b)
+			return
+		}
+		for _, child := range s.list {
+			walk(child)
+		}
+	}
+	for _, f := range forms {
+		walk(f)
+	}
+	return result, nil
+}
+
+// bindingFromForm recognizes a single binding form.
+func bindingFromForm(form sexp) (emacsBinding, bool) {
+	if len(form.list) == 0 || form.list[0].kind != sexpSymbol {
+		return emacsBinding{}, false
+	}
+	args := form.list[1:]
+
+	var keymapArg, keyArg, commandArg sexp
+	switch form.list[0].text {
+	case "global-set-key":
+		if len(args) < 2 {
+			return emacsBinding{}, false
+		}
+		keymapArg, keyArg, commandArg = sexp{kind: sexpSymbol, text: mappings.EmacsGlobalMap}, args[0], args[1]
+	case "keymap-global-set":
+		if len(args) < 2 {
+			return emacsBinding{}, false
+		}
+		keymapArg, keyArg, commandArg = sexp{kind: sexpSymbol, text: mappings.EmacsGlobalMap}, args[0], args[1]
+	case "keymap-set":
+		if len(args) < 3 {
+			return emacsBinding{}, false
+		}
+		keymapArg, keyArg, commandArg = args[0], args[1], args[2]
+	case "define-key":
+		if len(args) < 3 {
+			return emacsBinding{}, false
+		}
+		keymapArg, keyArg, commandArg = args[0], args[1], args[2]
+	default:
+		return emacsBinding{}, false
+	}
+
+	if keymapArg.kind != sexpSymbol || keymapArg.quoted {
+		return emacsBinding{}, false
+	}
+	keys, ok := keyDescription(keyArg)
+	if !ok {
+		return emacsBinding{}, false
+	}
+	if commandArg.kind != sexpSymbol || !commandArg.quoted {
+		return emacsBinding{}, false
+	}
+	return emacsBinding{Keymap: keymapArg.text, Keys: keys, Command: commandArg.text}, true
+}
+
+// keyDescription returns the key description of a key argument: a string literal, or (kbd "...").
+// For global-set-key/define-key a bare string is a raw event string rather than a description;
+// it is returned as written and only plain descriptions without escapes will parse.
+func keyDescription(arg sexp) (string, bool) {
+	switch {
+	case arg.kind == sexpString:
+		return arg.text, true
+	case arg.isList() && len(arg.list) == 2 && arg.list[0].kind == sexpSymbol &&
+		(arg.list[0].text == "kbd" || arg.list[0].text == "key-description") && arg.list[1].kind == sexpString:
+		return arg.list[1].text, true
+	default:
+		return "", false
+	}
+}
+
+type sexpKind int
+
+const (
+	sexpSymbol sexpKind = iota
+	sexpString
+	sexpList
+)
+
+// sexp is a minimal s-expression: a symbol, a string or a list (vectors are read as lists).
+type sexp struct {
+	kind   sexpKind
+	text   string
+	list   []sexp
+	quoted bool // preceded by ' or #'
+}
+
+func (s sexp) isList() bool { return s.kind == sexpList }
+
+// readSexps is a minimal elisp reader. It understands lists, vectors, strings, symbols,
+// character literals, quotes and comments, which is enough to find key binding forms.
+func readSexps(src string) ([]sexp, error) {
+	r := &sexpReader{src: src}
+	var result []sexp
+	for {
+		s, ok, err := r.read()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return result, nil
+		}
+		result = append(result, s)
+	}
+}
+
+type sexpReader struct {
+	src string
+	pos int
+}
+
+var errUnexpectedClose = errors.New("unexpected closing paren")
+
+func (r *sexpReader) skipSpaceAndComments() {
+	for r.pos < len(r.src) {
+		c := r.src[r.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			r.pos++
+		case c == ';':
+			for r.pos < len(r.src) && r.src[r.pos] != '\n' {
+				r.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// read returns the next s-expression; ok is false at end of input.
+func (r *sexpReader) read() (sexp, bool, error) {
+	r.skipSpaceAndComments()
+	if r.pos >= len(r.src) {
+		return sexp{}, false, nil
+	}
+	start := r.pos
+	c := r.src[r.pos]
+	switch {
+	case c == '(' || c == '[':
+		closing := byte(')')
+		if c == '[' {
+			closing = ']'
+		}
+		r.pos++
+		var items []sexp
+		for {
+			r.skipSpaceAndComments()
+			if r.pos >= len(r.src) {
+				return sexp{}, false, fmt.Errorf("unterminated list in elisp source at offset %d", start)
+			}
+			if r.src[r.pos] == closing {
+				r.pos++
+				return sexp{kind: sexpList, list: items}, true, nil
+			}
+			item, ok, err := r.read()
+			if err != nil {
+				return sexp{}, false, err
+			}
+			if !ok {
+				return sexp{}, false, fmt.Errorf("unterminated list in elisp source at offset %d", start)
+			}
+			items = append(items, item)
+		}
+	case c == ')' || c == ']':
+		return sexp{}, false, fmt.Errorf("%w in elisp source at offset %d", errUnexpectedClose, start)
+	case c == '\'' || c == '`' || c == ',' || strings.HasPrefix(r.src[r.pos:], "#'"):
+		if c == '#' {
+			r.pos++
+		}
+		r.pos++
+		s, ok, err := r.read()
+		if err != nil {
+			return sexp{}, false, err
+		}
+		if !ok {
+			return sexp{}, false, fmt.Errorf("nothing to quote in elisp source at offset %d", start)
+		}
+		s.quoted = c == '\'' || c == '#'
+		return s, true, nil
+	case c == '"':
+		s, err := r.readString()
+		if err != nil {
+			return sexp{}, false, err
+		}
+		return sexp{kind: sexpString, text: s}, true, nil
+	case c == '?':
+		// character literal such as ?a, ?\C-c or ?\)
+		r.pos++
+		if r.pos < len(r.src) && r.src[r.pos] == '\\' {
+			r.pos++
+		}
+		if r.pos < len(r.src) {
+			r.pos++
+		}
+		r.readSymbolRest()
+		return sexp{kind: sexpSymbol, text: r.src[start:r.pos]}, true, nil
+	default:
+		r.readSymbolRest()
+		if r.pos == start {
+			r.pos++
+		}
+		return sexp{kind: sexpSymbol, text: r.src[start:r.pos]}, true, nil
+	}
+}
+
+func (r *sexpReader) readSymbolRest() {
+	for r.pos < len(r.src) {
+		c := r.src[r.pos]
+		if c == '\\' && r.pos+1 < len(r.src) {
+			r.pos += 2
+			continue
+		}
+		if strings.IndexByte(" \t\n\r\f()[]\";'`,", c) >= 0 {
+			return
+		}
+		r.pos++
+	}
+}
+
+// readString decodes a string literal. Only \" \\ \n and \t are decoded; other escapes such as
+// \C- in raw event strings are kept with their backslash.
+func (r *sexpReader) readString() (string, error) {
+	start := r.pos
+	r.pos++ // opening quote
+	var sb strings.Builder
+	for r.pos < len(r.src) {
+		c := r.src[r.pos]
+		switch {
+		case c == '"':
+			r.pos++
+			return sb.String(), nil
+		case c == '\\' && r.pos+1 < len(r.src):
+			r.pos++
+			switch e := r.src[r.pos]; e {
+			case '"', '\\':
+				sb.WriteByte(e)
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case '\n':
+				// escaped newline is ignored
+			default:
+				sb.WriteByte('\\')
+				sb.WriteByte(e)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+		r.pos++
+	}
+	return "", fmt.Errorf("unterminated string in elisp source at offset %d", start)
+}
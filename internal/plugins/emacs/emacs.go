@@ -0,0 +1,39 @@
+package emacs
+
+import (
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+var _ pluginapi.Plugin = (*emacsPlugin)(nil)
+
+// emacsPlugin implements the plugins.Plugin interface for Emacs.
+type emacsPlugin struct {
+	mappingConfig *mappings.MappingConfig
+	importer      pluginapi.PluginImporter
+	exporter      pluginapi.PluginExporter
+	logger        *slog.Logger
+}
+
+// New creates a new Emacs plugin instance.
+func New(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) pluginapi.Plugin {
+	return &emacsPlugin{
+		mappingConfig: mappingConfig,
+		importer:      newImporter(mappingConfig, logger, recorder),
+		exporter:      newExporter(mappingConfig, logger, diff.NewJSONASCIIDiffer()),
+		logger:        logger,
+	}
+}
+
+// EditorType returns the unique identifier for Emacs.
+func (p *emacsPlugin) EditorType() pluginapi.EditorType { return pluginapi.EditorTypeEmacs }
+
+// Importer returns the importer for this plugin.
+func (p *emacsPlugin) Importer() (pluginapi.PluginImporter, error) { return p.importer, nil }
+
+// Exporter returns the exporter for this plugin.
+func (p *emacsPlugin) Exporter() (pluginapi.PluginExporter, error) { return p.exporter, nil }
@@ -0,0 +1,9 @@
+package emacs
+
+import "errors"
+
+var (
+	ErrNotSupportKeyChords = errors.New("key chord is not supported by emacs")
+	// ErrPrefixKeyBound is reported when a key sequence extends a key that is itself bound to a command.
+	ErrPrefixKeyBound = errors.New("prefix key is already bound to a command")
+)
@@ -0,0 +1,157 @@
+package emacs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/internal/export"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+type emacsExporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	differ        diff.Differ
+}
+
+func newExporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	differ diff.Differ,
+) pluginapi.PluginExporter {
+	return &emacsExporter{mappingConfig: mappingConfig, logger: logger, differ: differ}
+}
+
+// Export writes the keymap as a generated onekeymap-bindings.el.
+// The file is owned by onekeymap, so the existing file is only read for the diff report.
+func (e *emacsExporter) Export(
+	ctx context.Context,
+	destination io.Writer,
+	setting keymap.Keymap,
+	opts pluginapi.PluginExportOption,
+) (*pluginapi.PluginExportReport, error) {
+	var existing []emacsBinding
+	if opts.ExistingConfig != nil {
+		var err error
+		existing, err = parseElispBindings(opts.ExistingConfig)
+		if err != nil {
+			e.logger.WarnContext(ctx, "Failed to parse existing config, it will be overwritten", "error", err)
+			existing = nil
+		}
+	}
+
+	marker := export.NewMarker(&setting)
+	managed := e.identifyManagedKeybindings(ctx, &setting, marker)
+
+	if err := writeElispBindings(destination, managed); err != nil {
+		return nil, err
+	}
+
+	return &pluginapi.PluginExportReport{
+		BaseEditorConfig:   existing,
+		ExportEditorConfig: managed,
+		SkipReport:         marker.Report(),
+		ExportedReport:     marker.ExportedReport(),
+	}, nil
+}
+
+type emacsCandidate struct {
+	action  string
+	kb      keybinding.Keybinding
+	binding emacsBinding
+}
+
+// identifyManagedKeybindings generates Emacs bindings from KeymapSetting.
+// A key sequence whose prefix is bound to a command in the same keymap cannot be defined
+// in Emacs, so such sequences are skipped in favor of the shorter binding.
+func (e *emacsExporter) identifyManagedKeybindings(
+	ctx context.Context,
+	setting *keymap.Keymap,
+	marker *export.Marker,
+) []emacsBinding {
+	var candidates []emacsCandidate
+	for _, km := range setting.Actions {
+		mapping, usedFallback := e.mappingConfig.GetExportAction(km.Name, pluginapi.EditorTypeEmacs)
+		if mapping == nil || mapping.Emacs.Command == "" {
+			for _, b := range km.Bindings {
+				if len(b.KeyChords) > 0 {
+					marker.MarkSkippedForReason(km.Name, &b, pluginapi.ErrActionNotSupported)
+				}
+			}
+			continue
+		}
+
+		if usedFallback {
+			e.logger.InfoContext(ctx, "Action not directly supported, using fallback action",
+				"originalAction", km.Name,
+				"fallbackAction", mapping.ID,
+			)
+		}
+
+		for _, b := range km.Bindings {
+			if len(b.KeyChords) == 0 {
+				continue
+			}
+			keys, err := formatKeybinding(b)
+			if err != nil {
+				e.logger.DebugContext(ctx, "Skipping keybinding with un-formattable key", "action", km.Name, "error", err)
+				marker.MarkSkippedForReason(km.Name, &b, &pluginapi.UnsupportedExportActionError{Note: err.Error()})
+				continue
+			}
+			candidates = append(candidates, emacsCandidate{
+				action: km.Name,
+				kb:     b,
+				binding: emacsBinding{
+					Keymap:  mapping.Emacs.EffectiveKeymap(),
+					Keys:    keys,
+					Command: mapping.Emacs.Command,
+				},
+			})
+		}
+	}
+
+	bound := make(map[emacsBinding]struct{}, len(candidates))
+	for _, c := range candidates {
+		bound[emacsBinding{Keymap: c.binding.Keymap, Keys: c.binding.Keys}] = struct{}{}
+	}
+
+	var result []emacsBinding
+	seen := make(map[emacsBinding]struct{})
+	for _, c := range candidates {
+		if prefix, ok := boundPrefix(bound, c.binding); ok {
+			e.logger.DebugContext(ctx, "Skipping key sequence whose prefix is bound to a command",
+				"action", c.action, "keys", c.binding.Keys, "prefix", prefix)
+			marker.MarkSkippedForReason(c.action, &c.kb, &pluginapi.UnsupportedExportActionError{
+				Note: fmt.Sprintf("%s: %s", ErrPrefixKeyBound, prefix),
+			})
+			continue
+		}
+		marker.MarkExported(c.action, c.kb)
+
+		if _, dup := seen[c.binding]; dup {
+			continue
+		}
+		seen[c.binding] = struct{}{}
+		result = append(result, c.binding)
+	}
+	return result
+}
+
+// boundPrefix returns the first proper prefix of b's key sequence that is itself bound in the same keymap.
+func boundPrefix(bound map[emacsBinding]struct{}, b emacsBinding) (string, bool) {
+	chords := strings.Fields(b.Keys)
+	for i := 1; i < len(chords); i++ {
+		prefix := strings.Join(chords[:i], " ")
+		if _, ok := bound[emacsBinding{Keymap: b.Keymap, Keys: prefix}]; ok {
+			return prefix, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,105 @@
+package emacs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func newAction(actionName string, keybindings ...string) keymap.Action {
+	var bindings []keybinding.Keybinding
+	for _, kb := range keybindings {
+		b, err := keybinding.NewKeybinding(kb, keybinding.ParseOption{Separator: "+"})
+		if err != nil {
+			panic(err)
+		}
+		bindings = append(bindings, b)
+	}
+	return keymap.Action{
+		Name:     actionName,
+		Bindings: bindings,
+	}
+}
+
+func TestExportEmacsBindings(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		setting     keymap.Keymap
+		expected    string
+		wantSkipped []string
+	}{
+		{
+			name: "global and keymap specific bindings",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.edit.copy", "meta+c", "meta+c"),
+				newAction("actions.test.childSupported", "ctrl+alt+f5"),
+			}},
+			expected: elispFileHeader + `
+(keymap-global-set "s-c" #'kill-ring-save)
+(define-key prog-mode-map (kbd "C-M-<f5>") #'child-supported-command)
+` + elispFileFooter,
+		},
+		{
+			name: "multi-chord bindings define prefix keys",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+k ctrl+c", "ctrl+k ctrl+x ctrl+c"),
+			}},
+			expected: elispFileHeader + elispEnsurePrefixDefun + `
+(onekeymap--ensure-prefix global-map "C-k")
+(onekeymap--ensure-prefix global-map "C-k C-x")
+
+(when (onekeymap--prefix-p global-map "C-k")
+  (keymap-global-set "C-k C-c" #'kill-ring-save))
+(when (onekeymap--prefix-p global-map "C-k C-x")
+  (keymap-global-set "C-k C-x C-c" #'kill-ring-save))
+` + elispFileFooter,
+		},
+		{
+			name: "sequences extending a bound key are skipped",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+\\", "ctrl+\\ ctrl+c"),
+				newAction("actions.test.withArgs", "ctrl+e"),
+			}},
+			expected: elispFileHeader + `
+(keymap-global-set "C-\\" #'kill-ring-save)
+` + elispFileFooter,
+			wantSkipped: []string{"actions.edit.copy", "actions.test.withArgs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			exporter, err := p.Exporter()
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			report, err := exporter.Export(context.Background(), &buf, tt.setting, pluginapi.PluginExportOption{
+				// The generated file is fully owned by onekeymap and gets replaced
+				ExistingConfig: strings.NewReader(`(keymap-global-set "C-c f" #'find-file)`),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+
+			var skipped []string
+			for _, s := range report.SkipReport.SkipActions {
+				skipped = append(skipped, s.Action)
+			}
+			assert.ElementsMatch(t, tt.wantSkipped, skipped)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package emacs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/dedup"
+	"github.com/xinnjie/onekeymap-cli/internal/imports"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+type emacsImporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	reporter      *metrics.UnknownActionReporter
+}
+
+func newImporter(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) *emacsImporter {
+	return &emacsImporter{
+		mappingConfig: mappingConfig,
+		logger:        logger,
+		reporter:      metrics.NewUnknownActionReporter(recorder),
+	}
+}
+
+// Import reads an elisp file (init.el or a generated onekeymap-bindings.el) and converts its
+// global-set-key, keymap-global-set, keymap-set and define-key forms into the universal KeymapSetting format.
+func (i *emacsImporter) Import(
+	ctx context.Context,
+	source io.Reader,
+	_ pluginapi.PluginImportOption,
+) (pluginapi.PluginImportResult, error) {
+	bindings, err := parseElispBindings(source)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, fmt.Errorf("failed to parse emacs config: %w", err)
+	}
+
+	setting := keymap.Keymap{}
+	marker := imports.NewMarker()
+	for _, b := range bindings {
+		kb, err := parseKeybinding(b.Keys)
+		if err != nil {
+			i.logger.DebugContext(ctx, "failed to parse emacs key sequence", "keys", b.Keys, "error", err)
+			marker.MarkSkipped(b.Command, nil, fmt.Errorf("failed to parse key sequence '%s': %w", b.Keys, err))
+			continue
+		}
+
+		actionID, err := actionIDFromEmacs(i.mappingConfig, b.Command, b.Keymap)
+		if err != nil {
+			i.logger.DebugContext(ctx, "failed to find action", "command", b.Command, "keymap", b.Keymap, "error", err)
+			i.reporter.ReportUnknownCommand(ctx, pluginapi.EditorTypeEmacs, b.Command)
+			marker.MarkSkipped(b.Command, &kb, err)
+			continue
+		}
+
+		setting.Actions = append(setting.Actions, keymap.Action{
+			Name:     actionID,
+			Bindings: []keybinding.Keybinding{kb},
+		})
+		marker.MarkImported(actionID, b.Command, kb, kb)
+	}
+
+	setting.Actions = dedup.Actions(setting.Actions)
+	result := pluginapi.PluginImportResult{Keymap: setting}
+	result.Report.SkipReport = marker.Report()
+	result.Report.ImportedReport = marker.ImportedReport()
+	return result, nil
+}
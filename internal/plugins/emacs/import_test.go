@@ -0,0 +1,102 @@
+package emacs
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func TestImportEmacsBindings(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    []keymap.Action
+		wantSkipped int
+		wantErr     bool
+	}{
+		{
+			name: "global binding forms",
+			input: `;; init.el
+(global-set-key (kbd "s-c") 'kill-ring-save)
+(keymap-global-set "C-c w" #'kill-ring-save)
+(keymap-set global-map "C-c C-w" #'kill-ring-save)`,
+			expected: []keymap.Action{newAction("actions.edit.copy", "meta+c", "ctrl+c w", "ctrl+c ctrl+w")},
+		},
+		{
+			name: "keymap must match mapping",
+			input: `(define-key prog-mode-map (kbd "C-M-<f5>") #'child-supported-command)
+(define-key text-mode-map (kbd "C-c c") #'kill-ring-save)`,
+			expected:    []keymap.Action{newAction("actions.test.childSupported", "ctrl+alt+f5")},
+			wantSkipped: 1,
+		},
+		{
+			name: "nested forms are found and non-literal forms ignored",
+			input: `(with-eval-after-load 'prog-mode
+  (define-key prog-mode-map (kbd "C-c /") #'child-supported-command))
+(use-package foo
+  :config
+  (global-set-key (kbd "C-c x") (lambda () (interactive) (message "x")))
+  (global-set-key [f6] 'kill-ring-save)
+  (global-set-key (kbd "C-c u") #'unknown-command))
+(global-set-key "\C-cy" 'kill-ring-save)`,
+			expected:    []keymap.Action{newAction("actions.test.childSupported", "ctrl+c /")},
+			wantSkipped: 2,
+		},
+		{
+			name:    "unbalanced parens",
+			input:   `(global-set-key (kbd "C-c a") 'kill-ring-save`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			importer, err := p.Importer()
+			require.NoError(t, err)
+
+			result, err := importer.Import(context.Background(), strings.NewReader(tt.input), pluginapi.PluginImportOption{})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, result.Keymap.Actions)
+			assert.Len(t, result.Report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "meta+c", "ctrl+k ctrl+\\"),
+		newAction("actions.test.childSupported", "ctrl+alt+shift+f5"),
+	}}
+	var buf strings.Builder
+	_, err = exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	result, err := importer.Import(context.Background(), strings.NewReader(buf.String()), pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, setting.Actions, result.Keymap.Actions)
+}
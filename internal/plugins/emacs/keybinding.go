@@ -0,0 +1,200 @@
+package emacs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/xinnjie/onekeymap-cli/internal/bimap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keychord"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keycode"
+)
+
+//nolint:gochecknoglobals // static lookup tables shared across parse/format functions; initialized once and read-only
+var (
+	// Emacs Meta is the Alt/Option key, while our meta (Command/Windows key) is Emacs' Super.
+	emacsModifierMapping = bimap.NewBiMapFromMap(map[string]keycode.KeyModifier{
+		"C": keycode.KeyModifierCtrl,
+		"M": keycode.KeyModifierAlt,
+		"S": keycode.KeyModifierShift,
+		"s": keycode.KeyModifierMeta,
+	})
+
+	// emacsModifierOrder is the order required by `key-valid-p`.
+	emacsModifierOrder = []keycode.KeyModifier{
+		keycode.KeyModifierCtrl, keycode.KeyModifierAlt, keycode.KeyModifierShift, keycode.KeyModifierMeta,
+	}
+
+	// see (info "(elisp) Key Sequences") and (info "(elisp) Function Keys")
+	emacsKeyMapping = bimap.NewBiMapFromMap(map[string]keycode.KeyCode{
+		"SPC":           keycode.KeyCodeSpace,
+		"RET":           keycode.KeyCodeEnter,
+		"TAB":           keycode.KeyCodeTab,
+		"ESC":           keycode.KeyCodeEscape,
+		"DEL":           keycode.KeyCodeBackspace,
+		"<delete>":      keycode.KeyCodeDelete,
+		"<insert>":      keycode.KeyCodeInsert,
+		"<home>":        keycode.KeyCodeHome,
+		"<end>":         keycode.KeyCodeEnd,
+		"<prior>":       keycode.KeyCodePageUp,
+		"<next>":        keycode.KeyCodePageDown,
+		"<up>":          keycode.KeyCodeUp,
+		"<down>":        keycode.KeyCodeDown,
+		"<left>":        keycode.KeyCodeLeft,
+		"<right>":       keycode.KeyCodeRight,
+		"<f1>":          keycode.KeyCodeF1,
+		"<f2>":          keycode.KeyCodeF2,
+		"<f3>":          keycode.KeyCodeF3,
+		"<f4>":          keycode.KeyCodeF4,
+		"<f5>":          keycode.KeyCodeF5,
+		"<f6>":          keycode.KeyCodeF6,
+		"<f7>":          keycode.KeyCodeF7,
+		"<f8>":          keycode.KeyCodeF8,
+		"<f9>":          keycode.KeyCodeF9,
+		"<f10>":         keycode.KeyCodeF10,
+		"<f11>":         keycode.KeyCodeF11,
+		"<f12>":         keycode.KeyCodeF12,
+		"<f13>":         keycode.KeyCodeF13,
+		"<f14>":         keycode.KeyCodeF14,
+		"<f15>":         keycode.KeyCodeF15,
+		"<f16>":         keycode.KeyCodeF16,
+		"<f17>":         keycode.KeyCodeF17,
+		"<f18>":         keycode.KeyCodeF18,
+		"<f19>":         keycode.KeyCodeF19,
+		"<f20>":         keycode.KeyCodeF20,
+		"<kp-0>":        keycode.KeyCodeNumpad0,
+		"<kp-1>":        keycode.KeyCodeNumpad1,
+		"<kp-2>":        keycode.KeyCodeNumpad2,
+		"<kp-3>":        keycode.KeyCodeNumpad3,
+		"<kp-4>":        keycode.KeyCodeNumpad4,
+		"<kp-5>":        keycode.KeyCodeNumpad5,
+		"<kp-6>":        keycode.KeyCodeNumpad6,
+		"<kp-7>":        keycode.KeyCodeNumpad7,
+		"<kp-8>":        keycode.KeyCodeNumpad8,
+		"<kp-9>":        keycode.KeyCodeNumpad9,
+		"<kp-add>":      keycode.KeyCodeNumpadAdd,
+		"<kp-subtract>": keycode.KeyCodeNumpadSubtract,
+		"<kp-multiply>": keycode.KeyCodeNumpadMultiply,
+		"<kp-divide>":   keycode.KeyCodeNumpadDivide,
+		"<kp-decimal>":  keycode.KeyCodeNumpadDecimal,
+		"<kp-enter>":    keycode.KeyCodeNumpadEnter,
+		"<kp-equal>":    keycode.KeyCodeNumpadEquals,
+	})
+
+	// emacsKeyAliases are accepted on import but never written.
+	emacsKeyAliases = map[string]keycode.KeyCode{
+		"<return>":     keycode.KeyCodeEnter,
+		"<tab>":        keycode.KeyCodeTab,
+		"<escape>":     keycode.KeyCodeEscape,
+		"<backspace>":  keycode.KeyCodeBackspace,
+		"<deletechar>": keycode.KeyCodeDelete,
+	}
+)
+
+// formatKeybinding formats a keybinding as an Emacs key description, e.g. "C-k C-s" or "s-S-<f5>".
+// Every chord but the last becomes a prefix key.
+func formatKeybinding(kb keybinding.Keybinding) (string, error) {
+	chords := make([]string, 0, len(kb.KeyChords))
+	for _, chord := range kb.KeyChords {
+		s, err := formatKeyChord(chord)
+		if err != nil {
+			return "", err
+		}
+		chords = append(chords, s)
+	}
+	return strings.Join(chords, " "), nil
+}
+
+func formatKeyChord(chord keychord.KeyChord) (string, error) {
+	key, ok := emacsKeyMapping.GetInverse(chord.KeyCode)
+	if !ok {
+		if len(chord.KeyCode) != 1 {
+			return "", fmt.Errorf("%w: %q", ErrNotSupportKeyChords, chord.String(keychord.FormatOption{Separator: "+"}))
+		}
+		key = string(chord.KeyCode)
+	}
+
+	// A shifted letter without other modifiers is written as the uppercase letter
+	isLetter := len(key) == 1 && unicode.IsLower(rune(key[0]))
+	if isLetter && len(chord.Modifiers) == 1 && chord.Modifiers[0] == keycode.KeyModifierShift {
+		return strings.ToUpper(key), nil
+	}
+
+	var sb strings.Builder
+	for _, mod := range emacsModifierOrder {
+		if slices.Contains(chord.Modifiers, mod) {
+			name, _ := emacsModifierMapping.GetInverse(mod)
+			sb.WriteString(name + "-")
+		}
+	}
+	sb.WriteString(key)
+	return sb.String(), nil
+}
+
+// parseKeybinding parses an Emacs key description such as "C-c C-k" or "M-<f5>" into a keybinding.
+func parseKeybinding(keys string) (keybinding.Keybinding, error) {
+	fields := strings.Fields(keys)
+	if len(fields) == 0 {
+		return keybinding.Keybinding{}, errors.New("cannot parse empty key sequence")
+	}
+
+	chords := make([]keychord.KeyChord, 0, len(fields))
+	for _, field := range fields {
+		chord, err := parseKeyChord(field)
+		if err != nil {
+			return keybinding.Keybinding{}, err
+		}
+		chords = append(chords, chord)
+	}
+	return keybinding.Keybinding{KeyChords: chords}, nil
+}
+
+// parseKeyChord parses a single Emacs key like "C-M-x", "C--", "s-<f5>" or "A".
+func parseKeyChord(s string) (keychord.KeyChord, error) {
+	rest := s
+	chord := keychord.KeyChord{}
+	// Consume "X-" modifier prefixes; a lone trailing "-" is the minus key itself
+	for len(rest) > 2 && rest[1] == '-' {
+		mod, ok := emacsModifierMapping.Get(rest[:1])
+		if !ok {
+			return keychord.KeyChord{}, fmt.Errorf("unknown emacs modifier %q in %q", rest[:1], s)
+		}
+		if !slices.Contains(chord.Modifiers, mod) {
+			chord.Modifiers = append(chord.Modifiers, mod)
+		}
+		rest = rest[2:]
+	}
+
+	kc, shifted, err := fromEmacsKey(rest)
+	if err != nil {
+		return keychord.KeyChord{}, fmt.Errorf("%w: %q", err, s)
+	}
+	if shifted && !slices.Contains(chord.Modifiers, keycode.KeyModifierShift) {
+		chord.Modifiers = append(chord.Modifiers, keycode.KeyModifierShift)
+	}
+	chord.KeyCode = kc
+	return chord, nil
+}
+
+// fromEmacsKey converts an Emacs key name to a keycode. Uppercase letters are reported as shifted.
+func fromEmacsKey(key string) (keycode.KeyCode, bool, error) {
+	if kc, ok := emacsKeyMapping.Get(key); ok {
+		return kc, false, nil
+	}
+	if kc, ok := emacsKeyAliases[key]; ok {
+		return kc, false, nil
+	}
+	if len(key) == 1 {
+		r := rune(key[0])
+		if unicode.IsUpper(r) {
+			return keycode.KeyCode(strings.ToLower(key)), true, nil
+		}
+		if kc := keycode.KeyCode(key); kc.IsValid() {
+			return kc, false, nil
+		}
+	}
+	return "", false, errors.New("unsupported emacs key")
+}
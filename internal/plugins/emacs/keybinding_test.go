@@ -0,0 +1,78 @@
+package emacs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+)
+
+func TestFormatKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "Simple", in: "ctrl+k", want: "C-k"},
+		{name: "AltIsMeta", in: "alt+x", want: "M-x"},
+		{name: "MetaIsSuper", in: "meta+c", want: "s-c"},
+		{name: "ModifierOrder", in: "meta+shift+alt+ctrl+f5", want: "C-M-S-s-<f5>"},
+		{name: "PrefixChord", in: "ctrl+k ctrl+s", want: "C-k C-s"},
+		{name: "ShiftedLetter", in: "shift+a", want: "A"},
+		{name: "ShiftedLetterWithCtrl", in: "ctrl+shift+a", want: "C-S-a"},
+		{name: "Minus", in: "ctrl+-", want: "C--"},
+		{name: "Special", in: "ctrl+enter", want: "C-RET"},
+		{name: "PageDown", in: "pagedown", want: "<next>"},
+		{name: "Numpad", in: "numpad_add", want: "<kp-add>"},
+		{name: "Unsupported", in: "ctrl+capslock", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := keybinding.NewKeybinding(tc.in, keybinding.ParseOption{Separator: "+"})
+			require.NoError(t, err)
+			out, err := formatKeybinding(kb)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestParseKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "Simple", in: "C-k", want: "ctrl+k"},
+		{name: "ManyModifiersFunction", in: "C-M-S-s-<f5>", want: "meta+ctrl+shift+alt+f5"},
+		{name: "PrefixChord", in: "C-c C-k", want: "ctrl+c ctrl+k"},
+		{name: "Minus", in: "C--", want: "ctrl+-"},
+		{name: "Uppercase", in: "M-A", want: "shift+alt+a"},
+		{name: "Special", in: "SPC", want: "space"},
+		{name: "Alias", in: "<return>", want: "enter"},
+		{name: "UnknownModifier", in: "X-k", wantErr: true},
+		{name: "UnknownKey", in: "<mouse-1>", wantErr: true},
+		{name: "RawEventString", in: `\C-ca`, wantErr: true},
+		{name: "Empty", in: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := parseKeybinding(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, kb.String(keybinding.FormatOption{Separator: "+"}))
+		})
+	}
+}
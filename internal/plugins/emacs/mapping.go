@@ -0,0 +1,28 @@
+package emacs
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+// actionIDFromEmacs converts an Emacs command bound in the given keymap to a universal action ID.
+// Ties are broken by action ID to keep the result deterministic.
+func actionIDFromEmacs(mappingConfig *mappings.MappingConfig, command, keymapName string) (string, error) {
+	var ids []string
+	for id, mapping := range mappingConfig.Mappings {
+		econf := mapping.Emacs
+		if econf.DisableImport || econf.Command == "" {
+			continue
+		}
+		if econf.Command == command && econf.EffectiveKeymap() == keymapName {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return "", fmt.Errorf("no mapping found for emacs command: %s (keymap %s)", command, keymapName)
+	}
+	sort.Strings(ids)
+	return ids[0], nil
+}
@@ -31,6 +31,9 @@ type helixConfig struct {
 // Unknown/custom modes are still representable as Mode values.
 type Mode string
 
+// noOpCommand does nothing; binding a key to it removes Helix's default binding of that key.
+const noOpCommand = "no_op"
+
 const (
 	HelixModeNormal Mode = "normal"
 	HelixModeInsert Mode = "insert"
@@ -47,3 +50,26 @@ type helixKeys struct {
 	Insert map[string]string `toml:"insert,omitempty"`
 	Select map[string]string `toml:"select,omitempty"`
 }
+
+// unbind binds key to no_op in mode unless a command is already bound to it there.
+// It reports false for modes other than normal, insert and select.
+func (k *helixKeys) unbind(mode Mode, key string) bool {
+	var dest *map[string]string
+	switch mode {
+	case HelixModeNormal:
+		dest = &k.Normal
+	case HelixModeInsert:
+		dest = &k.Insert
+	case HelixModeSelect:
+		dest = &k.Select
+	default:
+		return false
+	}
+	if *dest == nil {
+		*dest = make(map[string]string)
+	}
+	if _, exists := (*dest)[key]; !exists {
+		(*dest)[key] = noOpCommand
+	}
+	return true
+}
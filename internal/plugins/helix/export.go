@@ -262,11 +262,60 @@ func (e *helixExporter) identifyManagedKeybindings(
 				(*dest)[keyStr] = hconf.Command
 			}
 		}
+
+		e.exportUnbind(ctx, km, mapping, &keysByMode, marker)
+	}
+
+	// Keymap-level removals apply to normal mode, where Helix binds most of its defaults.
+	for _, b := range setting.Unbind {
+		keyStr, err := formatKeybinding(b)
+		if err != nil {
+			e.logger.WarnContext(ctx, "Skipping unbind with un-formattable key", "error", err)
+			continue
+		}
+		keysByMode.unbind(HelixModeNormal, keyStr)
 	}
 
 	return keysByMode
 }
 
+// exportUnbind binds the action's unbind keys to no_op in each mode of the mapping. Helix removes
+// bindings per key, so removing every binding of an action cannot be expressed.
+func (e *helixExporter) exportUnbind(
+	ctx context.Context,
+	km keymap.Action,
+	mapping *mappings.ActionMappingConfig,
+	keysByMode *helixKeys,
+	marker *export.Marker,
+) {
+	if km.UnbindAll {
+		e.logger.DebugContext(ctx, "Helix cannot unbind all bindings of an action; ignoring", "action", km.Name)
+		marker.MarkSkippedForReason(km.Name, nil, &pluginapi.UnsupportedExportActionError{
+			Note: "Helix can only remove bindings per key",
+		})
+	}
+	for _, b := range km.Unbind {
+		keyStr, err := formatKeybinding(b)
+		if err != nil {
+			e.logger.WarnContext(ctx, "Skipping unbind with un-formattable key", "action", km.Name, "error", err)
+			marker.MarkSkippedForReason(km.Name, &b, &pluginapi.UnsupportedExportActionError{Note: err.Error()})
+			continue
+		}
+		for _, hconf := range mapping.Helix {
+			if hconf.Command == "" {
+				continue
+			}
+			mode := HelixModeNormal
+			if hconf.Mode != "" {
+				mode = Mode(hconf.Mode)
+			}
+			if !keysByMode.unbind(mode, keyStr) {
+				e.logger.WarnContext(ctx, "Unsupported Helix mode; skipping", "mode", string(mode), "action", km.Name)
+			}
+		}
+	}
+}
+
 // nonDestructiveMerge merges managed and unmanaged keybindings, with managed taking priority.
 func (e *helixExporter) nonDestructiveMerge(ctx context.Context, managed, unmanaged helixKeys) helixKeys {
 	result := helixKeys{}
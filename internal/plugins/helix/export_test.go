@@ -15,6 +15,7 @@ import (
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
 )
 
 // nolint:unparam // newAction creates a test Action with given keybindings
@@ -60,6 +61,27 @@ func TestExportHelixKeymap(t *testing.T) {
 			wantTOML: `
 [keys.insert]
 "M-c" = "yank"
+`,
+		},
+		{
+			name: "unbind entries become no_op",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{
+					func() keymap.Action {
+						a := newAction("actions.edit.copy", "meta+c")
+						a.Unbind = newAction("", "meta+c", "ctrl+c").Bindings
+						return a
+					}(),
+				},
+				Unbind: newAction("", "ctrl+k").Bindings,
+			},
+			wantTOML: `
+[keys.insert]
+"M-c" = "yank"
+"C-c" = "no_op"
+
+[keys.normal]
+"C-k" = "no_op"
 `,
 		},
 		// Non-destructive export tests
@@ -180,7 +202,7 @@ func TestExportHelixKeymap(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
 			exporter, err := p.Exporter()
 			require.NoError(t, err)
 
@@ -226,7 +248,7 @@ normal = "block"
 		},
 	}
 
-	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
 	exporter, err := p.Exporter()
 	require.NoError(t, err)
 
@@ -280,3 +302,24 @@ normal = "block"
 	assert.Equal(t, "bar", cursorShape["insert"])
 	assert.Equal(t, "block", cursorShape["normal"])
 }
+
+func TestExportHelixKeymap_ReportsUnbindAll(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	copyAction := newAction("actions.edit.copy", "meta+c")
+	copyAction.UnbindAll = true
+
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	report, err := exporter.Export(context.Background(), &buf,
+		keymap.Keymap{Actions: []keymap.Action{copyAction}}, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	require.Len(t, report.SkipReport.SkipActions, 1)
+	skip := report.SkipReport.SkipActions[0]
+	assert.Equal(t, "actions.edit.copy", skip.Action)
+	var unsupported *pluginapi.UnsupportedExportActionError
+	require.ErrorAs(t, skip.Error, &unsupported)
+}
@@ -6,20 +6,23 @@ import (
 	"github.com/xinnjie/onekeymap-cli/internal/diff"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
 )
 
 var _ pluginapi.Plugin = (*helixPlugin)(nil)
 
 type helixPlugin struct {
 	mappingConfig *mappings.MappingConfig
+	importer      pluginapi.PluginImporter
 	exporter      pluginapi.PluginExporter
 	logger        *slog.Logger
 }
 
 // New creates a new Helix plugin instance.
-func New(mappingConfig *mappings.MappingConfig, logger *slog.Logger) pluginapi.Plugin {
+func New(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) pluginapi.Plugin {
 	return &helixPlugin{
 		mappingConfig: mappingConfig,
+		importer:      newImporter(mappingConfig, logger, recorder),
 		exporter:      newExporter(mappingConfig, logger, diff.NewJSONASCIIDiffer()),
 		logger:        logger,
 	}
@@ -29,9 +32,7 @@ func New(mappingConfig *mappings.MappingConfig, logger *slog.Logger) pluginapi.P
 func (p *helixPlugin) EditorType() pluginapi.EditorType { return pluginapi.EditorTypeHelix }
 
 // Importer returns the importer for this plugin.
-func (p *helixPlugin) Importer() (pluginapi.PluginImporter, error) {
-	return nil, pluginapi.ErrNotSupported
-}
+func (p *helixPlugin) Importer() (pluginapi.PluginImporter, error) { return p.importer, nil }
 
 // Exporter returns the exporter for this plugin.
 func (p *helixPlugin) Exporter() (pluginapi.PluginExporter, error) { return p.exporter, nil }
@@ -0,0 +1,131 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/internal/dedup"
+	"github.com/xinnjie/onekeymap-cli/internal/imports"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+var errCommandSequence = errors.New("helix command sequences are not supported")
+
+type helixImporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	reporter      *metrics.UnknownActionReporter
+}
+
+func newImporter(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) *helixImporter {
+	return &helixImporter{
+		mappingConfig: mappingConfig,
+		logger:        logger,
+		reporter:      metrics.NewUnknownActionReporter(recorder),
+	}
+}
+
+// helixBinding is a flattened entry of a [keys.<mode>] table. Nested minor-mode
+// tables such as [keys.normal.space] are flattened into multi-chord key sequences.
+type helixBinding struct {
+	mode  Mode
+	keys  string
+	value interface{}
+}
+
+// Import reads Helix's config.toml and converts its [keys.*] tables into the
+// universal onekeymap KeymapSetting format.
+func (i *helixImporter) Import(
+	ctx context.Context,
+	source io.Reader,
+	_ pluginapi.PluginImportOption,
+) (pluginapi.PluginImportResult, error) {
+	_, fullConfig, err := parseConfig(source)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, fmt.Errorf("failed to parse helix toml: %w", err)
+	}
+
+	var bindings []helixBinding
+	if keysSection, ok := fullConfig["keys"].(map[string]interface{}); ok {
+		for _, mode := range []Mode{HelixModeNormal, HelixModeInsert, HelixModeSelect} {
+			if modeTable, ok := keysSection[string(mode)].(map[string]interface{}); ok {
+				bindings = flattenKeyTable(mode, "", modeTable, bindings)
+			}
+		}
+	}
+
+	setting := keymap.Keymap{}
+	marker := imports.NewMarker()
+	for _, b := range bindings {
+		kb, err := parseKeybinding(b.keys)
+		command, isCommand := b.value.(string)
+		if !isCommand {
+			// e.g. ["select_mode", "goto_line_end"]
+			command = fmt.Sprint(b.value)
+		}
+		if err != nil {
+			i.logger.WarnContext(ctx, "failed to parse helix key", "key", b.keys, "error", err)
+			marker.MarkSkipped(command, nil, fmt.Errorf("failed to parse key '%s': %w", b.keys, err))
+			continue
+		}
+		if !isCommand {
+			marker.MarkSkipped(command, &kb, errCommandSequence)
+			continue
+		}
+		if command == noOpCommand {
+			// A removal names no action, so it becomes a keymap-level unbind entry.
+			setting.Unbind = append(setting.Unbind, kb)
+			continue
+		}
+
+		actionID, err := i.actionIDFromHelix(command, b.mode)
+		if err != nil {
+			i.logger.DebugContext(ctx, "failed to find action", "command", command, "mode", b.mode, "error", err)
+			i.reporter.ReportUnknownCommand(ctx, pluginapi.EditorTypeHelix, command)
+			marker.MarkSkipped(command, &kb, err)
+			continue
+		}
+
+		setting.Actions = append(setting.Actions, keymap.Action{
+			Name:     actionID,
+			Bindings: []keybinding.Keybinding{kb},
+		})
+		marker.MarkImported(actionID, command, kb, kb)
+	}
+
+	setting.Actions = dedup.Actions(setting.Actions)
+	setting.Unbind = keymap.UnionKeybindings(nil, setting.Unbind)
+	result := pluginapi.PluginImportResult{Keymap: setting}
+	result.Report.SkipReport = marker.Report()
+	result.Report.ImportedReport = marker.ImportedReport()
+	return result, nil
+}
+
+// flattenKeyTable walks a key table in sorted key order, descending into minor-mode tables.
+func flattenKeyTable(mode Mode, prefix string, table map[string]interface{}, out []helixBinding) []helixBinding {
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		seq := strings.TrimSpace(prefix + " " + k)
+		switch v := table[k].(type) {
+		case map[string]interface{}:
+			out = flattenKeyTable(mode, seq, v, out)
+		default:
+			out = append(out, helixBinding{mode: mode, keys: seq, value: v})
+		}
+	}
+	return out
+}
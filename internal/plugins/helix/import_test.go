@@ -0,0 +1,149 @@
+package helix
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func TestImportHelixKeymap(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    []keymap.Action
+		wantSkipped int
+		wantErr     bool
+	}{
+		{
+			name: "insert mode binding",
+			input: `
+[keys.insert]
+"M-c" = "yank"
+`,
+			expected: []keymap.Action{newAction("actions.edit.copy", "meta+c")},
+		},
+		{
+			name: "mode must match mapping",
+			input: `
+[keys.normal]
+"M-c" = "yank"
+`,
+			wantSkipped: 1,
+		},
+		{
+			name: "uppercase key and minus",
+			input: `
+[keys.normal]
+"K" = "child_supported_command"
+"C--" = "child_supported_command"
+`,
+			expected: []keymap.Action{newAction("actions.test.childSupported", "ctrl+-", "shift+k")},
+		},
+		{
+			name: "nested minor mode tables become key sequences",
+			input: `
+theme = "onedark"
+
+[keys.normal.space]
+c = "child_supported_command"
+
+[keys.normal.space.w]
+x = "unknown_command"
+
+[keys.select]
+"C-c" = "yank"
+`,
+			expected:    []keymap.Action{newAction("actions.test.childSupported", "space c")},
+			wantSkipped: 2,
+		},
+		{
+			name: "command sequences and unparsable keys are skipped",
+			input: `
+[keys.insert]
+"C-y" = ["yank", "normal_mode"]
+"C-%" = "yank"
+"C-c" = "yank"
+`,
+			expected:    []keymap.Action{newAction("actions.edit.copy", "ctrl+c")},
+			wantSkipped: 1,
+		},
+		{
+			name:    "invalid toml",
+			input:   `[keys.normal`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			importer, err := p.Importer()
+			require.NoError(t, err)
+
+			result, err := importer.Import(context.Background(), strings.NewReader(tt.input), pluginapi.PluginImportOption{})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, result.Keymap.Actions)
+			assert.Len(t, result.Report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestImportHelixKeymap_NoOp(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	input := `
+[keys.normal]
+"C-k" = "no_op"
+
+[keys.insert]
+"C-k" = "no_op"
+"M-c" = "yank"
+`
+	result, err := importer.Import(context.Background(), strings.NewReader(input), pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.Equal(t, []keymap.Action{newAction("actions.edit.copy", "meta+c")}, result.Keymap.Actions)
+	assert.Equal(t, newAction("", "ctrl+k").Bindings, result.Keymap.Unbind)
+	assert.Empty(t, result.Report.SkipReport.SkipActions)
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "meta+c"),
+		newAction("actions.test.childSupported", "ctrl+shift+alt+f5"),
+	}}
+	var buf strings.Builder
+	_, err = exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	result, err := importer.Import(context.Background(), strings.NewReader(buf.String()), pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, setting.Actions, result.Keymap.Actions)
+}
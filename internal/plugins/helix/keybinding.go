@@ -1,12 +1,15 @@
 package helix
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/xinnjie/onekeymap-cli/internal/bimap"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keychord"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keycode"
 )
 
@@ -129,3 +132,77 @@ func toHelixKey(kc keycode.KeyCode) (string, error) {
 
 	return "", fmt.Errorf("cannot format key for helix: %s", keyStr)
 }
+
+// parseKeybinding parses a Helix key sequence like "C-k C-s" or "space f" into a keybinding.
+func parseKeybinding(keys string) (keybinding.Keybinding, error) {
+	fields := strings.Fields(keys)
+	if len(fields) == 0 {
+		return keybinding.Keybinding{}, errors.New("cannot parse empty key sequence")
+	}
+
+	chords := make([]keychord.KeyChord, 0, len(fields))
+	for _, field := range fields {
+		chord, err := parseKeyChord(field)
+		if err != nil {
+			return keybinding.Keybinding{}, err
+		}
+		chords = append(chords, chord)
+	}
+	return keybinding.Keybinding{KeyChords: chords}, nil
+}
+
+// parseKeyChord parses a single Helix key like "C-S-F5", "C--" or "K".
+func parseKeyChord(s string) (keychord.KeyChord, error) {
+	var modifierParts []string
+	var key string
+	switch {
+	case s == "-":
+		key = "-"
+	case strings.HasSuffix(s, "--"):
+		// e.g. "C--": the key itself is '-'
+		modifierParts = strings.Split(strings.TrimSuffix(s, "--"), "-")
+		key = "-"
+	default:
+		parts := strings.Split(s, "-")
+		modifierParts = parts[:len(parts)-1]
+		key = parts[len(parts)-1]
+	}
+
+	chord := keychord.KeyChord{}
+	for _, part := range modifierParts {
+		mod, ok := helixModifierMapping.Get(part)
+		if !ok {
+			return keychord.KeyChord{}, fmt.Errorf("unknown helix modifier %q in %q", part, s)
+		}
+		if !slices.Contains(chord.Modifiers, mod) {
+			chord.Modifiers = append(chord.Modifiers, mod)
+		}
+	}
+
+	kc, shifted, err := fromHelixKey(key)
+	if err != nil {
+		return keychord.KeyChord{}, fmt.Errorf("%w: %q", err, s)
+	}
+	if shifted && !slices.Contains(chord.Modifiers, keycode.KeyModifierShift) {
+		chord.Modifiers = append(chord.Modifiers, keycode.KeyModifierShift)
+	}
+	chord.KeyCode = kc
+	return chord, nil
+}
+
+// fromHelixKey converts a Helix key name to a keycode. Uppercase letters are reported as shifted.
+func fromHelixKey(key string) (keycode.KeyCode, bool, error) {
+	if kc, ok := helixKeyMapping.Get(key); ok {
+		return kc, false, nil
+	}
+	if len(key) == 1 {
+		r := rune(key[0])
+		if unicode.IsUpper(r) {
+			return keycode.KeyCode(strings.ToLower(key)), true, nil
+		}
+		if kc := keycode.KeyCode(key); kc.IsValid() {
+			return kc, false, nil
+		}
+	}
+	return "", false, errors.New("unsupported helix key")
+}
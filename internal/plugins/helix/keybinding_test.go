@@ -36,3 +36,35 @@ func TestFormatKeybinding(t *testing.T) {
 		})
 	}
 }
+
+func TestParseKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "Simple", in: "C-k", want: "ctrl+k"},
+		{name: "Special Enter", in: "C-ret", want: "ctrl+enter"},
+		{name: "ManyModifiersFunction", in: "M-C-S-A-F5", want: "meta+ctrl+shift+alt+f5"},
+		{name: "MultiChord", in: "C-k C-s", want: "ctrl+k ctrl+s"},
+		{name: "Minus", in: "C--", want: "ctrl+-"},
+		{name: "Uppercase", in: "G", want: "shift+g"},
+		{name: "Space", in: "space", want: "space"},
+		{name: "UnknownModifier", in: "X-k", wantErr: true},
+		{name: "UnknownKey", in: "C-%", wantErr: true},
+		{name: "Empty", in: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := parseKeybinding(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, kb.String(keybinding.FormatOption{Separator: "+"}))
+		})
+	}
+}
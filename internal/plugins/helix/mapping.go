@@ -0,0 +1,24 @@
+package helix
+
+import (
+	"fmt"
+)
+
+// actionIDFromHelix converts a Helix command and mode to a universal action ID.
+func (i *helixImporter) actionIDFromHelix(command string, mode Mode) (string, error) {
+	for _, mapping := range i.mappingConfig.Mappings {
+		for _, hconf := range mapping.Helix {
+			if hconf.DisableImport || hconf.Command != command {
+				continue
+			}
+			mappingMode := HelixModeNormal
+			if hconf.Mode != "" {
+				mappingMode = Mode(hconf.Mode)
+			}
+			if mappingMode == mode {
+				return mapping.ID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no mapping found for helix command: %s (mode %s)", command, mode)
+}
@@ -17,10 +17,25 @@ func (p *intellijPlugin) ConfigDetect(opts pluginapi.ConfigDetectOptions) (paths
 	return detectConfigForIDE("IntelliJ IDEA", "IntelliJIdea*", "idea", opts)
 }
 
+const (
+	jetbrainsVendorDir = "JetBrains"
+	// googleVendorDir holds Android Studio settings, which are not stored under JetBrains.
+	googleVendorDir = "Google"
+)
+
 // detectConfigForIDE is a helper function to detect config paths for a specific JetBrains IDE.
 func detectConfigForIDE(
 	appNamePrefix, dirPattern, commandName string,
 	opts pluginapi.ConfigDetectOptions,
+) (paths []string, installed bool, err error) {
+	return detectConfigForVendorIDE(jetbrainsVendorDir, appNamePrefix, dirPattern, commandName, opts)
+}
+
+// detectConfigForVendorIDE detects config paths for an IntelliJ-based IDE whose settings live under
+// vendorDir, e.g. "JetBrains" or "Google".
+func detectConfigForVendorIDE(
+	vendorDir, appNamePrefix, dirPattern, commandName string,
+	opts pluginapi.ConfigDetectOptions,
 ) (paths []string, installed bool, err error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -32,16 +47,16 @@ func detectConfigForIDE(
 	case "darwin":
 		candidates = append(
 			candidates,
-			filepath.Join(home, "Library", "Application Support", "JetBrains", dirPattern, "keymaps"),
+			filepath.Join(home, "Library", "Application Support", vendorDir, dirPattern, "keymaps"),
 		)
 	case "linux":
-		candidates = append(candidates, filepath.Join(home, ".config", "JetBrains", dirPattern, "keymaps"))
+		candidates = append(candidates, filepath.Join(home, ".config", vendorDir, dirPattern, "keymaps"))
 	case "windows":
 		appData := os.Getenv("APPDATA")
 		if appData == "" {
 			return nil, false, fmt.Errorf("APPDATA environment variable not set, %w", pluginapi.ErrNotSupported)
 		}
-		candidates = append(candidates, filepath.Join(appData, "JetBrains", dirPattern, "keymaps"))
+		candidates = append(candidates, filepath.Join(appData, vendorDir, dirPattern, "keymaps"))
 	default:
 		return nil, false, fmt.Errorf(
 			"automatic path discovery is only supported on macOS, Linux, and Windows for IntelliJ, %w",
@@ -0,0 +1,66 @@
+//go:build linux
+
+package intellij
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func TestConfigDetect_Linux_Variants(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name      string
+		plugin    pluginapi.Plugin
+		configDir []string
+	}{
+		{
+			name:      "android studio uses google vendor dir",
+			plugin:    NewAndroidStudio(mappingConfig, logger, metrics.NewNoop()),
+			configDir: []string{".config", "Google", "AndroidStudio2024.1"},
+		},
+		{
+			name:      "rider uses jetbrains vendor dir",
+			plugin:    NewRider(mappingConfig, logger, metrics.NewNoop()),
+			configDir: []string{".config", "JetBrains", "Rider2024.2"},
+		},
+		{
+			name:      "datagrip uses jetbrains vendor dir",
+			plugin:    NewDataGrip(mappingConfig, logger, metrics.NewNoop()),
+			configDir: []string{".config", "JetBrains", "DataGrip2024.2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+
+			_, _, err := tt.plugin.ConfigDetect(pluginapi.ConfigDetectOptions{Sandbox: true})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "could not locate "+tt.plugin.EditorType().AppName())
+
+			keymapDir := filepath.Join(append([]string{home}, append(tt.configDir, "keymaps")...)...)
+			require.NoError(t, os.MkdirAll(keymapDir, 0o755))
+
+			paths, installed, err := tt.plugin.ConfigDetect(pluginapi.ConfigDetectOptions{Sandbox: true})
+			require.NoError(t, err)
+			assert.False(t, installed)
+			assert.Equal(t, []string{filepath.Join(keymapDir, "Onekeymap.xml")}, paths)
+		})
+	}
+}
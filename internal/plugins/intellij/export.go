@@ -119,7 +119,7 @@ func (e *intellijExporter) identifyManagedActions(setting *keymap.Keymap, marker
 	var actionOrder []string
 
 	for _, km := range setting.Actions {
-		if len(km.Bindings) == 0 {
+		if len(km.Bindings) == 0 && !km.HasUnbind() {
 			continue
 		}
 		// Use GetExportAction to support fallback
@@ -141,6 +141,38 @@ func (e *intellijExporter) identifyManagedActions(setting *keymap.Keymap, marker
 			)
 		}
 		actionID := mapping.IntelliJ.Action
+		if !km.Scope.IsZero() {
+			// Keymap shortcuts are global to an action; the action itself decides where it is enabled.
+			// Exporting a scoped binding would make it apply everywhere, so it is skipped.
+			e.logger.Debug("IntelliJ shortcuts cannot be scoped, skipping", "action", km.Name, "scope", km.Scope)
+			reason := &pluginapi.UnsupportedExportActionError{
+				Note: fmt.Sprintf("IntelliJ shortcuts cannot be scoped to %q", km.Scope),
+			}
+			for _, b := range km.Bindings {
+				if len(b.KeyChords) > 0 {
+					marker.MarkSkippedForReason(km.Name, &b, reason)
+				}
+			}
+			if km.HasUnbind() {
+				marker.MarkSkippedForReason(km.Name, nil, reason)
+			}
+			continue
+		}
+		if km.UnbindAll {
+			// An action element without shortcuts overrides, and so removes, the parent keymap's shortcuts.
+			if _, exists := actionsMap[actionID]; !exists {
+				actionsMap[actionID] = &ActionXML{ID: actionID}
+				actionOrder = append(actionOrder, actionID)
+			}
+		} else if len(km.Unbind) > 0 && len(km.Bindings) == 0 {
+			// Overriding with remaining shortcuts would need the parent keymap's defaults, which are unknown here.
+			e.logger.Debug("IntelliJ cannot remove a single shortcut, ignoring unbind", "action", km.Name)
+			for _, b := range km.Unbind {
+				marker.MarkSkippedForReason(km.Name, &b, &pluginapi.UnsupportedExportActionError{
+					Note: "IntelliJ can only remove all shortcuts of an action",
+				})
+			}
+		}
 
 		for _, b := range km.Bindings {
 			if len(b.KeyChords) == 0 {
@@ -119,6 +119,27 @@ func TestExportIntelliJKeymap(t *testing.T) {
 				assert.Empty(t, out.Actions)
 			},
 		},
+		{
+			name: "unbind all becomes an empty action override",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:      "actions.edit.copy",
+						UnbindAll: true,
+					},
+					{
+						// A single shortcut cannot be removed without knowing the parent keymap.
+						Name:   "actions.test.withArgs",
+						Unbind: []keybinding.Keybinding{parseKB("meta+x")},
+					},
+				},
+			},
+			validateFunc: func(t *testing.T, out KeymapXML) {
+				require.Len(t, out.Actions, 1)
+				assert.Equal(t, "$Copy", out.Actions[0].ID)
+				assert.Empty(t, out.Actions[0].KeyboardShortcuts)
+			},
+		},
 		{
 			name: "dedup identical shortcuts for an action",
 			setting: keymap.Keymap{
@@ -363,3 +384,48 @@ func TestExportIntelliJKeymap(t *testing.T) {
 		})
 	}
 }
+
+func TestExportIntelliJKeymap_ReportsSingleShortcutUnbind(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	kb, err := keybinding.NewKeybinding("meta+x", keybinding.ParseOption{Separator: "+"})
+	require.NoError(t, err)
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		{Name: "actions.edit.copy", Unbind: []keybinding.Keybinding{kb}},
+	}}
+
+	plugin := New(mappingConfig, slog.New(slog.NewTextHandler(os.Stdout, nil)), metrics.NewNoop())
+	exporter, err := plugin.Exporter()
+	require.NoError(t, err)
+	report, err := exporter.Export(context.TODO(), &bytes.Buffer{}, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	require.Len(t, report.SkipReport.SkipActions, 1)
+	skip := report.SkipReport.SkipActions[0]
+	assert.Equal(t, "actions.edit.copy", skip.Action)
+	var unsupported *pluginapi.UnsupportedExportActionError
+	require.ErrorAs(t, skip.Error, &unsupported)
+}
+
+func TestExportIntelliJKeymap_ReportsScopedBindings(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	kb, err := keybinding.NewKeybinding("meta+c", keybinding.ParseOption{Separator: "+"})
+	require.NoError(t, err)
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		{Name: "actions.edit.copy", Bindings: []keybinding.Keybinding{kb}, Scope: keymap.Scope("terminalFocus")},
+	}}
+
+	plugin := New(mappingConfig, slog.New(slog.NewTextHandler(os.Stdout, nil)), metrics.NewNoop())
+	exporter, err := plugin.Exporter()
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	report, err := exporter.Export(context.TODO(), buf, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	var out KeymapXML
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &out))
+	assert.Empty(t, out.Actions, "a scoped binding is not exported as a global shortcut")
+	require.Len(t, report.SkipReport.SkipActions, 1)
+	require.ErrorContains(t, report.SkipReport.SkipActions[0].Error, "terminalFocus")
+}
@@ -56,6 +56,13 @@ func (p *intellijImporter) Import(
 	setting := keymap.Keymap{}
 	marker := imports.NewMarker()
 	for _, act := range doc.Actions {
+		if len(act.KeyboardShortcuts) == 0 && len(act.MouseShortcuts) == 0 {
+			// An empty override removes every shortcut the parent keymap gives the action.
+			if actionID, err := p.ActionIDFromIntelliJ(act.ID); err == nil {
+				setting.Actions = append(setting.Actions, keymap.Action{Name: actionID, UnbindAll: true})
+			}
+			continue
+		}
 		for _, ks := range act.KeyboardShortcuts {
 			if ks.First == "" {
 				continue
@@ -162,6 +162,22 @@ func TestImportIntelliJKeymap(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "Empty action override becomes unbind all",
+			input: `<keymap name="$default" version="1" disable-mnemonics="false">
+  <action id="$Copy"/>
+  <action id="UnknownAction"/>
+</keymap>`,
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:      "actions.edit.copy",
+						UnbindAll: true,
+					},
+				},
+			},
+			expectErr: false,
+		},
 		{
 			name: "CTRL alias and mixed-case tokens are normalized",
 			input: `<keymap name="$default" version="1" disable-mnemonics="false">
@@ -63,6 +63,18 @@ func (p *intellijVariantPlugin) ConfigDetect(
 		return detectConfigForIDE("GoLand", "GoLand*", "goland", opts)
 	case pluginapi.EditorTypeRustRover:
 		return detectConfigForIDE("RustRover", "RustRover*", "rustrover", opts)
+	case pluginapi.EditorTypeAndroidStudio:
+		return detectConfigForVendorIDE(googleVendorDir, "Android Studio", "AndroidStudio*", "studio", opts)
+	case pluginapi.EditorTypeDataGrip:
+		return detectConfigForIDE("DataGrip", "DataGrip*", "datagrip", opts)
+	case pluginapi.EditorTypeRider:
+		return detectConfigForIDE("Rider", "Rider*", "rider", opts)
+	case pluginapi.EditorTypeDataSpell:
+		return detectConfigForIDE("DataSpell", "DataSpell*", "dataspell", opts)
+	case pluginapi.EditorTypeAqua:
+		return detectConfigForIDE("Aqua", "Aqua*", "aqua", opts)
+	case pluginapi.EditorTypeWriterside:
+		return detectConfigForIDE("Writerside", "Writerside*", "writerside", opts)
 	case pluginapi.EditorTypeIntelliJ:
 		return detectConfigForIDE("IntelliJ IDEA", "IntelliJIdea*", "idea", opts)
 	default:
@@ -137,3 +149,57 @@ func NewRustRover(
 ) pluginapi.Plugin {
 	return newIntellijVariantPlugin(pluginapi.EditorTypeRustRover, mappingConfig, logger, recorder)
 }
+
+// NewAndroidStudio creates an Android Studio plugin instance.
+func NewAndroidStudio(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newIntellijVariantPlugin(pluginapi.EditorTypeAndroidStudio, mappingConfig, logger, recorder)
+}
+
+// NewDataGrip creates a DataGrip plugin instance.
+func NewDataGrip(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newIntellijVariantPlugin(pluginapi.EditorTypeDataGrip, mappingConfig, logger, recorder)
+}
+
+// NewRider creates a Rider plugin instance.
+func NewRider(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newIntellijVariantPlugin(pluginapi.EditorTypeRider, mappingConfig, logger, recorder)
+}
+
+// NewDataSpell creates a DataSpell plugin instance.
+func NewDataSpell(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newIntellijVariantPlugin(pluginapi.EditorTypeDataSpell, mappingConfig, logger, recorder)
+}
+
+// NewAqua creates an Aqua plugin instance.
+func NewAqua(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newIntellijVariantPlugin(pluginapi.EditorTypeAqua, mappingConfig, logger, recorder)
+}
+
+// NewWriterside creates a Writerside plugin instance.
+func NewWriterside(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newIntellijVariantPlugin(pluginapi.EditorTypeWriterside, mappingConfig, logger, recorder)
+}
@@ -0,0 +1,43 @@
+package kakoune
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+)
+
+// ConfigDetect returns the path of the user's kakrc, $XDG_CONFIG_HOME/kak/kakrc or ~/.config/kak/kakrc.
+// Kakoune only runs on Unix-like systems.
+func (p *kakounePlugin) ConfigDetect(opts pluginapi.ConfigDetectOptions) (paths []string, installed bool, err error) {
+	var configDir string
+	switch runtime.GOOS {
+	case "darwin", "linux":
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			configDir = filepath.Join(xdg, "kak")
+		} else {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, false, err
+			}
+			configDir = filepath.Join(home, ".config", "kak")
+		}
+	default:
+		return nil, false, fmt.Errorf(
+			"automatic path discovery is only supported on macOS and Linux, %w",
+			pluginapi.ErrNotSupported,
+		)
+	}
+
+	if opts.Sandbox {
+		installed = false
+	} else {
+		_, err = exec.LookPath("kak")
+		installed = err == nil
+	}
+
+	return []string{filepath.Join(configDir, "kakrc")}, installed, nil
+}
@@ -0,0 +1,7 @@
+package kakoune
+
+import "errors"
+
+var (
+	ErrNotSupportKeyChords = errors.New("not support key chords")
+)
@@ -0,0 +1,144 @@
+package kakoune
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/internal/export"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+type kakouneExporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	differ        diff.Differ
+}
+
+func newExporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	differ diff.Differ,
+) pluginapi.PluginExporter {
+	return &kakouneExporter{mappingConfig: mappingConfig, logger: logger, differ: differ}
+}
+
+// Export writes the keymap as a managed block of `map global` commands into the kakrc.
+// Every line outside the managed block is preserved as-is.
+func (e *kakouneExporter) Export(
+	ctx context.Context,
+	destination io.Writer,
+	setting keymap.Keymap,
+	opts pluginapi.PluginExportOption,
+) (*pluginapi.PluginExportReport, error) {
+	var existing kakrc
+	if opts.ExistingConfig != nil {
+		var err error
+		existing, err = parseKakrc(opts.ExistingConfig)
+		if err != nil {
+			e.logger.WarnContext(
+				ctx,
+				"Failed to parse existing config, proceeding with destructive export",
+				"error",
+				err,
+			)
+			existing = kakrc{}
+		}
+	}
+
+	marker := export.NewMarker(&setting)
+	managed := e.identifyManagedKeybindings(ctx, &setting, marker)
+
+	final := kakrc{
+		Before:   existing.Before,
+		Managed:  managed,
+		After:    existing.After,
+		HasBlock: existing.HasBlock,
+	}
+	e.logConflicts(ctx, managed, existing.unmanagedMappings())
+
+	if err := final.write(destination); err != nil {
+		return nil, err
+	}
+
+	return &pluginapi.PluginExportReport{
+		BaseEditorConfig:   existing.allMappings(),
+		ExportEditorConfig: final.allMappings(),
+		SkipReport:         marker.Report(),
+		ExportedReport:     marker.ExportedReport(),
+	}, nil
+}
+
+// identifyManagedKeybindings generates Kakoune mappings from KeymapSetting.
+func (e *kakouneExporter) identifyManagedKeybindings(
+	ctx context.Context,
+	setting *keymap.Keymap,
+	marker *export.Marker,
+) []kakMapping {
+	var result []kakMapping
+	seen := make(map[kakMapping]struct{})
+
+	for _, km := range setting.Actions {
+		mapping, usedFallback := e.mappingConfig.GetExportAction(km.Name, pluginapi.EditorTypeKakoune)
+		if mapping == nil || len(mapping.Kakoune) == 0 {
+			for _, b := range km.Bindings {
+				if len(b.KeyChords) > 0 {
+					marker.MarkSkippedForReason(km.Name, &b, pluginapi.ErrActionNotSupported)
+				}
+			}
+			continue
+		}
+
+		if usedFallback {
+			e.logger.InfoContext(ctx, "Action not directly supported, using fallback action",
+				"originalAction", km.Name,
+				"fallbackAction", mapping.ID,
+			)
+		}
+
+		for _, b := range km.Bindings {
+			if len(b.KeyChords) == 0 {
+				continue
+			}
+			key, err := formatKeybinding(b)
+			if err != nil {
+				e.logger.DebugContext(ctx, "Skipping keybinding with un-formattable key", "action", km.Name, "error", err)
+				marker.MarkSkippedForReason(km.Name, &b, &pluginapi.UnsupportedExportActionError{Note: err.Error()})
+				continue
+			}
+			marker.MarkExported(km.Name, b)
+
+			for _, kc := range mapping.Kakoune {
+				if kc.Command == "" {
+					continue
+				}
+				m := kakMapping{Mode: Mode(kc.EffectiveMode()), Key: key, Command: kc.Command}
+				if _, dup := seen[m]; dup {
+					continue
+				}
+				seen[m] = struct{}{}
+				result = append(result, m)
+			}
+		}
+	}
+
+	return result
+}
+
+// logConflicts reports user mappings outside the managed block that bind the same keys.
+// They are kept untouched; whichever is sourced last wins in Kakoune.
+func (e *kakouneExporter) logConflicts(ctx context.Context, managed, unmanaged []kakMapping) {
+	managedKeys := make(map[kakMapping]string)
+	for _, m := range managed {
+		managedKeys[kakMapping{Mode: m.Mode, Key: m.Key}] = m.Command
+	}
+	for _, u := range unmanaged {
+		if command, ok := managedKeys[kakMapping{Mode: u.Mode, Key: u.Key}]; ok && command != u.Command {
+			e.logger.DebugContext(ctx, "User mapping outside managed block binds the same key",
+				"mode", string(u.Mode), "key", u.Key, "managed_command", command, "unmanaged_command", u.Command)
+		}
+	}
+}
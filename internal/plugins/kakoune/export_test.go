@@ -0,0 +1,131 @@
+package kakoune
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+// newAction creates a test Action with given keybindings.
+func newAction(actionName string, keybindings ...string) keymap.Action {
+	var bindings []keybinding.Keybinding
+	for _, kb := range keybindings {
+		b, err := keybinding.NewKeybinding(kb, keybinding.ParseOption{Separator: "+"})
+		if err != nil {
+			panic(err)
+		}
+		bindings = append(bindings, b)
+	}
+	return keymap.Action{
+		Name:     actionName,
+		Bindings: bindings,
+	}
+}
+
+func block(lines ...string) string {
+	all := append([]string{managedBlockBegin, managedBlockNote}, lines...)
+	all = append(all, managedBlockEnd)
+	return strings.Join(all, "\n") + "\n"
+}
+
+func TestExportKakrc(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		setting        keymap.Keymap
+		existingConfig string
+		want           string
+	}{
+		{
+			name: "export to empty config",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.edit.copy", "ctrl+c", "alt+shift+y")},
+			},
+			want: block(`map global normal <c-c> 'y'`, `map global normal <a-Y> 'y'`),
+		},
+		{
+			name: "append block after user config",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.edit.copy", "ctrl+c")},
+			},
+			existingConfig: "colorscheme gruvbox\nmap global user f ':find '\n",
+			want:           "colorscheme gruvbox\nmap global user f ':find '\n\n" + block(`map global normal <c-c> 'y'`),
+		},
+		{
+			name: "replace existing block and keep surrounding lines",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.edit.copy", "ctrl+c")},
+			},
+			existingConfig: "add-highlighter global/ number-lines\n" +
+				block(`map global normal <c-s> ':write<ret>'`) +
+				"# user tail\nset-option global tabstop 4\n",
+			want: "add-highlighter global/ number-lines\n" + block(`map global normal <c-c> 'y'`) +
+				"# user tail\nset-option global tabstop 4\n",
+		},
+		{
+			name: "falls back to fallback action when parent not supported",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.test.parentNotSupported", "ctrl+shift+h")},
+			},
+			want: block(`map global insert <c-H> ':child-supported<ret>'`),
+		},
+		{
+			name: "unsupported actions and keys leave an empty block",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{
+					newAction("actions.test.withArgs", "ctrl+e"),
+					newAction("actions.edit.copy", "meta+c", "ctrl+k ctrl+c"),
+				},
+			},
+			want: block(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			exporter, err := p.Exporter()
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			opts := pluginapi.PluginExportOption{}
+			if tt.existingConfig != "" {
+				opts.ExistingConfig = strings.NewReader(tt.existingConfig)
+			}
+
+			_, err = exporter.Export(context.Background(), &buf, tt.setting, opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestExportKakrc_ReportsSkipped(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "ctrl+c", "meta+c"),
+		newAction("actions.test.withArgs", "ctrl+e"),
+	}}
+	var buf bytes.Buffer
+	report, err := exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+	assert.Len(t, report.SkipReport.SkipActions, 2)
+}
@@ -0,0 +1,78 @@
+package kakoune
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/dedup"
+	"github.com/xinnjie/onekeymap-cli/internal/imports"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+type kakouneImporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	reporter      *metrics.UnknownActionReporter
+}
+
+func newImporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) *kakouneImporter {
+	return &kakouneImporter{
+		mappingConfig: mappingConfig,
+		logger:        logger,
+		reporter:      metrics.NewUnknownActionReporter(recorder),
+	}
+}
+
+// Import reads a kakrc and converts its global `map` commands, both inside and outside
+// the onekeymap managed block, into the universal KeymapSetting format.
+func (i *kakouneImporter) Import(
+	ctx context.Context,
+	source io.Reader,
+	_ pluginapi.PluginImportOption,
+) (pluginapi.PluginImportResult, error) {
+	rc, err := parseKakrc(source)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, fmt.Errorf("failed to parse kakrc: %w", err)
+	}
+
+	setting := keymap.Keymap{}
+	marker := imports.NewMarker()
+	for _, m := range rc.allMappings() {
+		kb, err := parseKeybinding(m.Key)
+		if err != nil {
+			i.logger.DebugContext(ctx, "failed to parse kakoune key", "key", m.Key, "error", err)
+			marker.MarkSkipped(m.Command, nil, fmt.Errorf("failed to parse key '%s': %w", m.Key, err))
+			continue
+		}
+
+		actionID, err := actionIDFromKakoune(i.mappingConfig, m.Command, m.Mode)
+		if err != nil {
+			i.logger.DebugContext(ctx, "failed to find action", "command", m.Command, "mode", m.Mode, "error", err)
+			i.reporter.ReportUnknownCommand(ctx, pluginapi.EditorTypeKakoune, m.Command)
+			marker.MarkSkipped(m.Command, &kb, err)
+			continue
+		}
+
+		setting.Actions = append(setting.Actions, keymap.Action{
+			Name:     actionID,
+			Bindings: []keybinding.Keybinding{kb},
+		})
+		marker.MarkImported(actionID, m.Command, kb, kb)
+	}
+
+	setting.Actions = dedup.Actions(setting.Actions)
+	result := pluginapi.PluginImportResult{Keymap: setting}
+	result.Report.SkipReport = marker.Report()
+	result.Report.ImportedReport = marker.ImportedReport()
+	return result, nil
+}
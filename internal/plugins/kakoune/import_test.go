@@ -0,0 +1,95 @@
+package kakoune
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func TestImportKakrc(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    []keymap.Action
+		wantSkipped int
+		wantErr     bool
+	}{
+		{
+			name:     "mappings outside the managed block",
+			input:    "map global normal <c-c> y\nmap -docstring 'copy' global normal <a-Y> %{y}\n",
+			expected: []keymap.Action{newAction("actions.edit.copy", "ctrl+c", "alt+shift+y")},
+		},
+		{
+			name:     "mappings inside the managed block",
+			input:    block(`map global insert <c-H> ':child-supported<ret>'`),
+			expected: []keymap.Action{newAction("actions.test.childSupported", "ctrl+shift+h")},
+		},
+		{
+			name:        "mode must match mapping",
+			input:       "map global insert <c-c> y\n",
+			wantSkipped: 1,
+		},
+		{
+			name:        "unknown commands are skipped",
+			input:       "map global normal <c-p> ':fzf-mode<ret>'\nmap window normal <c-c> y\n",
+			wantSkipped: 1,
+		},
+		{
+			name:    "unterminated managed block",
+			input:   managedBlockBegin + "\nmap global normal <c-c> y\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			importer, err := p.Importer()
+			require.NoError(t, err)
+
+			result, err := importer.Import(context.Background(), strings.NewReader(tt.input), pluginapi.PluginImportOption{})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, result.Keymap.Actions)
+			assert.Len(t, result.Report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "ctrl+c", "alt+shift+y"),
+		newAction("actions.test.childSupported", "ctrl+f5"),
+	}}
+	var buf bytes.Buffer
+	_, err = exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	result, err := importer.Import(context.Background(), &buf, pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, setting.Actions, result.Keymap.Actions)
+}
@@ -0,0 +1,38 @@
+package kakoune
+
+import (
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+var _ pluginapi.Plugin = (*kakounePlugin)(nil)
+
+type kakounePlugin struct {
+	mappingConfig *mappings.MappingConfig
+	importer      pluginapi.PluginImporter
+	exporter      pluginapi.PluginExporter
+	logger        *slog.Logger
+}
+
+// New creates a new Kakoune plugin instance.
+func New(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) pluginapi.Plugin {
+	return &kakounePlugin{
+		mappingConfig: mappingConfig,
+		importer:      newImporter(mappingConfig, logger, recorder),
+		exporter:      newExporter(mappingConfig, logger, diff.NewJSONASCIIDiffer()),
+		logger:        logger,
+	}
+}
+
+// EditorType returns the unique identifier for Kakoune.
+func (p *kakounePlugin) EditorType() pluginapi.EditorType { return pluginapi.EditorTypeKakoune }
+
+// Importer returns the importer for this plugin.
+func (p *kakounePlugin) Importer() (pluginapi.PluginImporter, error) { return p.importer, nil }
+
+// Exporter returns the exporter for this plugin.
+func (p *kakounePlugin) Exporter() (pluginapi.PluginExporter, error) { return p.exporter, nil }
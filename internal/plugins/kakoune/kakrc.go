@@ -0,0 +1,254 @@
+package kakoune
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Mode is a Kakoune mode a mapping applies to, e.g. "normal", "insert" or a user mode.
+type Mode string
+
+const KakModeNormal Mode = "normal"
+
+const (
+	managedBlockBegin = `# >>> onekeymap managed block >>>`
+	managedBlockEnd   = `# <<< onekeymap managed block <<<`
+	managedBlockNote  = `# Generated by onekeymap. Changes inside this block will be overwritten.`
+
+	// globalScope is the only scope managed by onekeymap; buffer and window mappings are
+	// usually declared inside hooks.
+	globalScope = "global"
+)
+
+// kakMapping is a single `map` command, e.g. `map global normal <c-s> ':write<ret>'`.
+type kakMapping struct {
+	Mode    Mode   `json:"mode"`
+	Key     string `json:"key"`
+	Command string `json:"command"`
+}
+
+// String renders the mapping as a kakscript command.
+func (m kakMapping) String() string {
+	return fmt.Sprintf("map %s %s %s %s", globalScope, m.Mode, m.Key, quoteArg(m.Command))
+}
+
+// kakrc is a kakrc split around the onekeymap managed block.
+// Lines outside the block belong to the user and are preserved verbatim.
+type kakrc struct {
+	Before   []string
+	Managed  []kakMapping
+	After    []string
+	HasBlock bool
+}
+
+// parseKakrc reads a kakrc and splits it around the managed block.
+func parseKakrc(reader io.Reader) (kakrc, error) {
+	var rc kakrc
+	inBlock := false
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == managedBlockBegin && !rc.HasBlock:
+			inBlock = true
+			rc.HasBlock = true
+		case trimmed == managedBlockEnd && inBlock:
+			inBlock = false
+		case inBlock:
+			if m, ok := parseMappingLine(line); ok {
+				rc.Managed = append(rc.Managed, m)
+			}
+		case rc.HasBlock:
+			rc.After = append(rc.After, line)
+		default:
+			rc.Before = append(rc.Before, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return kakrc{}, fmt.Errorf("failed to read kakrc: %w", err)
+	}
+	if inBlock {
+		return kakrc{}, fmt.Errorf("unterminated onekeymap managed block, missing %q", managedBlockEnd)
+	}
+	return rc, nil
+}
+
+// unmanagedMappings returns mappings declared outside the managed block.
+func (rc kakrc) unmanagedMappings() []kakMapping {
+	var out []kakMapping
+	for _, lines := range [][]string{rc.Before, rc.After} {
+		for _, line := range lines {
+			if m, ok := parseMappingLine(line); ok {
+				out = append(out, m)
+			}
+		}
+	}
+	return out
+}
+
+// allMappings returns every mapping in the file, in file order.
+func (rc kakrc) allMappings() []kakMapping {
+	var out []kakMapping
+	for _, line := range rc.Before {
+		if m, ok := parseMappingLine(line); ok {
+			out = append(out, m)
+		}
+	}
+	out = append(out, rc.Managed...)
+	for _, line := range rc.After {
+		if m, ok := parseMappingLine(line); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// write renders the kakrc. When the source had no managed block, the block is appended at the end.
+func (rc kakrc) write(w io.Writer) error {
+	var sb strings.Builder
+	for _, line := range rc.Before {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if !rc.HasBlock && len(rc.Before) > 0 && strings.TrimSpace(rc.Before[len(rc.Before)-1]) != "" {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(managedBlockBegin + "\n")
+	sb.WriteString(managedBlockNote + "\n")
+	for _, m := range rc.Managed {
+		sb.WriteString(m.String())
+		sb.WriteString("\n")
+	}
+	sb.WriteString(managedBlockEnd + "\n")
+	for _, line := range rc.After {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("failed to write kakrc: %w", err)
+	}
+	return nil
+}
+
+// parseMappingLine parses a global map command like `map -docstring 'save' global normal <c-s> ':w<ret>'`.
+// Returns false for any line that is not a global map command.
+func parseMappingLine(line string) (kakMapping, bool) {
+	args, ok := splitArgs(line)
+	if !ok || len(args) == 0 || args[0] != "map" {
+		return kakMapping{}, false
+	}
+
+	// Drop switches such as -docstring <text>
+	var positional []string
+	for i := 1; i < len(args); i++ {
+		switch {
+		case args[i] == "-docstring":
+			i++
+		case args[i] == "--":
+			positional = append(positional, args[i+1:]...)
+			i = len(args)
+		case strings.HasPrefix(args[i], "-") && len(positional) == 0:
+			// Other switches take no argument
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+	if len(positional) != 4 || positional[0] != globalScope || positional[3] == "" {
+		return kakMapping{}, false
+	}
+	return kakMapping{Mode: Mode(positional[1]), Key: positional[2], Command: positional[3]}, true
+}
+
+// splitArgs splits a kakscript command line into its arguments, handling 'single', "double" and
+// %{balanced} quoting. Returns false when the line is empty, a comment, or has unterminated quoting.
+func splitArgs(line string) ([]string, bool) {
+	var args []string
+	s := strings.TrimSpace(line)
+	for s != "" {
+		switch {
+		case s[0] == '#':
+			s = ""
+			continue
+		case s[0] == '\'' || s[0] == '"':
+			arg, rest, ok := cutQuoted(s)
+			if !ok {
+				return nil, false
+			}
+			args = append(args, arg)
+			s = rest
+		case s[0] == '%' && len(s) > 1:
+			arg, rest, ok := cutPercentString(s)
+			if !ok {
+				return nil, false
+			}
+			args = append(args, arg)
+			s = rest
+		default:
+			end := strings.IndexAny(s, " \t")
+			if end < 0 {
+				end = len(s)
+			}
+			args = append(args, s[:end])
+			s = s[end:]
+		}
+		s = strings.TrimLeft(s, " \t")
+	}
+	return args, len(args) > 0
+}
+
+// cutQuoted reads a 'single' or "double" quoted string; the quote character is escaped by doubling it.
+func cutQuoted(s string) (string, string, bool) {
+	quote := s[0]
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] != quote {
+			sb.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == quote {
+			sb.WriteByte(quote)
+			i++
+			continue
+		}
+		return sb.String(), s[i+1:], true
+	}
+	return "", "", false
+}
+
+// cutPercentString reads a %{...} string. Nestable delimiters ({}, [], (), <>) must be balanced;
+// any other punctuation character is its own closing delimiter, as in %|...|.
+func cutPercentString(s string) (string, string, bool) {
+	// Skip an optional expansion type, e.g. %sh{...}; only its raw text is kept
+	start := 1
+	for start < len(s) && (s[start] >= 'a' && s[start] <= 'z') {
+		start++
+	}
+	if start >= len(s) {
+		return "", "", false
+	}
+	open := s[start]
+	closing, nestable := map[byte]byte{'{': '}', '[': ']', '(': ')', '<': '>'}[open]
+	if !nestable {
+		closing = open
+	}
+	depth := 0
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case nestable && s[i] == open:
+			depth++
+		case s[i] == closing && depth > 0:
+			depth--
+		case s[i] == closing:
+			return s[start+1 : i], s[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// quoteArg single-quotes a command argument, doubling any single quote inside it.
+func quoteArg(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,65 @@
+package kakoune
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMappingLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		want   kakMapping
+		wantOK bool
+	}{
+		{
+			name:   "single quoted command",
+			line:   `map global normal <c-s> ':write<ret>'`,
+			want:   kakMapping{Mode: KakModeNormal, Key: "<c-s>", Command: ":write<ret>"},
+			wantOK: true,
+		},
+		{
+			name:   "docstring and percent string",
+			line:   `  map -docstring 'save all' global user w %{:write-all<ret>}`,
+			want:   kakMapping{Mode: "user", Key: "w", Command: ":write-all<ret>"},
+			wantOK: true,
+		},
+		{
+			name:   "doubled quote inside single quotes",
+			line:   `map global insert <a-q> ':echo ''hi''<ret>'`,
+			want:   kakMapping{Mode: "insert", Key: "<a-q>", Command: ":echo 'hi'<ret>"},
+			wantOK: true,
+		},
+		{
+			name:   "unquoted command and trailing comment",
+			line:   `map global normal <c-z> u # undo`,
+			want:   kakMapping{Mode: KakModeNormal, Key: "<c-z>", Command: "u"},
+			wantOK: true,
+		},
+		{name: "window scope is not managed", line: `map window normal x y`},
+		{name: "missing command", line: `map global normal x`},
+		{name: "other command", line: `set-option global tabstop 4`},
+		{name: "comment", line: `# map global normal x y`},
+		{name: "unterminated quote", line: `map global normal x 'y`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseMappingLine(tt.line)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestKakMappingString(t *testing.T) {
+	m := kakMapping{Mode: KakModeNormal, Key: "<c-e>", Command: ":echo 'hi'<ret>"}
+	assert.Equal(t, `map global normal <c-e> ':echo ''hi''<ret>'`, m.String())
+
+	parsed, ok := parseMappingLine(m.String())
+	assert.True(t, ok)
+	assert.Equal(t, m, parsed)
+}
@@ -0,0 +1,191 @@
+package kakoune
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/xinnjie/onekeymap-cli/internal/bimap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keychord"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keycode"
+)
+
+//nolint:gochecknoglobals // static lookup tables shared across parse/format functions; initialized once and read-only
+var (
+	// see `:doc mapping mappable-keys`
+	kakModifierMapping = bimap.NewBiMapFromMap(map[string]keycode.KeyModifier{
+		"c": keycode.KeyModifierCtrl,
+		"a": keycode.KeyModifierAlt,
+		"s": keycode.KeyModifierShift,
+	})
+
+	kakModifierOrder = []keycode.KeyModifier{
+		keycode.KeyModifierCtrl, keycode.KeyModifierAlt, keycode.KeyModifierShift,
+	}
+
+	kakKeyNames = map[string]keycode.KeyCode{
+		"ret":       keycode.KeyCodeEnter,
+		"esc":       keycode.KeyCodeEscape,
+		"tab":       keycode.KeyCodeTab,
+		"space":     keycode.KeyCodeSpace,
+		"backspace": keycode.KeyCodeBackspace,
+		"del":       keycode.KeyCodeDelete,
+		"ins":       keycode.KeyCodeInsert,
+		"up":        keycode.KeyCodeUp,
+		"down":      keycode.KeyCodeDown,
+		"left":      keycode.KeyCodeLeft,
+		"right":     keycode.KeyCodeRight,
+		"pageup":    keycode.KeyCodePageUp,
+		"pagedown":  keycode.KeyCodePageDown,
+		"home":      keycode.KeyCodeHome,
+		"end":       keycode.KeyCodeEnd,
+		"F1":        keycode.KeyCodeF1,
+		"F2":        keycode.KeyCodeF2,
+		"F3":        keycode.KeyCodeF3,
+		"F4":        keycode.KeyCodeF4,
+		"F5":        keycode.KeyCodeF5,
+		"F6":        keycode.KeyCodeF6,
+		"F7":        keycode.KeyCodeF7,
+		"F8":        keycode.KeyCodeF8,
+		"F9":        keycode.KeyCodeF9,
+		"F10":       keycode.KeyCodeF10,
+		"F11":       keycode.KeyCodeF11,
+		"F12":       keycode.KeyCodeF12,
+		// Punctuation that clashes with the modifier syntax, e.g. <a-minus>
+		"plus":  keycode.KeyCodePlus,
+		"minus": keycode.KeyCodeMinus,
+	}
+	kakKeyMapping = bimap.NewBiMapFromMap(kakKeyNames)
+
+	// kakKeyNameIndex is used for parsing: key names are case-insensitive, and a few aliases are accepted.
+	kakKeyNameIndex = buildKeyNameIndex(kakKeyNames, map[string]keycode.KeyCode{
+		"semicolon": keycode.KeyCodeSemicolon,
+	})
+)
+
+func buildKeyNameIndex(names ...map[string]keycode.KeyCode) map[string]keycode.KeyCode {
+	index := make(map[string]keycode.KeyCode)
+	for _, m := range names {
+		for name, kc := range m {
+			index[strings.ToLower(name)] = kc
+		}
+	}
+	return index
+}
+
+// formatKeybinding converts a keybinding into Kakoune key notation, e.g. "<c-s>", "<a-X>" or "<s-tab>".
+// Shifted letters are written in upper case. `map` binds a single key, so multi-key sequences are rejected.
+func formatKeybinding(kb keybinding.Keybinding) (string, error) {
+	if len(kb.KeyChords) != 1 {
+		return "", fmt.Errorf("%w: kakoune maps a single key, got %d", ErrNotSupportKeyChords, len(kb.KeyChords))
+	}
+	chord := kb.KeyChords[0]
+	if chord.KeyCode == "" || slices.Contains(chord.Modifiers, keycode.KeyModifierMeta) {
+		return "", fmt.Errorf("%w: %q", ErrNotSupportKeyChords, chord.String(keychord.FormatOption{Separator: "+"}))
+	}
+
+	name, named := kakKeyMapping.GetInverse(chord.KeyCode)
+	if !named {
+		keyStr := string(chord.KeyCode)
+		if len(keyStr) != 1 {
+			return "", fmt.Errorf("%w: cannot format key for kakoune: %s", ErrNotSupportKeyChords, keyStr)
+		}
+		name = keyStr
+	}
+
+	var mods []string
+	for _, mod := range kakModifierOrder {
+		if !slices.Contains(chord.Modifiers, mod) {
+			continue
+		}
+		if mod == keycode.KeyModifierShift && !named {
+			// Shift is part of the character: "X" rather than "<s-x>"
+			r := rune(name[0])
+			if !unicode.IsLetter(r) {
+				return "", fmt.Errorf("%w: cannot shift %q in kakoune", ErrNotSupportKeyChords, name)
+			}
+			name = strings.ToUpper(name)
+			continue
+		}
+		m, _ := kakModifierMapping.GetInverse(mod)
+		mods = append(mods, m)
+	}
+
+	if len(mods) == 0 && !named {
+		return name, nil
+	}
+	return "<" + strings.Join(append(mods, name), "-") + ">", nil
+}
+
+// parseKeybinding parses a single Kakoune key such as "x", "X", "<c-s>" or "<a-ret>".
+func parseKeybinding(key string) (keybinding.Keybinding, error) {
+	if key == "" {
+		return keybinding.Keybinding{}, fmt.Errorf("%w: empty key", ErrNotSupportKeyChords)
+	}
+
+	var chord keychord.KeyChord
+	var err error
+	if len(key) > 2 && key[0] == '<' && key[len(key)-1] == '>' {
+		chord, err = parseKeyNotation(key[1 : len(key)-1])
+	} else {
+		r := []rune(key)
+		if len(r) != 1 {
+			return keybinding.Keybinding{}, fmt.Errorf("%w: kakoune maps a single key, got %q", ErrNotSupportKeyChords, key)
+		}
+		chord, err = parseSingleKey(r[0])
+	}
+	if err != nil {
+		return keybinding.Keybinding{}, err
+	}
+	return keybinding.Keybinding{KeyChords: []keychord.KeyChord{chord}}, nil
+}
+
+// parseKeyNotation parses the inside of a "<...>" notation such as "c-a-x" or "s-tab".
+func parseKeyNotation(notation string) (keychord.KeyChord, error) {
+	chord := keychord.KeyChord{}
+	rest := notation
+	for len(rest) > 2 && rest[1] == '-' {
+		mod, ok := kakModifierMapping.Get(strings.ToLower(rest[:1]))
+		if !ok {
+			break
+		}
+		if !slices.Contains(chord.Modifiers, mod) {
+			chord.Modifiers = append(chord.Modifiers, mod)
+		}
+		rest = rest[2:]
+	}
+
+	if kc, ok := kakKeyNameIndex[strings.ToLower(rest)]; ok {
+		chord.KeyCode = kc
+		return chord, nil
+	}
+	if r := []rune(rest); len(r) == 1 {
+		single, err := parseSingleKey(r[0])
+		if err == nil {
+			for _, mod := range single.Modifiers {
+				if !slices.Contains(chord.Modifiers, mod) {
+					chord.Modifiers = append(chord.Modifiers, mod)
+				}
+			}
+			chord.KeyCode = single.KeyCode
+			return chord, nil
+		}
+	}
+	return keychord.KeyChord{}, fmt.Errorf("%w: unsupported key notation <%s>", ErrNotSupportKeyChords, notation)
+}
+
+func parseSingleKey(r rune) (keychord.KeyChord, error) {
+	if unicode.IsUpper(r) {
+		kc := keycode.KeyCode(string(unicode.ToLower(r)))
+		if kc.IsValid() {
+			return keychord.KeyChord{Modifiers: []keycode.KeyModifier{keycode.KeyModifierShift}, KeyCode: kc}, nil
+		}
+	}
+	kc := keycode.KeyCode(string(r))
+	if kc.IsValid() && len(string(r)) == 1 {
+		return keychord.KeyChord{KeyCode: kc}, nil
+	}
+	return keychord.KeyChord{}, fmt.Errorf("%w: unsupported key %q", ErrNotSupportKeyChords, string(r))
+}
@@ -0,0 +1,77 @@
+package kakoune
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+)
+
+func TestFormatKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "PlainKey", in: "x", want: "x"},
+		{name: "Ctrl", in: "ctrl+s", want: "<c-s>"},
+		{name: "ShiftedLetter", in: "shift+x", want: "X"},
+		{name: "AltShiftedLetter", in: "alt+shift+x", want: "<a-X>"},
+		{name: "ModifierOrder", in: "shift+alt+ctrl+enter", want: "<c-a-s-ret>"},
+		{name: "NamedKey", in: "enter", want: "<ret>"},
+		{name: "Function", in: "ctrl+f5", want: "<c-F5>"},
+		{name: "Minus", in: "alt+-", want: "<a-minus>"},
+		{name: "ShiftedPunctuation", in: "shift+/", wantErr: true},
+		{name: "Meta", in: "meta+s", wantErr: true},
+		{name: "MultiChord", in: "ctrl+k ctrl+s", wantErr: true},
+		{name: "Numpad", in: "numpad1", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := keybinding.NewKeybinding(tc.in, keybinding.ParseOption{Separator: "+"})
+			require.NoError(t, err)
+			out, err := formatKeybinding(kb)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestParseKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "PlainKey", in: "x", want: "x"},
+		{name: "Uppercase", in: "X", want: "shift+x"},
+		{name: "Ctrl", in: "<c-s>", want: "ctrl+s"},
+		{name: "AltUppercase", in: "<a-X>", want: "shift+alt+x"},
+		{name: "NamedKeyCaseInsensitive", in: "<S-Tab>", want: "shift+tab"},
+		{name: "Function", in: "<c-F5>", want: "ctrl+f5"},
+		{name: "Minus", in: "<a-minus>", want: "alt+-"},
+		{name: "Punctuation", in: "%", wantErr: true},
+		{name: "UnknownNotation", in: "<c-foo>", wantErr: true},
+		{name: "Sequence", in: "gg", wantErr: true},
+		{name: "Empty", in: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := parseKeybinding(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, kb.String(keybinding.FormatOption{Separator: "+"}))
+		})
+	}
+}
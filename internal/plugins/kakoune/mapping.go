@@ -0,0 +1,29 @@
+package kakoune
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+// actionIDFromKakoune converts a Kakoune mapping command and mode to a universal action ID.
+// Ties are broken by action ID to keep the result deterministic.
+func actionIDFromKakoune(mappingConfig *mappings.MappingConfig, command string, mode Mode) (string, error) {
+	var candidates []string
+	for id, mapping := range mappingConfig.Mappings {
+		for _, kc := range mapping.Kakoune {
+			if kc.DisableImport || kc.Command == "" {
+				continue
+			}
+			if kc.Command == command && Mode(kc.EffectiveMode()) == mode {
+				candidates = append(candidates, id)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no mapping found for kakoune command: %s (mode %s)", command, mode)
+	}
+	sort.Strings(candidates)
+	return mappingConfig.Mappings[candidates[0]].ID, nil
+}
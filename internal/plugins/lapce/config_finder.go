@@ -0,0 +1,57 @@
+package lapce
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+)
+
+// ConfigDetect returns the path of the stable Lapce release's keymaps.toml:
+// ~/Library/Application Support/dev.lapce.Lapce-Stable on macOS, $XDG_CONFIG_HOME/lapce-stable on Linux
+// and %APPDATA%\lapce\Lapce-Stable\config on Windows.
+func (p *lapcePlugin) ConfigDetect(opts pluginapi.ConfigDetectOptions) (paths []string, installed bool, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var configDir string
+	switch runtime.GOOS {
+	case "darwin":
+		configDir = filepath.Join(home, "Library", "Application Support", "dev.lapce.Lapce-Stable")
+	case "linux":
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			configDir = filepath.Join(xdg, "lapce-stable")
+		} else {
+			configDir = filepath.Join(home, ".config", "lapce-stable")
+		}
+	case "windows":
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			return nil, false, fmt.Errorf("APPDATA environment variable not set, %w", pluginapi.ErrNotSupported)
+		}
+		configDir = filepath.Join(appData, "lapce", "Lapce-Stable", "config")
+	default:
+		return nil, false, fmt.Errorf(
+			"automatic path discovery is only supported on macOS, Linux, and Windows, %w",
+			pluginapi.ErrNotSupported,
+		)
+	}
+
+	if opts.Sandbox {
+		installed = false
+	} else {
+		_, err = exec.LookPath("lapce")
+		installed = err == nil
+		if !installed && runtime.GOOS == "darwin" {
+			_, statErr := os.Stat("/Applications/Lapce.app")
+			installed = statErr == nil
+		}
+	}
+
+	return []string{filepath.Join(configDir, "keymaps.toml")}, installed, nil
+}
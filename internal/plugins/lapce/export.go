@@ -0,0 +1,170 @@
+package lapce
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/internal/export"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+type lapceExporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	differ        diff.Differ
+}
+
+func newExporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	differ diff.Differ,
+) pluginapi.PluginExporter {
+	return &lapceExporter{mappingConfig: mappingConfig, logger: logger, differ: differ}
+}
+
+// Export writes the keymap to Lapce's keymaps.toml. Keymaps for commands not managed by onekeymap
+// are preserved unless they bind the same key in the same mode and context.
+func (e *lapceExporter) Export(
+	ctx context.Context,
+	destination io.Writer,
+	setting keymap.Keymap,
+	opts pluginapi.PluginExportOption,
+) (*pluginapi.PluginExportReport, error) {
+	var existing lapceKeymapsFile
+	if opts.ExistingConfig != nil {
+		var err error
+		existing, err = parseKeymaps(opts.ExistingConfig)
+		if err != nil {
+			e.logger.WarnContext(
+				ctx,
+				"Failed to parse existing config, proceeding with destructive export",
+				"error",
+				err,
+			)
+			existing = lapceKeymapsFile{}
+		}
+	}
+
+	marker := export.NewMarker(&setting)
+	managed := e.identifyManagedKeymaps(ctx, &setting, marker)
+
+	final := lapceKeymapsFile{
+		Keymaps: e.nonDestructiveMerge(ctx, managed, existing.Keymaps),
+		Extra:   existing.Extra,
+	}
+	if err := final.write(destination); err != nil {
+		return nil, err
+	}
+
+	return &pluginapi.PluginExportReport{
+		BaseEditorConfig:   existing.Keymaps,
+		ExportEditorConfig: final.Keymaps,
+		SkipReport:         marker.Report(),
+		ExportedReport:     marker.ExportedReport(),
+	}, nil
+}
+
+// identifyManagedKeymaps generates Lapce keymaps from KeymapSetting.
+func (e *lapceExporter) identifyManagedKeymaps(
+	ctx context.Context,
+	setting *keymap.Keymap,
+	marker *export.Marker,
+) []lapceKeymap {
+	var result []lapceKeymap
+	seen := make(map[lapceKeymap]struct{})
+
+	for _, km := range setting.Actions {
+		mapping, usedFallback := e.mappingConfig.GetExportAction(km.Name, pluginapi.EditorTypeLapce)
+		if mapping == nil || len(mapping.Lapce) == 0 {
+			for _, b := range km.Bindings {
+				if len(b.KeyChords) > 0 {
+					marker.MarkSkippedForReason(km.Name, &b, pluginapi.ErrActionNotSupported)
+				}
+			}
+			continue
+		}
+
+		if usedFallback {
+			e.logger.InfoContext(ctx, "Action not directly supported, using fallback action",
+				"originalAction", km.Name,
+				"fallbackAction", mapping.ID,
+			)
+		}
+
+		for _, b := range km.Bindings {
+			if len(b.KeyChords) == 0 {
+				continue
+			}
+			key, err := formatKeybinding(b)
+			if err != nil {
+				e.logger.DebugContext(ctx, "Skipping keybinding with un-formattable key", "action", km.Name, "error", err)
+				marker.MarkSkippedForReason(km.Name, &b, &pluginapi.UnsupportedExportActionError{Note: err.Error()})
+				continue
+			}
+			marker.MarkExported(km.Name, b)
+
+			for _, lc := range mapping.Lapce {
+				if lc.Command == "" {
+					continue
+				}
+				entry := lapceKeymap{Key: key, Command: lc.Command, Mode: lc.Mode, When: lc.When}
+				if _, dup := seen[entry]; dup {
+					continue
+				}
+				seen[entry] = struct{}{}
+				result = append(result, entry)
+			}
+		}
+	}
+
+	return result
+}
+
+// nonDestructiveMerge appends the existing keymaps that are kept to the managed ones. Keymaps of managed
+// commands are replaced; unmanaged keymaps and removals of defaults are kept unless they conflict.
+func (e *lapceExporter) nonDestructiveMerge(ctx context.Context, managed, existing []lapceKeymap) []lapceKeymap {
+	managedKeys := make(map[string]string, len(managed)) // key+mode+when -> command
+	for _, km := range managed {
+		managedKeys[conflictKey(km)] = km.Command
+	}
+
+	result := make([]lapceKeymap, 0, len(managed)+len(existing))
+	result = append(result, managed...)
+	for _, km := range existing {
+		if strings.HasPrefix(km.Command, removeCommandPrefix) {
+			// Drop removals that would cancel a keymap we export
+			if managedKeys[conflictKey(km)] == strings.TrimPrefix(km.Command, removeCommandPrefix) {
+				continue
+			}
+			result = append(result, km)
+			continue
+		}
+		if isManagedCommand(e.mappingConfig, km.Command) {
+			continue
+		}
+		if command, ok := managedKeys[conflictKey(km)]; ok {
+			e.logger.DebugContext(ctx, "Conflict resolved: managed keybinding takes priority",
+				"key", km.Key, "managed_command", command, "unmanaged_command", km.Command)
+			continue
+		}
+		result = append(result, km)
+	}
+	return result
+}
+
+// conflictKey identifies where a keymap applies. Keys are normalized, so that e.g. "Ctrl+P" and
+// "ctrl+p" compare equal.
+func conflictKey(km lapceKeymap) string {
+	key := km.Key
+	if kb, err := parseKeybinding(km.Key); err == nil {
+		if formatted, err := formatKeybinding(kb); err == nil {
+			key = formatted
+		}
+	}
+	return key + "|" + normalizeMode(km.Mode) + "|" + km.When
+}
@@ -0,0 +1,218 @@
+package lapce
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+// nolint:unparam // newAction creates a test Action with given keybindings
+func newAction(actionName string, keybindings ...string) keymap.Action {
+	var bindings []keybinding.Keybinding
+	for _, kb := range keybindings {
+		b, err := keybinding.NewKeybinding(kb, keybinding.ParseOption{Separator: "+"})
+		if err != nil {
+			panic(err)
+		}
+		bindings = append(bindings, b)
+	}
+	return keymap.Action{
+		Name:     actionName,
+		Bindings: bindings,
+	}
+}
+
+func decodeLapceTOMLMap(t *testing.T, s string) map[string]any {
+	var got map[string]any
+	require.NoError(t, toml.Unmarshal([]byte(s), &got))
+	return got
+}
+
+func TestExportLapceKeymap(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		setting        keymap.Keymap
+		existingConfig string
+		wantTOML       string
+		wantSkipped    int
+	}{
+		{
+			name:    "export copy keymap",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")}},
+			wantTOML: `
+[[keymaps]]
+key = "meta+c"
+command = "clipboard_copy"
+`,
+		},
+		{
+			name: "mode and when clause are written",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.test.childSupported", "ctrl+k ctrl+j"),
+			}},
+			wantTOML: `
+[[keymaps]]
+key = "ctrl+k ctrl+j"
+command = "child_supported"
+mode = "i"
+when = "editor_focus"
+`,
+		},
+		{
+			name: "falls back to fallback action when parent not supported",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.test.parentNotSupported", "meta+shift+h"),
+			}},
+			wantTOML: `
+[[keymaps]]
+key = "meta+shift+h"
+command = "child_supported"
+mode = "i"
+when = "editor_focus"
+`,
+		},
+		{
+			name: "unsupported actions and keys are skipped",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.test.withArgs", "ctrl+j"),
+				newAction("actions.edit.copy", "ctrl+numpad1", "ctrl+c"),
+			}},
+			wantTOML: `
+[[keymaps]]
+key = "ctrl+c"
+command = "clipboard_copy"
+`,
+			wantSkipped: 2,
+		},
+		{
+			name:    "non-destructive export keeps unmanaged keymaps and replaces managed ones",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")}},
+			existingConfig: `
+[[keymaps]]
+key = "ctrl+insert"
+command = "clipboard_copy"
+
+[[keymaps]]
+key = "ctrl+t"
+command = "palette.workspace_symbol"
+`,
+			wantTOML: `
+[[keymaps]]
+key = "meta+c"
+command = "clipboard_copy"
+
+[[keymaps]]
+key = "ctrl+t"
+command = "palette.workspace_symbol"
+`,
+		},
+		{
+			name:    "managed keymap takes priority over conflicting user keymap",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")}},
+			existingConfig: `
+[[keymaps]]
+key = "Meta+C"
+command = "custom_command"
+
+[[keymaps]]
+key = "meta+c"
+command = "custom_command"
+mode = "n"
+`,
+			wantTOML: `
+[[keymaps]]
+key = "meta+c"
+command = "clipboard_copy"
+
+[[keymaps]]
+key = "meta+c"
+command = "custom_command"
+mode = "n"
+`,
+		},
+		{
+			name:    "removals are kept unless they cancel an exported keymap",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")}},
+			existingConfig: `
+[[keymaps]]
+key = "meta+c"
+command = "-clipboard_copy"
+
+[[keymaps]]
+key = "ctrl+p"
+command = "-palette"
+`,
+			wantTOML: `
+[[keymaps]]
+key = "meta+c"
+command = "clipboard_copy"
+
+[[keymaps]]
+key = "ctrl+p"
+command = "-palette"
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			exporter, err := p.Exporter()
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			opts := pluginapi.PluginExportOption{}
+			if tt.existingConfig != "" {
+				opts.ExistingConfig = strings.NewReader(tt.existingConfig)
+			}
+
+			report, err := exporter.Export(context.Background(), &buf, tt.setting, opts)
+			require.NoError(t, err)
+			assert.Equal(t, decodeLapceTOMLMap(t, tt.wantTOML), decodeLapceTOMLMap(t, buf.String()))
+			assert.Len(t, report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestExportLapceKeymap_PreservesOtherEntries(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+
+	existingConfig := `version = 2
+
+[[keymaps]]
+key = "ctrl+t"
+command = "palette.workspace_symbol"
+`
+	setting := keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "ctrl+c")}}
+
+	var buf bytes.Buffer
+	_, err = exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{
+		ExistingConfig: strings.NewReader(existingConfig),
+	})
+	require.NoError(t, err)
+
+	got := decodeLapceTOMLMap(t, buf.String())
+	assert.EqualValues(t, 2, got["version"])
+	keymaps, ok := got["keymaps"].([]any)
+	require.True(t, ok, "keymaps should be an array of tables")
+	assert.Len(t, keymaps, 2)
+}
@@ -0,0 +1,79 @@
+package lapce
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/internal/dedup"
+	"github.com/xinnjie/onekeymap-cli/internal/imports"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+type lapceImporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	reporter      *metrics.UnknownActionReporter
+}
+
+func newImporter(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) *lapceImporter {
+	return &lapceImporter{
+		mappingConfig: mappingConfig,
+		logger:        logger,
+		reporter:      metrics.NewUnknownActionReporter(recorder),
+	}
+}
+
+// Import reads Lapce's keymaps.toml and converts its [[keymaps]] entries into the universal
+// KeymapSetting format. Entries whose command starts with "-" only remove a default keymap,
+// so there is nothing to import from them.
+func (i *lapceImporter) Import(
+	ctx context.Context,
+	source io.Reader,
+	_ pluginapi.PluginImportOption,
+) (pluginapi.PluginImportResult, error) {
+	file, err := parseKeymaps(source)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, err
+	}
+
+	setting := keymap.Keymap{}
+	marker := imports.NewMarker()
+	for _, km := range file.Keymaps {
+		if km.Command == "" || strings.HasPrefix(km.Command, removeCommandPrefix) {
+			continue
+		}
+		kb, err := parseKeybinding(km.Key)
+		if err != nil {
+			i.logger.DebugContext(ctx, "failed to parse lapce key", "key", km.Key, "error", err)
+			marker.MarkSkipped(km.Command, nil, fmt.Errorf("failed to parse key '%s': %w", km.Key, err))
+			continue
+		}
+
+		mapping := findMappingByLapce(i.mappingConfig, km.Command, km.Mode, km.When)
+		if mapping == nil {
+			i.logger.DebugContext(ctx, "failed to find action", "command", km.Command, "mode", km.Mode, "when", km.When)
+			i.reporter.ReportUnknownCommand(ctx, pluginapi.EditorTypeLapce, km.Command)
+			marker.MarkSkipped(km.Command, &kb, pluginapi.ErrActionNotSupported)
+			continue
+		}
+
+		setting.Actions = append(setting.Actions, keymap.Action{
+			Name:     mapping.ID,
+			Bindings: []keybinding.Keybinding{kb},
+		})
+		marker.MarkImported(mapping.ID, km.Command, kb, kb)
+	}
+
+	setting.Actions = dedup.Actions(setting.Actions)
+	result := pluginapi.PluginImportResult{Keymap: setting}
+	result.Report.SkipReport = marker.Report()
+	result.Report.ImportedReport = marker.ImportedReport()
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package lapce
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func TestImportLapceKeymap(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    []keymap.Action
+		wantSkipped int
+		wantErr     bool
+	}{
+		{
+			name: "simple keymap",
+			input: `
+[[keymaps]]
+key = "meta+c"
+command = "clipboard_copy"
+`,
+			expected: []keymap.Action{newAction("actions.edit.copy", "meta+c")},
+		},
+		{
+			name: "mode and when clause are matched",
+			input: `
+[[keymaps]]
+key = "ctrl+shift+k"
+command = "child_supported"
+mode = "i"
+when = "editor_focus"
+
+[[keymaps]]
+key = "Ctrl+K"
+command = "child_supported"
+`,
+			expected: []keymap.Action{newAction("actions.test.childSupported", "ctrl+shift+k", "ctrl+k")},
+		},
+		{
+			name: "removals of default keymaps are ignored",
+			input: `
+[[keymaps]]
+key = "ctrl+c"
+command = "-clipboard_copy"
+`,
+		},
+		{
+			name: "unknown commands and unparsable keys are skipped",
+			input: `
+[[keymaps]]
+key = "ctrl+j"
+command = "unknown_command"
+
+[[keymaps]]
+key = "ctrl+numpad1"
+command = "child_supported"
+
+[[keymaps]]
+key = "ctrl+c"
+command = "clipboard_copy"
+`,
+			expected:    []keymap.Action{newAction("actions.edit.copy", "ctrl+c")},
+			wantSkipped: 2,
+		},
+		{
+			name:    "invalid toml",
+			input:   `[[keymaps]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			importer, err := p.Importer()
+			require.NoError(t, err)
+
+			result, err := importer.Import(context.Background(), strings.NewReader(tt.input), pluginapi.PluginImportOption{})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, result.Keymap.Actions)
+			assert.Len(t, result.Report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "meta+c"),
+		newAction("actions.test.childSupported", "ctrl+k ctrl+shift+f5"),
+	}}
+	var buf strings.Builder
+	_, err = exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	result, err := importer.Import(context.Background(), strings.NewReader(buf.String()), pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, setting.Actions, result.Keymap.Actions)
+}
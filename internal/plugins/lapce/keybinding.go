@@ -0,0 +1,138 @@
+package lapce
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/internal/bimap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keychord"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keycode"
+)
+
+const (
+	lapceKeyStrokeSeparator = "+"
+	lapceKeySequenceSep     = " "
+)
+
+//nolint:gochecknoglobals // static lookup tables shared across parse/format functions; initialized once and read-only
+var (
+	// meta is Cmd on macOS and the Super/Windows key elsewhere.
+	lapceModifierMapping = bimap.NewBiMapFromMap(map[string]keycode.KeyModifier{
+		"ctrl":  keycode.KeyModifierCtrl,
+		"meta":  keycode.KeyModifierMeta,
+		"shift": keycode.KeyModifierShift,
+		"alt":   keycode.KeyModifierAlt,
+	})
+
+	lapceModifierOrder = []keycode.KeyModifier{
+		keycode.KeyModifierCtrl, keycode.KeyModifierMeta, keycode.KeyModifierShift, keycode.KeyModifierAlt,
+	}
+
+	// lapceUnsupportedKeys cannot be bound in Lapce. Other key names match ours, e.g. "pageup" or "escape".
+	lapceUnsupportedKeys = []keycode.KeyCode{
+		keycode.KeyCodeFn, keycode.KeyCodeShift, keycode.KeyCodeCtrl, keycode.KeyCodeAlt,
+		keycode.KeyCodeCmd, keycode.KeyCodeRightCmd, keycode.KeyCodeRightAlt, keycode.KeyCodeRightCtrl,
+		keycode.KeyCodeRightShift, keycode.KeyCodeMute, keycode.KeyCodeVolumeUp, keycode.KeyCodeVolumeDown,
+	}
+
+	// lapceKeyAliases are other key names Lapce accepts; they are never written.
+	lapceKeyAliases = map[string]keycode.KeyCode{
+		"arrowup":    keycode.KeyCodeUp,
+		"arrowdown":  keycode.KeyCodeDown,
+		"arrowleft":  keycode.KeyCodeLeft,
+		"arrowright": keycode.KeyCodeRight,
+		"del":        keycode.KeyCodeDelete,
+		"esc":        keycode.KeyCodeEscape,
+	}
+)
+
+// formatKeybinding formats a keybinding as a Lapce key, e.g. "ctrl+shift+p" or "ctrl+k ctrl+s".
+// Lapce matches logical keys, so numpad keys cannot be told apart from the main keyboard.
+func formatKeybinding(kb keybinding.Keybinding) (string, error) {
+	strokes := make([]string, 0, len(kb.KeyChords))
+	for _, chord := range kb.KeyChords {
+		if chord.KeyCode == "" || chord.KeyCode.IsNumpad() || slices.Contains(lapceUnsupportedKeys, chord.KeyCode) {
+			return "", fmt.Errorf("%w: %q", ErrNotSupportKeyChords, chord.String(keychord.FormatOption{
+				Separator: lapceKeyStrokeSeparator,
+			}))
+		}
+
+		var parts []string
+		for _, mod := range lapceModifierOrder {
+			if slices.Contains(chord.Modifiers, mod) {
+				name, _ := lapceModifierMapping.GetInverse(mod)
+				parts = append(parts, name)
+			}
+		}
+
+		parts = append(parts, string(chord.KeyCode))
+		strokes = append(strokes, strings.Join(parts, lapceKeyStrokeSeparator))
+	}
+	return strings.Join(strokes, lapceKeySequenceSep), nil
+}
+
+// parseKeybinding parses a Lapce key such as "Ctrl+Shift+P" or "ctrl+k ctrl+s".
+func parseKeybinding(key string) (keybinding.Keybinding, error) {
+	strokes := strings.Fields(key)
+	if len(strokes) == 0 {
+		return keybinding.Keybinding{}, errors.New("cannot parse empty key")
+	}
+
+	chords := make([]keychord.KeyChord, 0, len(strokes))
+	for _, s := range strokes {
+		chord, err := parseKeyStroke(s)
+		if err != nil {
+			return keybinding.Keybinding{}, err
+		}
+		chords = append(chords, chord)
+	}
+	return keybinding.Keybinding{KeyChords: chords}, nil
+}
+
+// parseKeyStroke parses a single stroke like "ctrl+shift+p" or "ctrl++". Like Lapce, the key is
+// whatever follows the last '+', and an empty key means the '+' key itself.
+func parseKeyStroke(s string) (keychord.KeyChord, error) {
+	modifiers, key := "", s
+	if idx := strings.LastIndex(s, lapceKeyStrokeSeparator); idx >= 0 {
+		modifiers, key = s[:idx], s[idx+1:]
+	}
+	if key == "" {
+		key = lapceKeyStrokeSeparator
+		modifiers = strings.TrimSuffix(modifiers, lapceKeyStrokeSeparator)
+	}
+
+	chord := keychord.KeyChord{}
+	if modifiers != "" {
+		for _, part := range strings.Split(modifiers, lapceKeyStrokeSeparator) {
+			mod, ok := lapceModifierMapping.Get(strings.ToLower(part))
+			if !ok {
+				return keychord.KeyChord{}, fmt.Errorf("unknown lapce modifier %q in %q", part, s)
+			}
+			if !slices.Contains(chord.Modifiers, mod) {
+				chord.Modifiers = append(chord.Modifiers, mod)
+			}
+		}
+	}
+
+	kc, err := fromLapceKey(key)
+	if err != nil {
+		return keychord.KeyChord{}, fmt.Errorf("%w: %q", err, s)
+	}
+	chord.KeyCode = kc
+	return chord, nil
+}
+
+func fromLapceKey(key string) (keycode.KeyCode, error) {
+	lower := strings.ToLower(key)
+	if kc, ok := lapceKeyAliases[lower]; ok {
+		return kc, nil
+	}
+	kc := keycode.KeyCode(lower)
+	if kc.IsValid() && !kc.IsNumpad() && !slices.Contains(lapceUnsupportedKeys, kc) {
+		return kc, nil
+	}
+	return "", errors.New("unsupported lapce key")
+}
@@ -0,0 +1,38 @@
+package lapce
+
+import (
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+var _ pluginapi.Plugin = (*lapcePlugin)(nil)
+
+type lapcePlugin struct {
+	mappingConfig *mappings.MappingConfig
+	importer      pluginapi.PluginImporter
+	exporter      pluginapi.PluginExporter
+	logger        *slog.Logger
+}
+
+// New creates a new Lapce plugin instance.
+func New(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) pluginapi.Plugin {
+	return &lapcePlugin{
+		mappingConfig: mappingConfig,
+		importer:      newImporter(mappingConfig, logger, recorder),
+		exporter:      newExporter(mappingConfig, logger, diff.NewJSONASCIIDiffer()),
+		logger:        logger,
+	}
+}
+
+// EditorType returns the unique identifier for Lapce.
+func (p *lapcePlugin) EditorType() pluginapi.EditorType { return pluginapi.EditorTypeLapce }
+
+// Importer returns the importer for this plugin.
+func (p *lapcePlugin) Importer() (pluginapi.PluginImporter, error) { return p.importer, nil }
+
+// Exporter returns the exporter for this plugin.
+func (p *lapcePlugin) Exporter() (pluginapi.PluginExporter, error) { return p.exporter, nil }
@@ -0,0 +1,85 @@
+package lapce
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// removeCommandPrefix marks an entry that removes a default keymap, e.g. command = "-palette.command".
+const removeCommandPrefix = "-"
+
+// lapceKeymap is a [[keymaps]] entry of Lapce's keymaps.toml:
+//
+//	[[keymaps]]
+//	key = "ctrl+shift+p"
+//	command = "palette.command"
+//	mode = "nv"
+//	when = "editor_focus"
+type lapceKeymap struct {
+	Key     string `toml:"key"            json:"key"`
+	Command string `toml:"command"        json:"command"`
+	Mode    string `toml:"mode,omitempty" json:"mode,omitempty"`
+	When    string `toml:"when,omitempty" json:"when,omitempty"`
+}
+
+// lapceKeymapsFile is Lapce's keymaps.toml.
+type lapceKeymapsFile struct {
+	Keymaps []lapceKeymap `toml:"keymaps"`
+	// Extra holds other top-level entries, which are written back unchanged.
+	Extra map[string]interface{} `toml:"-"`
+}
+
+func parseKeymaps(reader io.Reader) (lapceKeymapsFile, error) {
+	var file lapceKeymapsFile
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return file, fmt.Errorf("failed to read lapce keymaps: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return file, nil
+	}
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return lapceKeymapsFile{}, fmt.Errorf("failed to parse lapce keymaps: %w", err)
+	}
+	var generic map[string]interface{}
+	if err := toml.Unmarshal(data, &generic); err != nil {
+		return lapceKeymapsFile{}, fmt.Errorf("failed to parse lapce keymaps: %w", err)
+	}
+	delete(generic, "keymaps")
+	if len(generic) > 0 {
+		file.Extra = generic
+	}
+	return file, nil
+}
+
+func (f lapceKeymapsFile) write(w io.Writer) error {
+	var buf bytes.Buffer
+	if len(f.Extra) > 0 {
+		// Extra is written first: plain top-level keys must come before the [[keymaps]] tables
+		if err := toml.NewEncoder(&buf).Encode(f.Extra); err != nil {
+			return fmt.Errorf("failed to encode lapce keymaps: %w", err)
+		}
+		buf.WriteString("\n")
+	}
+	if err := toml.NewEncoder(&buf).Encode(struct {
+		Keymaps []lapceKeymap `toml:"keymaps"`
+	}{f.Keymaps}); err != nil {
+		return fmt.Errorf("failed to encode lapce keymaps: %w", err)
+	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write lapce keymaps: %w", err)
+	}
+	return nil
+}
+
+// normalizeMode sorts the mode letters so that e.g. "vn" and "nv" compare equal.
+func normalizeMode(mode string) string {
+	letters := strings.Split(strings.ToLower(mode), "")
+	sort.Strings(letters)
+	return strings.Join(letters, "")
+}
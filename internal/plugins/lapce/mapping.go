@@ -0,0 +1,53 @@
+package lapce
+
+import (
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+// findMappingByLapce finds the action for a Lapce command bound with the given mode and when clause.
+// Configs whose mode and when clause also match are preferred over those that only share the command;
+// ties are broken by action ID to keep the result deterministic.
+func findMappingByLapce(
+	mappingConfig *mappings.MappingConfig,
+	command, mode, when string,
+) *mappings.ActionMappingConfig {
+	var exact, commandOnly []string
+	for id, mapping := range mappingConfig.Mappings {
+		for _, lc := range mapping.Lapce {
+			if lc.DisableImport || lc.Command == "" || lc.Command != command {
+				continue
+			}
+			if normalizeMode(lc.Mode) == normalizeMode(mode) && lc.When == when {
+				exact = append(exact, id)
+			} else {
+				commandOnly = append(commandOnly, id)
+			}
+		}
+	}
+
+	candidates := exact
+	if len(candidates) == 0 {
+		candidates = commandOnly
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Strings(candidates)
+	m := mappingConfig.Mappings[candidates[0]]
+	return &m
+}
+
+// isManagedCommand reports whether a Lapce command corresponds to one of our action mappings,
+// including export-only ones.
+func isManagedCommand(mappingConfig *mappings.MappingConfig, command string) bool {
+	for _, mapping := range mappingConfig.Mappings {
+		for _, lc := range mapping.Lapce {
+			if lc.Command != "" && lc.Command == command {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package pulsar
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+)
+
+// ConfigDetect returns the path of keymap.cson in Pulsar's config directory, $ATOM_HOME or ~/.pulsar.
+func (p *pulsarPlugin) ConfigDetect(opts pluginapi.ConfigDetectOptions) (paths []string, installed bool, err error) {
+	configDir := os.Getenv("ATOM_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, false, err
+		}
+		configDir = filepath.Join(home, ".pulsar")
+	}
+
+	if opts.Sandbox {
+		installed = false
+	} else {
+		_, err = exec.LookPath("pulsar")
+		installed = err == nil
+		if !installed && runtime.GOOS == "darwin" {
+			_, statErr := os.Stat("/Applications/Pulsar.app")
+			installed = statErr == nil
+		}
+	}
+
+	return []string{filepath.Join(configDir, "keymap.cson")}, installed, nil
+}
@@ -0,0 +1,292 @@
+package pulsar
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const csonIndent = "  "
+
+// pulsarBinding is a single `'keystroke': 'command'` entry.
+type pulsarBinding struct {
+	Keystroke string `json:"keystroke"`
+	Command   string `json:"command"`
+	// Comments are the comment lines above the entry and Trailing the comment after it on its line,
+	// both as written in the file.
+	Comments []string `json:"-"`
+	Trailing string   `json:"-"`
+}
+
+// pulsarSection groups the bindings declared under one CSS selector.
+type pulsarSection struct {
+	Selector string          `json:"selector"`
+	Bindings []pulsarBinding `json:"bindings"`
+	// Comments are the comment lines above the selector and Trailing the comment after it on its line.
+	Comments []string `json:"-"`
+	Trailing string   `json:"-"`
+}
+
+// pulsarKeymap is Pulsar's keymap.cson, e.g.
+//
+//	'atom-text-editor':
+//	  'ctrl-shift-d': 'editor:duplicate-lines'
+//
+// Only the subset of CSON used by keymaps is understood: one level of selectors, each mapping keystrokes
+// to command strings. Header is the comment block before the first selector, which Pulsar seeds the file
+// with, and Footer the comment lines after the last binding; both are written back unchanged. Other
+// comments belong to the selector or binding below them. Blank lines after the header are not kept.
+type pulsarKeymap struct {
+	Header   []string
+	Sections []pulsarSection
+	Footer   []string
+}
+
+func parseKeymap(reader io.Reader) (pulsarKeymap, error) {
+	var km pulsarKeymap
+	var current *pulsarSection
+	var comments []string // comment lines waiting for the selector or binding below them
+	inBlockComment := false
+	lineNo := 0
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		lineNo++
+		raw := strings.TrimSuffix(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(raw)
+		if inBlockComment || isBlockCommentStart(trimmed) {
+			if inBlockComment {
+				inBlockComment = !strings.Contains(trimmed, "###")
+			} else {
+				inBlockComment = !strings.Contains(trimmed[3:], "###")
+			}
+			if len(km.Sections) == 0 {
+				km.Header = append(km.Header, raw)
+			} else {
+				comments = append(comments, raw)
+			}
+			continue
+		}
+
+		stripped := stripComment(raw)
+		content := strings.TrimSpace(stripped)
+		trailing := strings.TrimSpace(raw[len(stripped):])
+		if content == "" {
+			if len(km.Sections) == 0 {
+				km.Header = append(km.Header, raw)
+			} else if trailing != "" {
+				comments = append(comments, raw)
+			}
+			continue
+		}
+
+		key, rest, err := cutKey(content)
+		if err != nil {
+			return pulsarKeymap{}, fmt.Errorf("failed to parse keymap.cson line %d: %w", lineNo, err)
+		}
+		rest = strings.TrimSpace(rest)
+
+		if raw[0] != ' ' && raw[0] != '\t' {
+			if rest != "" && rest != "{}" {
+				return pulsarKeymap{}, fmt.Errorf(
+					"failed to parse keymap.cson line %d: expected bindings to be nested under selector %q",
+					lineNo, key,
+				)
+			}
+			current = km.section(key)
+			current.Comments = append(current.Comments, comments...)
+			if trailing != "" {
+				current.Trailing = trailing
+			}
+			comments = nil
+			continue
+		}
+
+		if current == nil {
+			return pulsarKeymap{}, fmt.Errorf("failed to parse keymap.cson line %d: binding outside a selector", lineNo)
+		}
+		command, tail, err := cutString(rest)
+		if err != nil {
+			return pulsarKeymap{}, fmt.Errorf("failed to parse keymap.cson line %d: %w", lineNo, err)
+		}
+		if strings.TrimSpace(tail) != "" {
+			return pulsarKeymap{}, fmt.Errorf("failed to parse keymap.cson line %d: unexpected %q", lineNo, tail)
+		}
+		// A keystroke declared again replaces the earlier entry, comments included
+		b := current.set(key, command)
+		b.Comments, b.Trailing = comments, trailing
+		comments = nil
+	}
+	if err := scanner.Err(); err != nil {
+		return pulsarKeymap{}, fmt.Errorf("failed to read keymap.cson: %w", err)
+	}
+	if inBlockComment {
+		return pulsarKeymap{}, errors.New("failed to parse keymap.cson: unterminated ### comment")
+	}
+
+	km.Footer = comments
+
+	// Trailing blank lines of the header are regenerated on write
+	for len(km.Header) > 0 && strings.TrimSpace(km.Header[len(km.Header)-1]) == "" {
+		km.Header = km.Header[:len(km.Header)-1]
+	}
+	return km, nil
+}
+
+// section returns the section for selector, creating it when needed. Like CoffeeScript object literals,
+// a selector declared twice is a single object; later keystrokes override earlier ones.
+func (k *pulsarKeymap) section(selector string) *pulsarSection {
+	for i := range k.Sections {
+		if k.Sections[i].Selector == selector {
+			return &k.Sections[i]
+		}
+	}
+	k.Sections = append(k.Sections, pulsarSection{Selector: selector})
+	return &k.Sections[len(k.Sections)-1]
+}
+
+// set binds keystroke to command, replacing an earlier binding of the same keystroke in place,
+// and returns the binding.
+func (s *pulsarSection) set(keystroke, command string) *pulsarBinding {
+	for i, b := range s.Bindings {
+		if b.Keystroke == keystroke {
+			s.Bindings[i].Command = command
+			return &s.Bindings[i]
+		}
+	}
+	s.Bindings = append(s.Bindings, pulsarBinding{Keystroke: keystroke, Command: command})
+	return &s.Bindings[len(s.Bindings)-1]
+}
+
+func (k pulsarKeymap) write(w io.Writer) error {
+	var sb strings.Builder
+	for _, line := range k.Header {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	first := true
+	for _, section := range k.Sections {
+		if len(section.Bindings) == 0 {
+			continue
+		}
+		if !first || len(k.Header) > 0 {
+			sb.WriteString("\n")
+		}
+		first = false
+		writeComments(&sb, section.Comments)
+		sb.WriteString(quoteString(section.Selector) + ":")
+		writeTrailing(&sb, section.Trailing)
+		for _, b := range section.Bindings {
+			writeComments(&sb, b.Comments)
+			fmt.Fprintf(&sb, "%s%s: %s", csonIndent, quoteString(b.Keystroke), quoteString(b.Command))
+			writeTrailing(&sb, b.Trailing)
+		}
+	}
+	if len(k.Footer) > 0 {
+		sb.WriteString("\n")
+		writeComments(&sb, k.Footer)
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("failed to write keymap.cson: %w", err)
+	}
+	return nil
+}
+
+func writeComments(sb *strings.Builder, lines []string) {
+	for _, line := range lines {
+		sb.WriteString(line + "\n")
+	}
+}
+
+func writeTrailing(sb *strings.Builder, comment string) {
+	if comment != "" {
+		sb.WriteString(" " + comment)
+	}
+	sb.WriteString("\n")
+}
+
+// cutKey reads an object key followed by ':' and returns the key and the text after the colon.
+// Keys are 'single' or "double" quoted, or bare identifiers.
+func cutKey(s string) (string, string, error) {
+	var key, rest string
+	if s[0] == '\'' || s[0] == '"' {
+		var err error
+		key, rest, err = cutString(s)
+		if err != nil {
+			return "", "", err
+		}
+	} else {
+		end := strings.IndexAny(s, ": \t")
+		if end <= 0 {
+			return "", "", fmt.Errorf("expected a key in %q", s)
+		}
+		key, rest = s[:end], s[end:]
+	}
+	rest = strings.TrimLeft(rest, " \t")
+	if !strings.HasPrefix(rest, ":") {
+		return "", "", fmt.Errorf("expected ':' after key %q", key)
+	}
+	return key, rest[1:], nil
+}
+
+// cutString reads a 'single' or "double" quoted string with backslash escapes and returns
+// the unquoted value and the text after the closing quote.
+func cutString(s string) (string, string, error) {
+	if s == "" || (s[0] != '\'' && s[0] != '"') {
+		return "", "", fmt.Errorf("expected a quoted string, got %q", s)
+	}
+	quote := s[0]
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(s[i])
+			}
+		case c == quote:
+			return sb.String(), s[i+1:], nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", "", fmt.Errorf("unterminated string %q", s)
+}
+
+// isBlockCommentStart reports whether a line opens a ### block comment. Lines of four or more '#'
+// are ordinary line comments.
+func isBlockCommentStart(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "###") && (len(trimmed) == 3 || trimmed[3] != '#')
+}
+
+// stripComment removes a trailing '#' comment that is not inside a quoted string.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// quoteString single-quotes s, escaping backslashes and single quotes.
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,116 @@
+package pulsar
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeymap(t *testing.T) {
+	input := `# Your keymap
+#
+# Pulsar keymaps work similarly to style sheets.
+
+###
+'atom-text-editor':
+  'ctrl-x': 'commented:out'
+###
+
+'atom-text-editor':
+  'ctrl-shift-d': 'editor:duplicate-lines' # trailing comment
+  "ctrl-\\": "tree-view:toggle"
+
+atom-workspace:
+  'ctrl-p': 'fuzzy-finder:toggle-file-finder'
+
+'body': {}
+
+'atom-text-editor':
+  'ctrl-shift-d': 'unset!'
+`
+	km, err := parseKeymap(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Len(t, km.Header, 8)
+	assert.Equal(t, []pulsarSection{
+		{Selector: "atom-text-editor", Bindings: []pulsarBinding{
+			{Keystroke: "ctrl-shift-d", Command: "unset!"},
+			{Keystroke: `ctrl-\`, Command: "tree-view:toggle"},
+		}},
+		{Selector: "atom-workspace", Bindings: []pulsarBinding{
+			{Keystroke: "ctrl-p", Command: "fuzzy-finder:toggle-file-finder"},
+		}},
+		{Selector: "body"},
+	}, km.Sections)
+}
+
+func TestParseKeymap_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "binding outside a selector", input: "  'ctrl-k': 'x'\n"},
+		{name: "inline object", input: "'body': {'ctrl-k': 'x'}\n"},
+		{name: "non-string command", input: "'body':\n  'ctrl-k': ['a', 'b']\n"},
+		{name: "unterminated string", input: "'body':\n  'ctrl-k': 'x\n"},
+		{name: "unterminated block comment", input: "###\n'body':\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseKeymap(strings.NewReader(tt.input))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestWriteKeymap(t *testing.T) {
+	km := pulsarKeymap{
+		Header: []string{"# Your keymap"},
+		Sections: []pulsarSection{
+			{Selector: "atom-workspace", Bindings: []pulsarBinding{{Keystroke: "ctrl-p", Command: "a:b"}}},
+			{Selector: "body"},
+			{Selector: "atom-text-editor[data-grammar~='go']", Bindings: []pulsarBinding{
+				{Keystroke: `ctrl-\`, Command: "c:d"},
+			}},
+		},
+	}
+	var sb strings.Builder
+	require.NoError(t, km.write(&sb))
+	assert.Equal(t, `# Your keymap
+
+'atom-workspace':
+  'ctrl-p': 'a:b'
+
+'atom-text-editor[data-grammar~=\'go\']':
+  'ctrl-\\': 'c:d'
+`, sb.String())
+
+	parsed, err := parseKeymap(strings.NewReader(sb.String()))
+	require.NoError(t, err)
+	assert.Equal(t, km.Sections[2], parsed.Sections[1])
+}
+
+func TestParseAndWriteKeymap_KeepsComments(t *testing.T) {
+	input := `# Your keymap
+
+'atom-workspace': # workspace
+  # open files
+  'ctrl-p': 'fuzzy-finder:toggle-file-finder' # mine
+  ###
+  'ctrl-x': 'commented:out'
+  ###
+  'ctrl-y': 'a:b'
+
+# Copy
+'body':
+  'cmd-c': 'core:copy'
+
+# end
+`
+	km, err := parseKeymap(strings.NewReader(input))
+	require.NoError(t, err)
+	var sb strings.Builder
+	require.NoError(t, km.write(&sb))
+	assert.Equal(t, input, sb.String())
+}
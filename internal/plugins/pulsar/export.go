@@ -0,0 +1,242 @@
+package pulsar
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/internal/export"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+type pulsarExporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	differ        diff.Differ
+}
+
+func newExporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	differ diff.Differ,
+) pluginapi.PluginExporter {
+	return &pulsarExporter{mappingConfig: mappingConfig, logger: logger, differ: differ}
+}
+
+// Export writes the keymap to Pulsar's keymap.cson. The user's selectors keep their order, and bindings
+// of commands not managed by onekeymap are preserved unless they bind the same keystroke under the same selector.
+func (e *pulsarExporter) Export(
+	ctx context.Context,
+	destination io.Writer,
+	setting keymap.Keymap,
+	opts pluginapi.PluginExportOption,
+) (*pluginapi.PluginExportReport, error) {
+	var existing pulsarKeymap
+	if opts.ExistingConfig != nil {
+		var err error
+		existing, err = parseKeymap(opts.ExistingConfig)
+		if err != nil {
+			e.logger.WarnContext(
+				ctx,
+				"Failed to parse existing config, proceeding with destructive export",
+				"error",
+				err,
+			)
+			existing = pulsarKeymap{}
+		}
+	}
+
+	marker := export.NewMarker(&setting)
+	managed := e.identifyManagedSections(ctx, &setting, marker)
+
+	final := pulsarKeymap{
+		Header:   existing.Header,
+		Sections: orderByBaseSelector(e.nonDestructiveMerge(ctx, managed, existing.Sections), existing.Sections),
+		Footer:   existing.Footer,
+	}
+	if err := final.write(destination); err != nil {
+		return nil, err
+	}
+
+	return &pluginapi.PluginExportReport{
+		BaseEditorConfig:   existing.Sections,
+		ExportEditorConfig: final.Sections,
+		SkipReport:         marker.Report(),
+		ExportedReport:     marker.ExportedReport(),
+	}, nil
+}
+
+// identifyManagedSections generates Pulsar bindings from KeymapSetting, grouped by selector.
+func (e *pulsarExporter) identifyManagedSections(
+	ctx context.Context,
+	setting *keymap.Keymap,
+	marker *export.Marker,
+) []pulsarSection {
+	var result pulsarKeymap
+
+	for _, km := range setting.Actions {
+		mapping, usedFallback := e.mappingConfig.GetExportAction(km.Name, pluginapi.EditorTypePulsar)
+		if mapping == nil || len(mapping.Pulsar) == 0 {
+			for _, b := range km.Bindings {
+				if len(b.KeyChords) > 0 {
+					marker.MarkSkippedForReason(km.Name, &b, pluginapi.ErrActionNotSupported)
+				}
+			}
+			continue
+		}
+
+		if usedFallback {
+			e.logger.InfoContext(ctx, "Action not directly supported, using fallback action",
+				"originalAction", km.Name,
+				"fallbackAction", mapping.ID,
+			)
+		}
+
+		for _, b := range km.Bindings {
+			if len(b.KeyChords) == 0 {
+				continue
+			}
+			keystroke, err := formatKeybinding(b)
+			if err != nil {
+				e.logger.DebugContext(ctx, "Skipping keybinding with un-formattable key", "action", km.Name, "error", err)
+				marker.MarkSkippedForReason(km.Name, &b, &pluginapi.UnsupportedExportActionError{Note: err.Error()})
+				continue
+			}
+			marker.MarkExported(km.Name, b)
+
+			for _, pc := range mapping.Pulsar {
+				if pc.Command == "" {
+					continue
+				}
+				result.section(pc.EffectiveSelector()).set(keystroke, pc.Command)
+			}
+		}
+	}
+
+	return result.Sections
+}
+
+// nonDestructiveMerge adds the existing bindings that are kept to the managed sections. Bindings of managed
+// commands are replaced, except that an exported binding the user already has under another selector stays
+// there. Other bindings, including 'unset!' directives, are kept unless the same keystroke is exported under
+// the same selector. Comments of existing selectors and bindings stay with them; a managed binding that
+// replaces an identical one keeps its comments.
+func (e *pulsarExporter) nonDestructiveMerge(ctx context.Context, managed, existing []pulsarSection) []pulsarSection {
+	kept := keptSelectors(managed, existing)
+	result := pulsarKeymap{}
+	managedKeys := make(map[string]string) // selector|keystroke -> command
+	for _, section := range managed {
+		target := result.section(section.Selector)
+		for _, b := range section.Bindings {
+			if kept[bindingKey(b)] {
+				continue
+			}
+			target.Bindings = append(target.Bindings, b)
+			managedKeys[conflictKey(section.Selector, b.Keystroke)] = b.Command
+		}
+	}
+
+	for _, section := range existing {
+		target := result.section(section.Selector)
+		target.Comments, target.Trailing = section.Comments, section.Trailing
+		for _, b := range section.Bindings {
+			if isManagedCommand(e.mappingConfig, b.Command) && !kept[bindingKey(b)] {
+				target.keepComments(b)
+				continue
+			}
+			if command, ok := managedKeys[conflictKey(section.Selector, b.Keystroke)]; ok {
+				e.logger.DebugContext(ctx, "Conflict resolved: managed keybinding takes priority",
+					"selector", section.Selector, "keystroke", b.Keystroke,
+					"managed_command", command, "unmanaged_command", b.Command)
+				continue
+			}
+			target.Bindings = append(target.Bindings, b)
+		}
+	}
+
+	sections := result.Sections[:0]
+	for _, section := range result.Sections {
+		if len(section.Bindings) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// keepComments moves the comments of an existing binding to the managed binding that replaces it, if any.
+func (s *pulsarSection) keepComments(existing pulsarBinding) {
+	for i, b := range s.Bindings {
+		if bindingKey(b) == bindingKey(existing) {
+			s.Bindings[i].Comments, s.Bindings[i].Trailing = existing.Comments, existing.Trailing
+			return
+		}
+	}
+}
+
+// keptSelectors returns the exported bindings, by bindingKey, that the existing config already has under a
+// selector other than the one they are exported under. Those keep the user's selector.
+func keptSelectors(managed, existing []pulsarSection) map[string]bool {
+	exportedUnder := make(map[string]map[string]bool) // bindingKey -> selectors
+	for _, section := range managed {
+		for _, b := range section.Bindings {
+			key := bindingKey(b)
+			if exportedUnder[key] == nil {
+				exportedUnder[key] = make(map[string]bool)
+			}
+			exportedUnder[key][section.Selector] = true
+		}
+	}
+
+	kept := make(map[string]bool)
+	for _, section := range existing {
+		for _, b := range section.Bindings {
+			key := bindingKey(b)
+			if selectors, ok := exportedUnder[key]; ok && !selectors[section.Selector] {
+				kept[key] = true
+			}
+		}
+	}
+	return kept
+}
+
+// bindingKey identifies a binding regardless of its selector.
+func bindingKey(b pulsarBinding) string {
+	return conflictKey("", b.Keystroke) + "|" + b.Command
+}
+
+// orderByBaseSelector reorders sections following the selector order of the base config. Selectors
+// not present in base come after those that do, in alphabetical order for determinism.
+func orderByBaseSelector(final, base []pulsarSection) []pulsarSection {
+	baseOrder := make(map[string]int, len(base))
+	for i, section := range base {
+		baseOrder[section.Selector] = i
+	}
+	sort.SliceStable(final, func(i, j int) bool {
+		oi, okI := baseOrder[final[i].Selector]
+		oj, okJ := baseOrder[final[j].Selector]
+		switch {
+		case okI && okJ:
+			return oi < oj
+		case okI != okJ:
+			return okI
+		default:
+			return final[i].Selector < final[j].Selector
+		}
+	})
+	return final
+}
+
+// conflictKey identifies where a binding applies. Keystrokes are normalized, so that e.g. "ctrl-P"
+// and "ctrl-shift-p" compare equal.
+func conflictKey(selector, keystroke string) string {
+	if kb, err := parseKeybinding(keystroke); err == nil {
+		if formatted, err := formatKeybinding(kb); err == nil {
+			keystroke = formatted
+		}
+	}
+	return selector + "|" + keystroke
+}
@@ -0,0 +1,216 @@
+package pulsar
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+// nolint:unparam // newAction creates a test Action with given keybindings
+func newAction(actionName string, keybindings ...string) keymap.Action {
+	var bindings []keybinding.Keybinding
+	for _, kb := range keybindings {
+		b, err := keybinding.NewKeybinding(kb, keybinding.ParseOption{Separator: "+"})
+		if err != nil {
+			panic(err)
+		}
+		bindings = append(bindings, b)
+	}
+	return keymap.Action{
+		Name:     actionName,
+		Bindings: bindings,
+	}
+}
+
+func TestExportPulsarKeymap(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		setting        keymap.Keymap
+		existingConfig string
+		want           string
+		wantSkipped    int
+	}{
+		{
+			name:    "export copy binding",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")}},
+			want: `'body':
+  'cmd-c': 'core:copy'
+`,
+		},
+		{
+			name: "new selectors are sorted",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.test.childSupported", "ctrl+k ctrl+j"),
+				newAction("actions.edit.copy", "ctrl+c"),
+			}},
+			want: `'atom-text-editor':
+  'ctrl-k ctrl-j': 'test:child-supported'
+
+'body':
+  'ctrl-c': 'core:copy'
+`,
+		},
+		{
+			name: "falls back to fallback action when parent not supported",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.test.parentNotSupported", "ctrl+shift+h"),
+			}},
+			want: `'atom-text-editor':
+  'ctrl-shift-h': 'test:child-supported'
+`,
+		},
+		{
+			name: "unsupported actions and keys are skipped",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.test.withArgs", "ctrl+j"),
+				newAction("actions.edit.copy", "ctrl+numpad1", "ctrl+c"),
+			}},
+			want: `'body':
+  'ctrl-c': 'core:copy'
+`,
+			wantSkipped: 2,
+		},
+		{
+			name: "comments of the user's selectors and bindings are kept",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.edit.copy", "meta+c"),
+			}},
+			existingConfig: `# Your keymap
+
+'atom-workspace': # workspace
+  # open files
+  'ctrl-p': 'fuzzy-finder:toggle-file-finder' # mine
+
+# Copy
+'body':
+  # copy the selection
+  'cmd-c': 'core:copy' # kept
+  # old copy
+  'ctrl-insert': 'core:copy'
+  'ctrl-t': 'unset!'
+
+# end
+`,
+			want: `# Your keymap
+
+'atom-workspace': # workspace
+  # open files
+  'ctrl-p': 'fuzzy-finder:toggle-file-finder' # mine
+
+# Copy
+'body':
+  # copy the selection
+  'cmd-c': 'core:copy' # kept
+  'ctrl-t': 'unset!'
+
+# end
+`,
+		},
+		{
+			name: "non-destructive export keeps the user's selectors, their order and the header",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.edit.copy", "meta+c"),
+				newAction("actions.test.childSupported", "ctrl+alt+j"),
+			}},
+			existingConfig: `# Your keymap
+#
+# Pulsar keymaps work similarly to style sheets.
+
+'atom-workspace atom-text-editor[data-grammar~="go"]':
+  'ctrl-alt-g': 'go-plus:run'
+
+'body':
+  'ctrl-insert': 'core:copy'
+  'cmd-C': 'custom:copy-path'
+  'ctrl-t': 'unset!'
+`,
+			want: `# Your keymap
+#
+# Pulsar keymaps work similarly to style sheets.
+
+'atom-workspace atom-text-editor[data-grammar~="go"]':
+  'ctrl-alt-g': 'go-plus:run'
+
+'body':
+  'cmd-c': 'core:copy'
+  'cmd-C': 'custom:copy-path'
+  'ctrl-t': 'unset!'
+
+'atom-text-editor':
+  'ctrl-alt-j': 'test:child-supported'
+`,
+		},
+		{
+			name:    "re-exported binding keeps the user's selector",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "ctrl+c", "meta+c")}},
+			existingConfig: `'atom-text-editor.vim-mode-plus':
+  'ctrl-c': 'core:copy'
+`,
+			want: `'atom-text-editor.vim-mode-plus':
+  'ctrl-c': 'core:copy'
+
+'body':
+  'cmd-c': 'core:copy'
+`,
+		},
+		{
+			name:    "managed binding takes priority over conflicting user binding",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "meta+shift+c")}},
+			existingConfig: `'body':
+  'cmd-C': 'custom:copy-path'
+  'cmd-k': 'custom:other'
+
+'atom-text-editor':
+  'cmd-shift-c': 'custom:kept'
+`,
+			want: `'body':
+  'shift-cmd-c': 'core:copy'
+  'cmd-k': 'custom:other'
+
+'atom-text-editor':
+  'cmd-shift-c': 'custom:kept'
+`,
+		},
+		{
+			name:           "unparsable existing config is replaced",
+			setting:        keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "ctrl+c")}},
+			existingConfig: "'body': {'ctrl-k': 'x'}\n",
+			want: `'body':
+  'ctrl-c': 'core:copy'
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			exporter, err := p.Exporter()
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			opts := pluginapi.PluginExportOption{}
+			if tt.existingConfig != "" {
+				opts.ExistingConfig = strings.NewReader(tt.existingConfig)
+			}
+
+			report, err := exporter.Export(context.Background(), &buf, tt.setting, opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+			assert.Len(t, report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package pulsar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/internal/dedup"
+	"github.com/xinnjie/onekeymap-cli/internal/imports"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+type pulsarImporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	reporter      *metrics.UnknownActionReporter
+}
+
+func newImporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) *pulsarImporter {
+	return &pulsarImporter{
+		mappingConfig: mappingConfig,
+		logger:        logger,
+		reporter:      metrics.NewUnknownActionReporter(recorder),
+	}
+}
+
+// Import reads Pulsar's keymap.cson and converts its bindings into the universal KeymapSetting format.
+// Directives such as 'unset!' and 'native!' do not bind a command, so there is nothing to import from them.
+func (i *pulsarImporter) Import(
+	ctx context.Context,
+	source io.Reader,
+	_ pluginapi.PluginImportOption,
+) (pluginapi.PluginImportResult, error) {
+	km, err := parseKeymap(source)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, err
+	}
+
+	setting := keymap.Keymap{}
+	marker := imports.NewMarker()
+	for _, section := range km.Sections {
+		for _, b := range section.Bindings {
+			if b.Command == "" || strings.HasSuffix(b.Command, "!") {
+				continue
+			}
+			kb, err := parseKeybinding(b.Keystroke)
+			if err != nil {
+				i.logger.DebugContext(ctx, "failed to parse pulsar keystroke", "keystroke", b.Keystroke, "error", err)
+				marker.MarkSkipped(b.Command, nil, fmt.Errorf("failed to parse keystroke '%s': %w", b.Keystroke, err))
+				continue
+			}
+
+			mapping := findMappingByPulsar(i.mappingConfig, b.Command, section.Selector)
+			if mapping == nil {
+				i.logger.DebugContext(ctx, "failed to find action", "command", b.Command, "selector", section.Selector)
+				i.reporter.ReportUnknownCommand(ctx, pluginapi.EditorTypePulsar, b.Command)
+				marker.MarkSkipped(b.Command, &kb, pluginapi.ErrActionNotSupported)
+				continue
+			}
+
+			setting.Actions = append(setting.Actions, keymap.Action{
+				Name:     mapping.ID,
+				Bindings: []keybinding.Keybinding{kb},
+			})
+			marker.MarkImported(mapping.ID, b.Command, kb, kb)
+		}
+	}
+
+	setting.Actions = dedup.Actions(setting.Actions)
+	result := pluginapi.PluginImportResult{Keymap: setting}
+	result.Report.SkipReport = marker.Report()
+	result.Report.ImportedReport = marker.ImportedReport()
+	return result, nil
+}
@@ -0,0 +1,108 @@
+package pulsar
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func TestImportPulsarKeymap(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    []keymap.Action
+		wantSkipped int
+		wantErr     bool
+	}{
+		{
+			name: "simple binding",
+			input: `'body':
+  'cmd-c': 'core:copy'
+`,
+			expected: []keymap.Action{newAction("actions.edit.copy", "meta+c")},
+		},
+		{
+			name: "hand-written selectors still match the command",
+			input: `'atom-text-editor':
+  'ctrl-K': 'test:child-supported'
+
+'atom-workspace atom-text-editor:not([mini])':
+  'ctrl-k ctrl-j': 'test:child-supported'
+`,
+			expected: []keymap.Action{newAction("actions.test.childSupported", "ctrl+shift+k", "ctrl+k ctrl+j")},
+		},
+		{
+			name: "directives are ignored",
+			input: `'body':
+  'ctrl-c': 'unset!'
+  'ctrl-v': 'native!'
+`,
+		},
+		{
+			name: "unknown commands and unparsable keystrokes are skipped",
+			input: `'atom-workspace':
+  'ctrl-j': 'unknown:command'
+  'ctrl-numpad1': 'test:child-supported'
+  'ctrl-c': 'core:copy'
+`,
+			expected:    []keymap.Action{newAction("actions.edit.copy", "ctrl+c")},
+			wantSkipped: 2,
+		},
+		{
+			name:    "invalid cson",
+			input:   "'body':\n  'ctrl-c': core:copy\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			importer, err := p.Importer()
+			require.NoError(t, err)
+
+			result, err := importer.Import(context.Background(), strings.NewReader(tt.input), pluginapi.PluginImportOption{})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, result.Keymap.Actions)
+			assert.Len(t, result.Report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "meta+c"),
+		newAction("actions.test.childSupported", "ctrl+k ctrl+shift+f5"),
+	}}
+	var buf strings.Builder
+	_, err = exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	result, err := importer.Import(context.Background(), strings.NewReader(buf.String()), pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, setting.Actions, result.Keymap.Actions)
+}
@@ -0,0 +1,128 @@
+package pulsar
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/xinnjie/onekeymap-cli/internal/bimap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keychord"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keycode"
+)
+
+const (
+	pulsarKeyStrokeSeparator = "-"
+	pulsarKeySequenceSep     = " "
+)
+
+//nolint:gochecknoglobals // static lookup tables shared across parse/format functions; initialized once and read-only
+var (
+	// cmd is ⌘ on macOS; Pulsar does not bind the Super/Windows key elsewhere.
+	pulsarModifierMapping = bimap.NewBiMapFromMap(map[string]keycode.KeyModifier{
+		"ctrl":  keycode.KeyModifierCtrl,
+		"alt":   keycode.KeyModifierAlt,
+		"shift": keycode.KeyModifierShift,
+		"cmd":   keycode.KeyModifierMeta,
+	})
+
+	// pulsarModifierOrder is the order atom-keymap normalizes keystrokes to.
+	pulsarModifierOrder = []keycode.KeyModifier{
+		keycode.KeyModifierCtrl, keycode.KeyModifierAlt, keycode.KeyModifierShift, keycode.KeyModifierMeta,
+	}
+
+	// pulsarUnsupportedKeys cannot be bound in Pulsar. Other key names match ours, e.g. "pageup" or "escape".
+	pulsarUnsupportedKeys = []keycode.KeyCode{
+		keycode.KeyCodeCapsLock, keycode.KeyCodeFn, keycode.KeyCodeShift, keycode.KeyCodeCtrl, keycode.KeyCodeAlt,
+		keycode.KeyCodeCmd, keycode.KeyCodeRightCmd, keycode.KeyCodeRightAlt, keycode.KeyCodeRightCtrl,
+		keycode.KeyCodeRightShift, keycode.KeyCodeMute, keycode.KeyCodeVolumeUp, keycode.KeyCodeVolumeDown,
+	}
+)
+
+// formatKeybinding formats a keybinding as a Pulsar keystroke, e.g. "ctrl-shift-p" or "ctrl-k ctrl-c".
+// Pulsar matches the characters a key produces, so numpad keys cannot be told apart from the main keyboard.
+func formatKeybinding(kb keybinding.Keybinding) (string, error) {
+	strokes := make([]string, 0, len(kb.KeyChords))
+	for _, chord := range kb.KeyChords {
+		if chord.KeyCode == "" || chord.KeyCode.IsNumpad() || slices.Contains(pulsarUnsupportedKeys, chord.KeyCode) {
+			return "", fmt.Errorf("%w: %q", ErrNotSupportKeyChords, chord.String(keychord.FormatOption{
+				Separator: pulsarKeyStrokeSeparator,
+			}))
+		}
+
+		var parts []string
+		for _, mod := range pulsarModifierOrder {
+			if slices.Contains(chord.Modifiers, mod) {
+				name, _ := pulsarModifierMapping.GetInverse(mod)
+				parts = append(parts, name)
+			}
+		}
+
+		parts = append(parts, string(chord.KeyCode))
+		strokes = append(strokes, strings.Join(parts, pulsarKeyStrokeSeparator))
+	}
+	return strings.Join(strokes, pulsarKeySequenceSep), nil
+}
+
+// parseKeybinding parses a Pulsar keystroke sequence such as "ctrl-shift-p", "ctrl-P" or "ctrl-k ctrl-c".
+func parseKeybinding(keystrokes string) (keybinding.Keybinding, error) {
+	strokes := strings.Fields(keystrokes)
+	if len(strokes) == 0 {
+		return keybinding.Keybinding{}, errors.New("cannot parse empty keystroke")
+	}
+
+	chords := make([]keychord.KeyChord, 0, len(strokes))
+	for _, s := range strokes {
+		chord, err := parseKeyStroke(s)
+		if err != nil {
+			return keybinding.Keybinding{}, err
+		}
+		chords = append(chords, chord)
+	}
+	return keybinding.Keybinding{KeyChords: chords}, nil
+}
+
+// parseKeyStroke parses a single keystroke like "ctrl-shift-p" or "ctrl--". The key is whatever follows
+// the last '-', and an empty key means the '-' key itself. An upper-case letter implies shift.
+func parseKeyStroke(s string) (keychord.KeyChord, error) {
+	if strings.HasPrefix(s, "^") {
+		return keychord.KeyChord{}, fmt.Errorf("%w: keyup keystrokes are not supported: %q", ErrNotSupportKeyChords, s)
+	}
+	modifiers, key := "", s
+	if idx := strings.LastIndex(s, pulsarKeyStrokeSeparator); idx >= 0 {
+		modifiers, key = s[:idx], s[idx+1:]
+	}
+	if key == "" {
+		key = pulsarKeyStrokeSeparator
+		modifiers = strings.TrimSuffix(modifiers, pulsarKeyStrokeSeparator)
+	}
+
+	chord := keychord.KeyChord{}
+	if modifiers != "" {
+		for _, part := range strings.Split(modifiers, pulsarKeyStrokeSeparator) {
+			mod, ok := pulsarModifierMapping.Get(strings.ToLower(part))
+			if !ok {
+				return keychord.KeyChord{}, fmt.Errorf("unknown pulsar modifier %q in %q", part, s)
+			}
+			if !slices.Contains(chord.Modifiers, mod) {
+				chord.Modifiers = append(chord.Modifiers, mod)
+			}
+		}
+	}
+
+	if r := []rune(key); len(r) == 1 && unicode.IsUpper(r[0]) {
+		if !slices.Contains(chord.Modifiers, keycode.KeyModifierShift) {
+			chord.Modifiers = append(chord.Modifiers, keycode.KeyModifierShift)
+		}
+		key = strings.ToLower(key)
+	}
+
+	kc := keycode.KeyCode(key)
+	if !kc.IsValid() || kc.IsNumpad() || slices.Contains(pulsarUnsupportedKeys, kc) {
+		return keychord.KeyChord{}, fmt.Errorf("unsupported pulsar key: %q", s)
+	}
+	chord.KeyCode = kc
+	return chord, nil
+}
@@ -0,0 +1,70 @@
+package pulsar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+)
+
+func TestFormatKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "Simple", in: "ctrl+k", want: "ctrl-k"},
+		{name: "ModifierOrder", in: "meta+shift+alt+ctrl+p", want: "ctrl-alt-shift-cmd-p"},
+		{name: "MultiChord", in: "ctrl+k ctrl+c", want: "ctrl-k ctrl-c"},
+		{name: "Minus", in: "ctrl+-", want: "ctrl--"},
+		{name: "Special", in: "shift+pageup", want: "shift-pageup"},
+		{name: "Numpad", in: "ctrl+numpad0", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := keybinding.NewKeybinding(tc.in, keybinding.ParseOption{Separator: "+"})
+			require.NoError(t, err)
+			out, err := formatKeybinding(kb)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestParseKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "Simple", in: "ctrl-k", want: "ctrl+k"},
+		{name: "UppercaseImpliesShift", in: "ctrl-P", want: "ctrl+shift+p"},
+		{name: "ExplicitShiftAndUppercase", in: "ctrl-shift-P", want: "ctrl+shift+p"},
+		{name: "MultiChord", in: "ctrl-k  ctrl-c", want: "ctrl+k ctrl+c"},
+		{name: "Minus", in: "ctrl--", want: "ctrl+-"},
+		{name: "Cmd", in: "cmd-shift-d", want: "meta+shift+d"},
+		{name: "Keyup", in: "^ctrl", wantErr: true},
+		{name: "UnknownModifier", in: "hyper-k", wantErr: true},
+		{name: "UnknownKey", in: "ctrl-mouse1", wantErr: true},
+		{name: "Empty", in: " ", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := parseKeybinding(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, kb.String(keybinding.FormatOption{Separator: "+"}))
+		})
+	}
+}
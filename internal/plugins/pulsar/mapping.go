@@ -0,0 +1,53 @@
+package pulsar
+
+import (
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+// findMappingByPulsar finds the action for a Pulsar command bound under selector. Hand-written keymaps
+// use many equivalent selectors, so configs whose selector matches exactly are preferred over those
+// that only share the command; ties are broken by action ID to keep the result deterministic.
+func findMappingByPulsar(
+	mappingConfig *mappings.MappingConfig,
+	command, selector string,
+) *mappings.ActionMappingConfig {
+	var exact, commandOnly []string
+	for id, mapping := range mappingConfig.Mappings {
+		for _, pc := range mapping.Pulsar {
+			if pc.DisableImport || pc.Command == "" || pc.Command != command {
+				continue
+			}
+			if pc.EffectiveSelector() == selector {
+				exact = append(exact, id)
+			} else {
+				commandOnly = append(commandOnly, id)
+			}
+		}
+	}
+
+	candidates := exact
+	if len(candidates) == 0 {
+		candidates = commandOnly
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Strings(candidates)
+	m := mappingConfig.Mappings[candidates[0]]
+	return &m
+}
+
+// isManagedCommand reports whether a Pulsar command corresponds to one of our action mappings,
+// including export-only ones.
+func isManagedCommand(mappingConfig *mappings.MappingConfig, command string) bool {
+	for _, mapping := range mappingConfig.Mappings {
+		for _, pc := range mapping.Pulsar {
+			if pc.Command != "" && pc.Command == command {
+				return true
+			}
+		}
+	}
+	return false
+}
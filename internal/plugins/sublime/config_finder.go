@@ -0,0 +1,78 @@
+package sublime
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+)
+
+// KeymapFileName returns the user keymap file name Sublime Text loads on the given platform,
+// e.g. "Default (OSX).sublime-keymap". If plat is empty, it defaults to the current runtime platform.
+func KeymapFileName(plat platform.Platform) string {
+	if plat == "" {
+		plat = platform.Current()
+	}
+	switch plat {
+	case platform.PlatformMacOS:
+		return "Default (OSX).sublime-keymap"
+	case platform.PlatformWindows:
+		return "Default (Windows).sublime-keymap"
+	default:
+		return "Default (Linux).sublime-keymap"
+	}
+}
+
+// ConfigDetect returns the path of the user keymap inside Sublime Text's Packages/User directory.
+// The directory is chosen from the runtime OS (preferring an existing Sublime Text 3 profile only when
+// Sublime Text 4's is absent), while the file name follows opts.Platform, so a keymap for another
+// platform can be written into a synced Packages/User directory.
+func (p *sublimePlugin) ConfigDetect(opts pluginapi.ConfigDetectOptions) (paths []string, installed bool, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var candidates []string
+	switch runtime.GOOS {
+	case "darwin":
+		base := filepath.Join(home, "Library", "Application Support")
+		candidates = []string{filepath.Join(base, "Sublime Text"), filepath.Join(base, "Sublime Text 3")}
+	case "linux":
+		base := filepath.Join(home, ".config")
+		candidates = []string{filepath.Join(base, "sublime-text"), filepath.Join(base, "sublime-text-3")}
+	case "windows":
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			return nil, false, fmt.Errorf("APPDATA environment variable not set, %w", pluginapi.ErrNotSupported)
+		}
+		candidates = []string{filepath.Join(appData, "Sublime Text"), filepath.Join(appData, "Sublime Text 3")}
+	default:
+		return nil, false, fmt.Errorf(
+			"automatic path discovery is only supported on macOS, Linux, and Windows, %w",
+			pluginapi.ErrNotSupported,
+		)
+	}
+
+	dataDir := candidates[0]
+	for _, c := range candidates {
+		if _, statErr := os.Stat(filepath.Join(c, "Packages", "User")); statErr == nil {
+			dataDir = c
+			break
+		}
+	}
+	configPath := filepath.Join(dataDir, "Packages", "User", KeymapFileName(opts.Platform))
+
+	if opts.Sandbox {
+		installed = false
+	} else {
+		_, err = exec.LookPath("subl")
+		installed = err == nil
+	}
+
+	return []string{configPath}, installed, nil
+}
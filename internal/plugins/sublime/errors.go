@@ -0,0 +1,5 @@
+package sublime
+
+import "errors"
+
+var ErrNotSupportKeyChords = errors.New("key chord is not supported by sublime text")
@@ -0,0 +1,194 @@
+package sublime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/internal/export"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+var _ pluginapi.PluginExporter = (*sublimeExporter)(nil)
+
+type sublimeExporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	differ        diff.Differ
+}
+
+func newExporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	differ diff.Differ,
+) pluginapi.PluginExporter {
+	return &sublimeExporter{mappingConfig: mappingConfig, logger: logger, differ: differ}
+}
+
+// Export writes the keymap as a .sublime-keymap file, keeping keybindings of the
+// existing file that are not managed by onekeymap.
+func (e *sublimeExporter) Export(
+	ctx context.Context,
+	destination io.Writer,
+	setting keymap.Keymap,
+	opts pluginapi.PluginExportOption,
+) (*pluginapi.PluginExportReport, error) {
+	existingKeybindings, err := parseConfig(opts.ExistingConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmanagedKeybindings []sublimeKeybinding
+	for _, kb := range existingKeybindings {
+		if !isManagedKeybinding(e.mappingConfig, kb) {
+			unmanagedKeybindings = append(unmanagedKeybindings, kb)
+		}
+	}
+
+	marker := export.NewMarker(&setting)
+	managedKeybindings := e.identifyManagedKeybindings(ctx, &setting, marker)
+
+	finalKeybindings := e.nonDestructiveMerge(ctx, managedKeybindings, unmanagedKeybindings, opts.TargetPlatform)
+	finalKeybindings = orderByBaseCommand(finalKeybindings, existingKeybindings)
+
+	// Write JSON without HTML escaping so that '&&', '<', '>' remain as-is
+	enc := json.NewEncoder(destination)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(finalKeybindings); err != nil {
+		return nil, fmt.Errorf("failed to encode sublime keymap to json: %w", err)
+	}
+
+	return &pluginapi.PluginExportReport{
+		BaseEditorConfig:   existingKeybindings,
+		ExportEditorConfig: finalKeybindings,
+		SkipReport:         marker.Report(),
+		ExportedReport:     marker.ExportedReport(),
+	}, nil
+}
+
+// identifyManagedKeybindings generates Sublime Text keybindings from KeymapSetting.
+func (e *sublimeExporter) identifyManagedKeybindings(
+	ctx context.Context,
+	setting *keymap.Keymap,
+	marker *export.Marker,
+) []sublimeKeybinding {
+	result := make([]sublimeKeybinding, 0, len(setting.Actions))
+
+	for _, km := range setting.Actions {
+		mapping, usedFallback := e.mappingConfig.GetExportAction(km.Name, pluginapi.EditorTypeSublime)
+		if mapping == nil {
+			for _, b := range km.Bindings {
+				if len(b.KeyChords) > 0 {
+					marker.MarkSkippedForReason(km.Name, &b, pluginapi.ErrActionNotSupported)
+				}
+			}
+			continue
+		}
+
+		if usedFallback {
+			e.logger.InfoContext(ctx, "Action not directly supported, using fallback action",
+				"originalAction", km.Name,
+				"fallbackAction", mapping.ID,
+			)
+		}
+
+		for _, b := range km.Bindings {
+			if len(b.KeyChords) == 0 {
+				continue
+			}
+			keys, err := formatKeybinding(b)
+			if err != nil {
+				e.logger.DebugContext(ctx, "Skipping keybinding with un-formattable key", "action", km.Name, "error", err)
+				marker.MarkSkippedForReason(km.Name, &b, &pluginapi.UnsupportedExportActionError{Note: err.Error()})
+				continue
+			}
+			marker.MarkExported(km.Name, b)
+			for _, sc := range mapping.Sublime {
+				if sc.Command == "" {
+					continue
+				}
+				result = append(result, sublimeKeybinding{
+					Keys:    keys,
+					Command: sc.Command,
+					Args:    sc.Args,
+					Context: sc.Context,
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// nonDestructiveMerge merges managed and unmanaged keybindings, with managed taking priority.
+// Two keybindings conflict when they bind the same keys under the same context; unmanaged keys
+// are normalized for targetPlatform first so that e.g. "primary+c" matches a managed "ctrl+c".
+func (e *sublimeExporter) nonDestructiveMerge(
+	ctx context.Context,
+	managed, unmanaged []sublimeKeybinding,
+	targetPlatform platform.Platform,
+) []sublimeKeybinding {
+	managedKeys := make(map[string]bool, len(managed))
+	for _, kb := range managed {
+		managedKeys[conflictKey(kb.Keys, kb.Context)] = true
+	}
+
+	result := make([]sublimeKeybinding, 0, len(managed)+len(unmanaged))
+	result = append(result, managed...)
+
+	for _, kb := range unmanaged {
+		keys := kb.Keys
+		if parsed, err := parseKeybinding(kb.Keys, targetPlatform); err == nil {
+			if formatted, err := formatKeybinding(parsed); err == nil {
+				keys = formatted
+			}
+		}
+		if managedKeys[conflictKey(keys, kb.Context)] {
+			e.logger.DebugContext(ctx, "Conflict resolved: managed keybinding takes priority",
+				"keys", kb.Keys, "unmanaged_command", kb.Command)
+			continue
+		}
+		result = append(result, kb)
+	}
+
+	return result
+}
+
+func conflictKey(keys []string, context []map[string]any) string {
+	var contextStr string
+	if len(context) > 0 {
+		b, _ := json.Marshal(context)
+		contextStr = string(b)
+	}
+	return strings.Join(keys, ",") + "|" + contextStr
+}
+
+// orderByBaseCommand reorders exported keybindings following the order of commands
+// present in the base config. Items whose command is not present in base keep
+// their original relative order.
+func orderByBaseCommand(final, base []sublimeKeybinding) []sublimeKeybinding {
+	if len(final) == 0 || len(base) == 0 {
+		return final
+	}
+	baseOrder := make(map[string]int, len(base))
+	for _, kb := range base {
+		if _, ok := baseOrder[kb.Command]; !ok && kb.Command != "" {
+			baseOrder[kb.Command] = len(baseOrder)
+		}
+	}
+	sort.SliceStable(final, func(i, j int) bool {
+		oi, okI := baseOrder[final[i].Command]
+		oj, okJ := baseOrder[final[j].Command]
+		return okI && okJ && oi < oj
+	})
+	return final
+}
@@ -0,0 +1,130 @@
+package sublime
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func newAction(actionName string, keybindings ...string) keymap.Action {
+	var bindings []keybinding.Keybinding
+	for _, kb := range keybindings {
+		b, err := keybinding.NewKeybinding(kb, keybinding.ParseOption{Separator: "+"})
+		if err != nil {
+			panic(err)
+		}
+		bindings = append(bindings, b)
+	}
+	return keymap.Action{
+		Name:     actionName,
+		Bindings: bindings,
+	}
+}
+
+func TestExportSublimeKeymap(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		setting        keymap.Keymap
+		existingConfig string
+		targetPlatform platform.Platform
+		expectedJSON   string
+		wantSkipped    int
+	}{
+		{
+			name:    "exports a simple action",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")}},
+			expectedJSON: `[
+				{"keys": ["super+c"], "command": "copy"}
+			]`,
+		},
+		{
+			name:    "exports args, context and key chords",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.test.mutipleActions", "ctrl+k ctrl+m")}},
+			expectedJSON: `[
+				{"keys": ["ctrl+k", "ctrl+m"], "command": "command1"},
+				{"keys": ["ctrl+k", "ctrl+m"], "command": "command2", "context": [{"key": "context2"}]}
+			]`,
+		},
+		{
+			name: "uses fallback action and skips unsupported ones",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.test.parentNotSupported", "alt+f1"),
+				newAction("actions.unknown.action", "alt+f2"),
+				newAction("actions.edit.copy", "ctrl+capslock"),
+			}},
+			expectedJSON: `[
+				{"keys": ["alt+f1"], "command": "child_supported_command"}
+			]`,
+			wantSkipped: 2,
+		},
+		{
+			name:    "non-destructive merge keeps unmanaged keybindings",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "ctrl+c")}},
+			existingConfig: `[
+				// user bindings
+				{ "keys": ["ctrl+shift+t"], "command": "reopen_last_file" },
+				{ "keys": ["primary+c"], "command": "my_copy" },
+				{ "keys": ["ctrl+c"], "command": "my_copy", "context": [{ "key": "panel_has_focus" }] },
+				{ "keys": ["super+c"], "command": "copy" },
+			]`,
+			targetPlatform: platform.PlatformLinux,
+			// Entries keep the command order of the existing file
+			expectedJSON: `[
+				{"keys": ["ctrl+shift+t"], "command": "reopen_last_file"},
+				{"keys": ["ctrl+c"], "command": "my_copy", "context": [{"key": "panel_has_focus"}]},
+				{"keys": ["ctrl+c"], "command": "copy"}
+			]`,
+		},
+		{
+			name:    "primary is resolved for the target platform",
+			setting: keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "ctrl+c")}},
+			existingConfig: `[
+				{ "keys": ["primary+c"], "command": "my_copy" }
+			]`,
+			targetPlatform: platform.PlatformMacOS,
+			expectedJSON: `[
+				{"keys": ["ctrl+c"], "command": "copy"},
+				{"keys": ["primary+c"], "command": "my_copy"}
+			]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			exporter, err := p.Exporter()
+			require.NoError(t, err)
+
+			opts := pluginapi.PluginExportOption{TargetPlatform: tt.targetPlatform}
+			if tt.existingConfig != "" {
+				opts.ExistingConfig = strings.NewReader(tt.existingConfig)
+			}
+			var buf bytes.Buffer
+			report, err := exporter.Export(context.Background(), &buf, tt.setting, opts)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectedJSON, buf.String())
+			assert.Len(t, report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestKeymapFileName(t *testing.T) {
+	assert.Equal(t, "Default (OSX).sublime-keymap", KeymapFileName(platform.PlatformMacOS))
+	assert.Equal(t, "Default (Windows).sublime-keymap", KeymapFileName(platform.PlatformWindows))
+	assert.Equal(t, "Default (Linux).sublime-keymap", KeymapFileName(platform.PlatformLinux))
+}
@@ -0,0 +1,81 @@
+package sublime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/dedup"
+	"github.com/xinnjie/onekeymap-cli/internal/imports"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+type sublimeImporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	reporter      *metrics.UnknownActionReporter
+}
+
+func newImporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) *sublimeImporter {
+	return &sublimeImporter{
+		mappingConfig: mappingConfig,
+		logger:        logger,
+		reporter:      metrics.NewUnknownActionReporter(recorder),
+	}
+}
+
+// Import reads a .sublime-keymap file and converts it to a universal KeymapSetting.
+func (i *sublimeImporter) Import(
+	ctx context.Context,
+	source io.Reader,
+	opts pluginapi.PluginImportOption,
+) (pluginapi.PluginImportResult, error) {
+	sublimeKeybindings, err := parseConfig(source)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, fmt.Errorf("failed to parse sublime keymap: %w", err)
+	}
+
+	setting := keymap.Keymap{}
+	marker := imports.NewMarker()
+	for _, binding := range sublimeKeybindings {
+		kb, err := parseKeybinding(binding.Keys, opts.SourcePlatform)
+		if err != nil {
+			i.logger.WarnContext(ctx, "Skipping keybinding with unparsable keys", "keys", binding.Keys, "error", err)
+			marker.MarkSkipped(binding.Command, nil, fmt.Errorf("unparsable keys %q: %w", binding.Keys, err))
+			continue
+		}
+
+		mapping := findMappingBySublime(i.mappingConfig, binding.Command, binding.Args, binding.Context)
+		if mapping == nil {
+			i.logger.DebugContext(ctx, "Skipping keybinding with unknown command",
+				"command", binding.Command,
+				"args", binding.Args,
+			)
+			i.reporter.ReportUnknownCommand(ctx, pluginapi.EditorTypeSublime, binding.Command)
+			marker.MarkSkipped(binding.Command, &kb, pluginapi.ErrActionNotSupported)
+			continue
+		}
+
+		setting.Actions = append(setting.Actions, keymap.Action{
+			Name:     mapping.ID,
+			Bindings: []keybinding.Keybinding{kb},
+		})
+		marker.MarkImported(mapping.ID, binding.Command, kb, kb)
+	}
+
+	setting.Actions = dedup.Actions(setting.Actions)
+
+	result := pluginapi.PluginImportResult{Keymap: setting}
+	result.Report.SkipReport = marker.Report()
+	result.Report.ImportedReport = marker.ImportedReport()
+	return result, nil
+}
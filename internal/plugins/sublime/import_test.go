@@ -0,0 +1,118 @@
+package sublime
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func TestImportSublimeKeymap(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		input          string
+		sourcePlatform platform.Platform
+		expected       []keymap.Action
+		wantSkipped    int
+		wantErr        bool
+	}{
+		{
+			name:     "simple binding",
+			input:    `[{ "keys": ["super+c"], "command": "copy" }]`,
+			expected: []keymap.Action{newAction("actions.edit.copy", "meta+c")},
+		},
+		{
+			name:           "primary modifier follows the source platform",
+			input:          `[{ "keys": ["primary+c"], "command": "copy" }]`,
+			sourcePlatform: platform.PlatformWindows,
+			expected:       []keymap.Action{newAction("actions.edit.copy", "ctrl+c")},
+		},
+		{
+			name: "args must match, context is preferred but optional",
+			input: `[
+				{ "keys": ["alt+e"], "command": "move_to", "args": { "extend": false, "to": "eol" } },
+				{ "keys": ["ctrl+k", "ctrl+m"], "command": "command2", "context": [{ "key": "other" }] },
+			]`,
+			expected: []keymap.Action{
+				newAction("actions.test.withArgs", "alt+e"),
+				newAction("actions.test.mutipleActions", "ctrl+k ctrl+m"),
+			},
+		},
+		{
+			name:        "args mismatch is skipped",
+			input:       `[{ "keys": ["alt+x"], "command": "move_to", "args": { "to": "bol" } }]`,
+			wantSkipped: 1,
+		},
+		{
+			name: "unknown commands and unparsable keys are skipped",
+			input: `[
+				{ "keys": ["ctrl+alt+n"], "command": "unknown_command" },
+				{ "keys": ["hyper+c"], "command": "child_supported_command" },
+				{ "keys": ["ctrl+c"], "command": "copy" }
+			]`,
+			expected:    []keymap.Action{newAction("actions.edit.copy", "ctrl+c")},
+			wantSkipped: 2,
+		},
+		{
+			name:    "invalid json",
+			input:   `[{ "keys": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			importer, err := p.Importer()
+			require.NoError(t, err)
+
+			result, err := importer.Import(
+				context.Background(),
+				strings.NewReader(tt.input),
+				pluginapi.PluginImportOption{SourcePlatform: tt.sourcePlatform},
+			)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, result.Keymap.Actions)
+			assert.Len(t, result.Report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "meta+c"),
+		newAction("actions.test.withArgs", "ctrl+alt+shift+f5"),
+		newAction("actions.test.childSupported", "ctrl+k ctrl+numpad1"),
+	}}
+	var buf strings.Builder
+	_, err = exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	result, err := importer.Import(context.Background(), strings.NewReader(buf.String()), pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, setting.Actions, result.Keymap.Actions)
+}
@@ -0,0 +1,190 @@
+package sublime
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/xinnjie/onekeymap-cli/internal/bimap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keychord"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keycode"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+const sublimeKeyChordSeparator = "+"
+
+//nolint:gochecknoglobals // static lookup tables shared across parse/format functions; initialized once and read-only
+var (
+	// super is Command(⌘) on macOS and the Windows/Super key elsewhere, i.e. our meta modifier.
+	sublimeModifierMapping = bimap.NewBiMapFromMap(map[string]keycode.KeyModifier{
+		"ctrl":  keycode.KeyModifierCtrl,
+		"alt":   keycode.KeyModifierAlt,
+		"shift": keycode.KeyModifierShift,
+		"super": keycode.KeyModifierMeta,
+	})
+
+	// sublimeModifierAliases are accepted on import but never written.
+	sublimeModifierAliases = map[string]keycode.KeyModifier{
+		"cmd":     keycode.KeyModifierMeta,
+		"command": keycode.KeyModifierMeta,
+		"option":  keycode.KeyModifierAlt,
+	}
+
+	// sublimeKeyMapping lists keys whose Sublime Text name differs from ours;
+	// all other keys (letters, digits, punctuation, arrows, f1-f20, ...) share the same name.
+	sublimeKeyMapping = bimap.NewBiMapFromMap(map[string]keycode.KeyCode{
+		"keypad0":         keycode.KeyCodeNumpad0,
+		"keypad1":         keycode.KeyCodeNumpad1,
+		"keypad2":         keycode.KeyCodeNumpad2,
+		"keypad3":         keycode.KeyCodeNumpad3,
+		"keypad4":         keycode.KeyCodeNumpad4,
+		"keypad5":         keycode.KeyCodeNumpad5,
+		"keypad6":         keycode.KeyCodeNumpad6,
+		"keypad7":         keycode.KeyCodeNumpad7,
+		"keypad8":         keycode.KeyCodeNumpad8,
+		"keypad9":         keycode.KeyCodeNumpad9,
+		"keypad_period":   keycode.KeyCodeNumpadDecimal,
+		"keypad_divide":   keycode.KeyCodeNumpadDivide,
+		"keypad_multiply": keycode.KeyCodeNumpadMultiply,
+		"keypad_minus":    keycode.KeyCodeNumpadSubtract,
+		"keypad_plus":     keycode.KeyCodeNumpadAdd,
+		"keypad_enter":    keycode.KeyCodeNumpadEnter,
+		"clear":           keycode.KeyCodeNumpadClear,
+	})
+
+	// sublimeUnsupportedKeys have no Sublime Text key name.
+	sublimeUnsupportedKeys = []keycode.KeyCode{
+		keycode.KeyCodeCapsLock, keycode.KeyCodeFn, keycode.KeyCodeShift, keycode.KeyCodeCtrl, keycode.KeyCodeAlt,
+		keycode.KeyCodeCmd, keycode.KeyCodeRightCmd, keycode.KeyCodeRightAlt, keycode.KeyCodeRightCtrl,
+		keycode.KeyCodeRightShift, keycode.KeyCodeMute, keycode.KeyCodeVolumeUp, keycode.KeyCodeVolumeDown,
+		keycode.KeyCodeNumpadEquals,
+	}
+)
+
+// formatKeybinding formats a keybinding into Sublime Text's keys array, e.g. ["super+k", "super+b"].
+func formatKeybinding(kb keybinding.Keybinding) ([]string, error) {
+	keys := make([]string, 0, len(kb.KeyChords))
+	for _, chord := range kb.KeyChords {
+		if chord.KeyCode == "" || slices.Contains(sublimeUnsupportedKeys, chord.KeyCode) {
+			return nil, fmt.Errorf("%w: %q", ErrNotSupportKeyChords, chord.String(keychord.FormatOption{
+				Separator: sublimeKeyChordSeparator,
+			}))
+		}
+
+		var parts []string
+		// Modifiers first, in super-ctrl-alt-shift order like Sublime Text's default keymaps
+		for _, mod := range []keycode.KeyModifier{
+			keycode.KeyModifierMeta, keycode.KeyModifierCtrl, keycode.KeyModifierAlt, keycode.KeyModifierShift,
+		} {
+			if slices.Contains(chord.Modifiers, mod) {
+				name, _ := sublimeModifierMapping.GetInverse(mod)
+				parts = append(parts, name)
+			}
+		}
+
+		key := string(chord.KeyCode)
+		if name, ok := sublimeKeyMapping.GetInverse(chord.KeyCode); ok {
+			key = name
+		}
+		parts = append(parts, key)
+		keys = append(keys, strings.Join(parts, sublimeKeyChordSeparator))
+	}
+	return keys, nil
+}
+
+// parseKeybinding parses a Sublime Text keys array into a keybinding.
+// The "primary" modifier resolves to super on macOS and ctrl elsewhere; plat defaults to the current platform.
+func parseKeybinding(keys []string, plat platform.Platform) (keybinding.Keybinding, error) {
+	if len(keys) == 0 {
+		return keybinding.Keybinding{}, errors.New("cannot parse empty keys")
+	}
+	if plat == "" {
+		plat = platform.Current()
+	}
+
+	chords := make([]keychord.KeyChord, 0, len(keys))
+	for _, k := range keys {
+		chord, err := parseKeyChord(k, plat)
+		if err != nil {
+			return keybinding.Keybinding{}, err
+		}
+		chords = append(chords, chord)
+	}
+	return keybinding.Keybinding{KeyChords: chords}, nil
+}
+
+// parseKeyChord parses a single chord like "ctrl+shift+p", "primary+k" or "ctrl++".
+func parseKeyChord(s string, plat platform.Platform) (keychord.KeyChord, error) {
+	var modifierParts []string
+	var key string
+	switch {
+	case s == sublimeKeyChordSeparator:
+		key = sublimeKeyChordSeparator
+	case strings.HasSuffix(s, "++"):
+		// e.g. "ctrl++": the key itself is '+'
+		modifierParts = strings.Split(strings.TrimSuffix(s, "++"), sublimeKeyChordSeparator)
+		key = sublimeKeyChordSeparator
+	default:
+		parts := strings.Split(s, sublimeKeyChordSeparator)
+		modifierParts = parts[:len(parts)-1]
+		key = parts[len(parts)-1]
+	}
+
+	chord := keychord.KeyChord{}
+	for _, part := range modifierParts {
+		mod, err := parseModifier(strings.ToLower(part), plat)
+		if err != nil {
+			return keychord.KeyChord{}, fmt.Errorf("%w in %q", err, s)
+		}
+		if !slices.Contains(chord.Modifiers, mod) {
+			chord.Modifiers = append(chord.Modifiers, mod)
+		}
+	}
+
+	kc, shifted, err := fromSublimeKey(key)
+	if err != nil {
+		return keychord.KeyChord{}, fmt.Errorf("%w: %q", err, s)
+	}
+	if shifted && !slices.Contains(chord.Modifiers, keycode.KeyModifierShift) {
+		chord.Modifiers = append(chord.Modifiers, keycode.KeyModifierShift)
+	}
+	chord.KeyCode = kc
+	return chord, nil
+}
+
+func parseModifier(name string, plat platform.Platform) (keycode.KeyModifier, error) {
+	if name == "primary" {
+		if plat == platform.PlatformMacOS {
+			return keycode.KeyModifierMeta, nil
+		}
+		return keycode.KeyModifierCtrl, nil
+	}
+	if mod, ok := sublimeModifierMapping.Get(name); ok {
+		return mod, nil
+	}
+	if mod, ok := sublimeModifierAliases[name]; ok {
+		return mod, nil
+	}
+	return "", fmt.Errorf("unknown sublime modifier %q", name)
+}
+
+// fromSublimeKey converts a Sublime Text key name to a keycode. Uppercase letters are reported as shifted.
+func fromSublimeKey(key string) (keycode.KeyCode, bool, error) {
+	if key == "plus" {
+		return keycode.KeyCodePlus, false, nil
+	}
+	if kc, ok := sublimeKeyMapping.Get(strings.ToLower(key)); ok {
+		return kc, false, nil
+	}
+	if len(key) == 1 && unicode.IsUpper(rune(key[0])) {
+		return keycode.KeyCode(strings.ToLower(key)), true, nil
+	}
+	kc := keycode.KeyCode(strings.ToLower(key))
+	if kc.IsValid() && !slices.Contains(sublimeUnsupportedKeys, kc) && !sublimeKeyMapping.ExistsInverse(kc) {
+		return kc, false, nil
+	}
+	return "", false, errors.New("unsupported sublime key")
+}
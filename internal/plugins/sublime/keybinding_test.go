@@ -0,0 +1,78 @@
+package sublime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+func TestFormatKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{name: "Simple", in: "ctrl+k", want: []string{"ctrl+k"}},
+		{name: "MetaIsSuper", in: "meta+shift+p", want: []string{"super+shift+p"}},
+		{name: "ModifierOrder", in: "shift+alt+ctrl+meta+f5", want: []string{"super+ctrl+alt+shift+f5"}},
+		{name: "MultiChord", in: "ctrl+k ctrl+b", want: []string{"ctrl+k", "ctrl+b"}},
+		{name: "Keypad", in: "ctrl+numpad_add", want: []string{"ctrl+keypad_plus"}},
+		{name: "Plus", in: "ctrl++", want: []string{"ctrl++"}},
+		{name: "Unsupported", in: "ctrl+capslock", wantErr: true},
+		{name: "ModifierOnly", in: "shift", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := keybinding.NewKeybinding(tc.in, keybinding.ParseOption{Separator: "+"})
+			require.NoError(t, err)
+			out, err := formatKeybinding(kb)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestParseKeybinding(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []string
+		platform platform.Platform
+		want     string
+		wantErr  bool
+	}{
+		{name: "Simple", in: []string{"ctrl+k"}, want: "ctrl+k"},
+		{name: "Super", in: []string{"super+shift+p"}, want: "meta+shift+p"},
+		{name: "MultiChord", in: []string{"ctrl+k", "ctrl+b"}, want: "ctrl+k ctrl+b"},
+		{name: "PrimaryOnMacOS", in: []string{"primary+c"}, platform: platform.PlatformMacOS, want: "meta+c"},
+		{name: "PrimaryOnLinux", in: []string{"primary+c"}, platform: platform.PlatformLinux, want: "ctrl+c"},
+		{name: "Keypad", in: []string{"keypad_enter"}, want: "numpad_enter"},
+		{name: "Plus", in: []string{"ctrl++"}, want: "ctrl++"},
+		{name: "Uppercase", in: []string{"ctrl+K"}, want: "ctrl+shift+k"},
+		{name: "Alias", in: []string{"cmd+option+up"}, want: "meta+alt+up"},
+		{name: "UnknownModifier", in: []string{"hyper+k"}, wantErr: true},
+		{name: "UnknownKey", in: []string{"ctrl+browser_back"}, wantErr: true},
+		{name: "OwnNumpadNameRejected", in: []string{"numpad1"}, wantErr: true},
+		{name: "Empty", in: nil, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := parseKeybinding(tc.in, tc.platform)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, kb.String(keybinding.FormatOption{Separator: "+"}))
+		})
+	}
+}
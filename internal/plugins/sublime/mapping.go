@@ -0,0 +1,69 @@
+package sublime
+
+import (
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+// findMappingBySublime finds the action for a Sublime Text command and args.
+// Configs whose context also matches are preferred over those with a different context;
+// ties are broken by action ID to keep the result deterministic.
+func findMappingBySublime(
+	mappingConfig *mappings.MappingConfig,
+	command string,
+	args sublimeArgs,
+	context []map[string]any,
+) *mappings.ActionMappingConfig {
+	var exact, ignoreContext []string
+	for id, mapping := range mappingConfig.Mappings {
+		for _, sc := range mapping.Sublime {
+			if sc.DisableImport || sc.Command != command || !equalArgs(sc.Args, args) {
+				continue
+			}
+			if equalContext(sc.Context, context) {
+				exact = append(exact, id)
+			} else {
+				ignoreContext = append(ignoreContext, id)
+			}
+		}
+	}
+
+	candidates := exact
+	if len(candidates) == 0 {
+		candidates = ignoreContext
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Strings(candidates)
+	m := mappingConfig.Mappings[candidates[0]]
+	return &m
+}
+
+// isManagedKeybinding reports whether a keybinding from an existing keymap file
+// corresponds exactly to one of our action mappings, including export-only ones.
+func isManagedKeybinding(mappingConfig *mappings.MappingConfig, kb sublimeKeybinding) bool {
+	for _, mapping := range mappingConfig.Mappings {
+		for _, sc := range mapping.Sublime {
+			if sc.Command == kb.Command && equalArgs(sc.Args, kb.Args) && equalContext(sc.Context, kb.Context) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func equalArgs(a map[string]interface{}, b sublimeArgs) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	return equalJSON(a, b)
+}
+
+func equalContext(a, b []map[string]any) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	return equalJSON(a, b)
+}
@@ -0,0 +1,69 @@
+package sublime
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/tailscale/hujson"
+)
+
+// sublimeKeybinding represents a single entry in a .sublime-keymap file.
+type sublimeKeybinding struct {
+	// Keys is the key sequence, one chord per element, e.g. ["ctrl+k", "ctrl+b"]
+	Keys []string `json:"keys"`
+	// Command is the command to run, e.g. "toggle_side_bar"
+	Command string `json:"command"`
+	// Args is the arguments to pass to the command
+	Args sublimeArgs `json:"args,omitempty"`
+	// Context is the list of conditions under which the keybinding is active
+	Context []map[string]any `json:"context,omitempty"`
+}
+
+type sublimeArgs map[string]any
+
+func (a sublimeArgs) LogValue() slog.Value {
+	b, err := json.Marshal(a)
+	if err != nil {
+		return slog.StringValue(err.Error())
+	}
+	return slog.StringValue(string(b))
+}
+
+// parseConfig decodes a .sublime-keymap file. Sublime Text accepts comments and
+// trailing commas in its JSON files, so the content is standardized first.
+func parseConfig(reader io.Reader) ([]sublimeKeybinding, error) {
+	var keybindings []sublimeKeybinding
+	if reader == nil {
+		return keybindings, nil
+	}
+
+	rawData, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read existing config: %w", err)
+	}
+	if len(rawData) == 0 {
+		return keybindings, nil
+	}
+
+	standardizedData, err := hujson.Standardize(rawData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to standardize JSON: %w", err)
+	}
+	if err := json.Unmarshal(standardizedData, &keybindings); err != nil {
+		return nil, fmt.Errorf("failed to decode existing config: %w", err)
+	}
+	return keybindings, nil
+}
+
+// equalJSON compares two values by canonical JSON encoding to avoid type
+// mismatches (e.g., YAML int vs JSON float64) and map iteration order issues.
+func equalJSON(a, b any) bool {
+	ab, err1 := json.Marshal(a)
+	bb, err2 := json.Marshal(b)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	return string(ab) == string(bb)
+}
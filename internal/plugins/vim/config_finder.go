@@ -0,0 +1,92 @@
+package vim
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+)
+
+// ConfigDetect returns candidate paths for the user's vimrc.
+// On macOS and Linux this is ~/.vimrc, ~/.vim/vimrc or Neovim's ~/.config/nvim/init.vim;
+// the first existing file is returned first, falling back to ~/.vimrc.
+func (p *vimPlugin) ConfigDetect(_ pluginapi.ConfigDetectOptions) (paths []string, installed bool, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var candidates []string
+	switch runtime.GOOS {
+	case "darwin", "linux":
+		candidates = []string{
+			filepath.Join(home, ".vimrc"),
+			filepath.Join(home, ".vim", "vimrc"),
+			filepath.Join(home, ".config", "nvim", "init.vim"),
+		}
+	case "windows":
+		candidates = []string{
+			filepath.Join(home, "_vimrc"),
+			filepath.Join(home, "vimfiles", "vimrc"),
+		}
+		if localAppData := os.Getenv("LOCALAPPDATA"); localAppData != "" {
+			candidates = append(candidates, filepath.Join(localAppData, "nvim", "init.vim"))
+		}
+	default:
+		return nil, false, fmt.Errorf(
+			"automatic path discovery is only supported on macOS, Linux, and Windows, %w",
+			pluginapi.ErrNotSupported,
+		)
+	}
+
+	paths = []string{candidates[0]}
+	for _, c := range candidates {
+		if _, statErr := os.Stat(c); statErr == nil {
+			paths = []string{c}
+			break
+		}
+	}
+
+	_, vimErr := exec.LookPath("vim")
+	_, nvimErr := exec.LookPath("nvim")
+	installed = vimErr == nil || nvimErr == nil
+
+	return paths, installed, nil
+}
+
+// ConfigDetect returns the path of the generated onekeymap.lua module inside Neovim's
+// config directory, so it can be loaded from init.lua with `require("onekeymap")`.
+func (p *neovimPlugin) ConfigDetect(_ pluginapi.ConfigDetectOptions) (paths []string, installed bool, err error) {
+	var configDir string
+	switch runtime.GOOS {
+	case "darwin", "linux":
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			configDir = filepath.Join(xdg, "nvim")
+		} else {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, false, err
+			}
+			configDir = filepath.Join(home, ".config", "nvim")
+		}
+	case "windows":
+		localAppData := os.Getenv("LOCALAPPDATA")
+		if localAppData == "" {
+			return nil, false, fmt.Errorf("LOCALAPPDATA environment variable not set, %w", pluginapi.ErrNotSupported)
+		}
+		configDir = filepath.Join(localAppData, "nvim")
+	default:
+		return nil, false, fmt.Errorf(
+			"automatic path discovery is only supported on macOS, Linux, and Windows, %w",
+			pluginapi.ErrNotSupported,
+		)
+	}
+
+	_, err = exec.LookPath("nvim")
+	installed = err == nil
+
+	return []string{filepath.Join(configDir, "lua", "onekeymap.lua")}, installed, nil
+}
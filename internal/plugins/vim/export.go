@@ -0,0 +1,166 @@
+package vim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/internal/export"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+type vimExporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	differ        diff.Differ
+}
+
+func newExporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	differ diff.Differ,
+) pluginapi.PluginExporter {
+	return &vimExporter{mappingConfig: mappingConfig, logger: logger, differ: differ}
+}
+
+// Export writes the keymap as a managed block of noremap commands into the vimrc.
+// Every line outside the managed block is preserved as-is.
+func (e *vimExporter) Export(
+	ctx context.Context,
+	destination io.Writer,
+	setting keymap.Keymap,
+	opts pluginapi.PluginExportOption,
+) (*pluginapi.PluginExportReport, error) {
+	var existing vimrc
+	if opts.ExistingConfig != nil {
+		var err error
+		existing, err = parseVimrc(opts.ExistingConfig)
+		if err != nil {
+			e.logger.WarnContext(
+				ctx,
+				"Failed to parse existing config, proceeding with destructive export",
+				"error",
+				err,
+			)
+			existing = vimrc{}
+		}
+	}
+
+	marker := export.NewMarker(&setting)
+	managed := e.identifyManagedKeybindings(ctx, &setting, marker)
+
+	final := vimrc{
+		Before:   existing.Before,
+		Managed:  managed,
+		After:    existing.After,
+		HasBlock: existing.HasBlock,
+	}
+	e.logConflicts(ctx, managed, existing.unmanagedMappings())
+
+	if err := final.write(destination); err != nil {
+		return nil, err
+	}
+
+	return &pluginapi.PluginExportReport{
+		BaseEditorConfig:   existing.allMappings(),
+		ExportEditorConfig: final.allMappings(),
+		SkipReport:         marker.Report(),
+		ExportedReport:     marker.ExportedReport(),
+	}, nil
+}
+
+// identifyManagedKeybindings generates Vim mappings from KeymapSetting.
+func (e *vimExporter) identifyManagedKeybindings(
+	ctx context.Context,
+	setting *keymap.Keymap,
+	marker *export.Marker,
+) []vimMapping {
+	return buildManagedMappings(ctx, e.logger, e.mappingConfig, pluginapi.EditorTypeVim, setting, marker)
+}
+
+// buildManagedMappings converts the keymap into mappings for the given Vim-family editor.
+// It is shared by the vimscript and the Neovim Lua exporters.
+func buildManagedMappings(
+	ctx context.Context,
+	logger *slog.Logger,
+	mappingConfig *mappings.MappingConfig,
+	editorType pluginapi.EditorType,
+	setting *keymap.Keymap,
+	marker *export.Marker,
+) []vimMapping {
+	var result []vimMapping
+	seen := make(map[vimMapping]struct{})
+
+	for _, km := range setting.Actions {
+		mapping, usedFallback := mappingConfig.GetExportAction(km.Name, editorType)
+		if mapping == nil || mapping.Vim.Command == "" {
+			for _, b := range km.Bindings {
+				if len(b.KeyChords) > 0 {
+					marker.MarkSkippedForReason(km.Name, &b, pluginapi.ErrActionNotSupported)
+				}
+			}
+			continue
+		}
+
+		if usedFallback {
+			logger.InfoContext(ctx, "Action not directly supported, using fallback action",
+				"originalAction", km.Name,
+				"fallbackAction", mapping.ID,
+			)
+		}
+
+		mode := Mode(mapping.Vim.EffectiveMode())
+		if _, ok := noremapCommands[mode]; !ok {
+			logger.WarnContext(ctx, "Unsupported Vim mode; skipping", "mode", string(mode), "action", km.Name)
+			for _, b := range km.Bindings {
+				marker.MarkSkippedForReason(
+					km.Name,
+					&b,
+					&pluginapi.UnsupportedExportActionError{Note: fmt.Sprintf("unsupported vim mode %q", mode)},
+				)
+			}
+			continue
+		}
+
+		for _, b := range km.Bindings {
+			if len(b.KeyChords) == 0 {
+				continue
+			}
+			lhs, err := formatKeybinding(b)
+			if err != nil {
+				logger.DebugContext(ctx, "Skipping keybinding with un-formattable key", "action", km.Name, "error", err)
+				marker.MarkSkippedForReason(km.Name, &b, &pluginapi.UnsupportedExportActionError{Note: err.Error()})
+				continue
+			}
+			marker.MarkExported(km.Name, b)
+
+			m := vimMapping{Mode: mode, LHS: lhs, RHS: mapping.Vim.Command}
+			if _, dup := seen[m]; dup {
+				continue
+			}
+			seen[m] = struct{}{}
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
+
+// logConflicts reports user mappings outside the managed block that bind the same keys.
+// They are kept untouched; whichever is sourced last wins in Vim.
+func (e *vimExporter) logConflicts(ctx context.Context, managed, unmanaged []vimMapping) {
+	managedKeys := make(map[vimMapping]string)
+	for _, m := range managed {
+		managedKeys[vimMapping{Mode: m.Mode, LHS: m.LHS}] = m.RHS
+	}
+	for _, u := range unmanaged {
+		if rhs, ok := managedKeys[vimMapping{Mode: u.Mode, LHS: u.LHS}]; ok && rhs != u.RHS {
+			e.logger.DebugContext(ctx, "User mapping outside managed block binds the same key",
+				"mode", string(u.Mode), "key", u.LHS, "managed_command", rhs, "unmanaged_command", u.RHS)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package vim
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+// newAction creates a test Action with given keybindings.
+func newAction(actionName string, keybindings ...string) keymap.Action {
+	var bindings []keybinding.Keybinding
+	for _, kb := range keybindings {
+		b, err := keybinding.NewKeybinding(kb, keybinding.ParseOption{Separator: "+"})
+		if err != nil {
+			panic(err)
+		}
+		bindings = append(bindings, b)
+	}
+	return keymap.Action{
+		Name:     actionName,
+		Bindings: bindings,
+	}
+}
+
+func block(lines ...string) string {
+	all := append([]string{managedBlockBegin, managedBlockNote}, lines...)
+	all = append(all, managedBlockEnd)
+	return strings.Join(all, "\n") + "\n"
+}
+
+func TestExportVimConfig(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		setting        keymap.Keymap
+		existingConfig string
+		want           string
+	}{
+		{
+			name: "export to empty config",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c", "ctrl+c")},
+			},
+			want: block(`vnoremap <D-c> "+y`, `vnoremap <C-c> "+y`),
+		},
+		{
+			name: "append block after user config",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")},
+			},
+			existingConfig: "set number\nnnoremap <C-p> :Files<CR>\n",
+			want:           "set number\nnnoremap <C-p> :Files<CR>\n\n" + block(`vnoremap <D-c> "+y`),
+		},
+		{
+			name: "replace existing block and keep surrounding lines",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")},
+			},
+			existingConfig: "set number\n" +
+				block(`vnoremap <C-c> "+y`, `nnoremap <C-s> :w<CR>`) +
+				"\" user tail\nset hlsearch\n",
+			want: "set number\n" + block(`vnoremap <D-c> "+y`) + "\" user tail\nset hlsearch\n",
+		},
+		{
+			name: "falls back to fallback action when parent not supported",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.test.parentNotSupported", "meta+shift+h")},
+			},
+			want: block(`nnoremap <D-S-h> :ChildSupported<CR>`),
+		},
+		{
+			name: "unsupported action leaves an empty block",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.test.withArgs", "ctrl+e")},
+			},
+			want: block(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			exporter, err := p.Exporter()
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			opts := pluginapi.PluginExportOption{}
+			if tt.existingConfig != "" {
+				opts.ExistingConfig = strings.NewReader(tt.existingConfig)
+			}
+
+			_, err = exporter.Export(context.Background(), &buf, tt.setting, opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestExportVimConfig_ReportsSkipped(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "meta+c"),
+		newAction("actions.test.withArgs", "ctrl+e"),
+	}}
+	var buf bytes.Buffer
+	report, err := exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+	require.Len(t, report.SkipReport.SkipActions, 1)
+	assert.Equal(t, "actions.test.withArgs", report.SkipReport.SkipActions[0].Action)
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "meta+c", "ctrl+k ctrl+c"),
+		newAction("actions.test.childSupported", "alt+f5"),
+	}}
+	var buf bytes.Buffer
+	_, err = exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	result, err := importer.Import(context.Background(), &buf, pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, setting.Actions, result.Keymap.Actions)
+}
@@ -0,0 +1,87 @@
+package vim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/dedup"
+	"github.com/xinnjie/onekeymap-cli/internal/imports"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+type vimImporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	reporter      *metrics.UnknownActionReporter
+}
+
+func newImporter(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) *vimImporter {
+	return &vimImporter{
+		mappingConfig: mappingConfig,
+		logger:        logger,
+		reporter:      metrics.NewUnknownActionReporter(recorder),
+	}
+}
+
+// Import reads a vimrc/init.vim and converts its key mappings, both inside and
+// outside the onekeymap managed block, into the universal KeymapSetting format.
+func (i *vimImporter) Import(
+	ctx context.Context,
+	source io.Reader,
+	_ pluginapi.PluginImportOption,
+) (pluginapi.PluginImportResult, error) {
+	rc, err := parseVimrc(source)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, fmt.Errorf("failed to parse vim config: %w", err)
+	}
+
+	return importMappings(ctx, i.logger, i.reporter, i.mappingConfig, pluginapi.EditorTypeVim, rc.allMappings()), nil
+}
+
+// importMappings converts parsed Vim mappings into a keymap, resolving each rhs/mode
+// pair through the vim action mappings. Shared by the vimscript and Neovim Lua importers.
+func importMappings(
+	ctx context.Context,
+	logger *slog.Logger,
+	reporter *metrics.UnknownActionReporter,
+	mappingConfig *mappings.MappingConfig,
+	editorType pluginapi.EditorType,
+	vimMappings []vimMapping,
+) pluginapi.PluginImportResult {
+	setting := keymap.Keymap{}
+	marker := imports.NewMarker()
+	for _, m := range vimMappings {
+		kb, err := parseKeybinding(m.LHS)
+		if err != nil {
+			logger.DebugContext(ctx, "failed to parse vim key sequence", "lhs", m.LHS, "error", err)
+			marker.MarkSkipped(m.RHS, nil, fmt.Errorf("failed to parse key sequence '%s': %w", m.LHS, err))
+			continue
+		}
+
+		actionID, err := actionIDFromVim(mappingConfig, m.RHS, m.Mode)
+		if err != nil {
+			logger.DebugContext(ctx, "failed to find action", "command", m.RHS, "mode", m.Mode, "error", err)
+			reporter.ReportUnknownCommand(ctx, editorType, m.RHS)
+			marker.MarkSkipped(m.RHS, &kb, err)
+			continue
+		}
+
+		setting.Actions = append(setting.Actions, keymap.Action{
+			Name:     actionID,
+			Bindings: []keybinding.Keybinding{kb},
+		})
+		marker.MarkImported(actionID, m.RHS, kb, kb)
+	}
+
+	setting.Actions = dedup.Actions(setting.Actions)
+	result := pluginapi.PluginImportResult{Keymap: setting}
+	result.Report.SkipReport = marker.Report()
+	result.Report.ImportedReport = marker.ImportedReport()
+	return result
+}
@@ -0,0 +1,100 @@
+package vim
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func TestImportVimConfig(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    keymap.Keymap
+		wantSkipped int
+	}{
+		{
+			name:  "mapping inside managed block",
+			input: managedBlockBegin + "\n" + `vnoremap <D-c> "+y` + "\n" + managedBlockEnd + "\n",
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")},
+			},
+		},
+		{
+			name: "user mappings outside block with map arguments",
+			input: `set number
+" copy to clipboard
+xnoremap <silent> <C-c> "+y
+nnoremap <C-S-h> :ChildSupported<CR>
+`,
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{
+					newAction("actions.edit.copy", "ctrl+c"),
+					newAction("actions.test.childSupported", "ctrl+shift+h"),
+				},
+			},
+		},
+		{
+			name: "mode must match",
+			input: `nnoremap <C-c> "+y
+`,
+			expected:    keymap.Keymap{},
+			wantSkipped: 1,
+		},
+		{
+			name: "unknown command and unsupported lhs are skipped",
+			input: `nnoremap <C-p> :Files<CR>
+nnoremap <leader>g :Rg<CR>
+vnoremap <D-c> "+y
+`,
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")},
+			},
+			wantSkipped: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			importer, err := p.Importer()
+			require.NoError(t, err)
+
+			result, err := importer.Import(
+				context.Background(),
+				strings.NewReader(tt.input),
+				pluginapi.PluginImportOption{},
+			)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected.Actions, result.Keymap.Actions)
+			assert.Len(t, result.Report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestImportVimConfig_UnterminatedBlock(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	_, err = importer.Import(
+		context.Background(),
+		strings.NewReader(managedBlockBegin+"\nnnoremap <C-c> \"+y\n"),
+		pluginapi.PluginImportOption{},
+	)
+	require.Error(t, err)
+}
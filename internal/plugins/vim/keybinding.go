@@ -0,0 +1,243 @@
+package vim
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/xinnjie/onekeymap-cli/internal/bimap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keychord"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keycode"
+)
+
+//nolint:gochecknoglobals // static lookup tables shared across parse/format functions; initialized once and read-only
+var (
+	// see `:help key-notation`
+	vimModifierMapping = bimap.NewBiMapFromMap(map[string]keycode.KeyModifier{
+		"D": keycode.KeyModifierMeta, // Command key, only recognized by MacVim and Neovim GUIs
+		"C": keycode.KeyModifierCtrl,
+		"S": keycode.KeyModifierShift,
+		"M": keycode.KeyModifierAlt,
+	})
+
+	vimKeyNames = map[string]keycode.KeyCode{
+		"CR":       keycode.KeyCodeEnter,
+		"Esc":      keycode.KeyCodeEscape,
+		"BS":       keycode.KeyCodeBackspace,
+		"Del":      keycode.KeyCodeDelete,
+		"Insert":   keycode.KeyCodeInsert,
+		"Tab":      keycode.KeyCodeTab,
+		"Space":    keycode.KeyCodeSpace,
+		"Bslash":   keycode.KeyCodeBackslash,
+		"Up":       keycode.KeyCodeUp,
+		"Down":     keycode.KeyCodeDown,
+		"Left":     keycode.KeyCodeLeft,
+		"Right":    keycode.KeyCodeRight,
+		"Home":     keycode.KeyCodeHome,
+		"End":      keycode.KeyCodeEnd,
+		"PageUp":   keycode.KeyCodePageUp,
+		"PageDown": keycode.KeyCodePageDown,
+
+		"F1":  keycode.KeyCodeF1,
+		"F2":  keycode.KeyCodeF2,
+		"F3":  keycode.KeyCodeF3,
+		"F4":  keycode.KeyCodeF4,
+		"F5":  keycode.KeyCodeF5,
+		"F6":  keycode.KeyCodeF6,
+		"F7":  keycode.KeyCodeF7,
+		"F8":  keycode.KeyCodeF8,
+		"F9":  keycode.KeyCodeF9,
+		"F10": keycode.KeyCodeF10,
+		"F11": keycode.KeyCodeF11,
+		"F12": keycode.KeyCodeF12,
+
+		"k0":        keycode.KeyCodeNumpad0,
+		"k1":        keycode.KeyCodeNumpad1,
+		"k2":        keycode.KeyCodeNumpad2,
+		"k3":        keycode.KeyCodeNumpad3,
+		"k4":        keycode.KeyCodeNumpad4,
+		"k5":        keycode.KeyCodeNumpad5,
+		"k6":        keycode.KeyCodeNumpad6,
+		"k7":        keycode.KeyCodeNumpad7,
+		"k8":        keycode.KeyCodeNumpad8,
+		"k9":        keycode.KeyCodeNumpad9,
+		"kPlus":     keycode.KeyCodeNumpadAdd,
+		"kMinus":    keycode.KeyCodeNumpadSubtract,
+		"kMultiply": keycode.KeyCodeNumpadMultiply,
+		"kDivide":   keycode.KeyCodeNumpadDivide,
+		"kEnter":    keycode.KeyCodeNumpadEnter,
+		"kPoint":    keycode.KeyCodeNumpadDecimal,
+	}
+
+	vimKeyMapping = bimap.NewBiMapFromMap(vimKeyNames)
+
+	// vimKeyNameIndex is used for parsing: Vim key names are case-insensitive and have a few aliases.
+	vimKeyNameIndex = buildKeyNameIndex(vimKeyNames, map[string]keycode.KeyCode{
+		"return":    keycode.KeyCodeEnter,
+		"enter":     keycode.KeyCodeEnter,
+		"backspace": keycode.KeyCodeBackspace,
+		"delete":    keycode.KeyCodeDelete,
+		"ins":       keycode.KeyCodeInsert,
+		"minus":     keycode.KeyCodeMinus,
+	})
+)
+
+func buildKeyNameIndex(names ...map[string]keycode.KeyCode) map[string]keycode.KeyCode {
+	index := make(map[string]keycode.KeyCode)
+	for _, m := range names {
+		for name, kc := range m {
+			index[strings.ToLower(name)] = kc
+		}
+	}
+	return index
+}
+
+// formatKeybinding converts a keybinding into Vim key notation, e.g. "<C-k><C-s>".
+func formatKeybinding(kb keybinding.Keybinding) (string, error) {
+	if len(kb.KeyChords) == 0 {
+		return "", fmt.Errorf("%w: empty keybinding", ErrNotSupportKeyChords)
+	}
+	var sb strings.Builder
+	for _, chord := range kb.KeyChords {
+		s, err := formatKeyChord(chord)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(s)
+	}
+	return sb.String(), nil
+}
+
+func formatKeyChord(chord keychord.KeyChord) (string, error) {
+	if chord.KeyCode == "" {
+		return "", fmt.Errorf("%w: modifier-only chord", ErrNotSupportKeyChords)
+	}
+
+	name, named := vimKeyMapping.GetInverse(chord.KeyCode)
+	if !named {
+		keyStr := string(chord.KeyCode)
+		if len(keyStr) != 1 {
+			return "", fmt.Errorf("%w: cannot format key for vim: %s", ErrNotSupportKeyChords, keyStr)
+		}
+		name = keyStr
+	}
+
+	if len(chord.Modifiers) == 0 {
+		if named {
+			return "<" + name + ">", nil
+		}
+		return name, nil
+	}
+
+	var parts []string
+	// Modifiers first, in D-C-S-M order for consistency
+	for _, mod := range []keycode.KeyModifier{
+		keycode.KeyModifierMeta,
+		keycode.KeyModifierCtrl,
+		keycode.KeyModifierShift,
+		keycode.KeyModifierAlt,
+	} {
+		if slices.Contains(chord.Modifiers, mod) {
+			if m, ok := vimModifierMapping.GetInverse(mod); ok {
+				parts = append(parts, m)
+			}
+		}
+	}
+	parts = append(parts, name)
+	return "<" + strings.Join(parts, "-") + ">", nil
+}
+
+// parseKeybinding parses the left-hand side of a Vim mapping into a keybinding.
+// Notations that do not correspond to a physical key (e.g. <leader>, <Plug>) are rejected.
+func parseKeybinding(lhs string) (keybinding.Keybinding, error) {
+	if lhs == "" {
+		return keybinding.Keybinding{}, fmt.Errorf("%w: empty key sequence", ErrNotSupportKeyChords)
+	}
+
+	var chords []keychord.KeyChord
+	rest := lhs
+	for rest != "" {
+		if rest[0] == '<' {
+			end := strings.IndexByte(rest[1:], '>')
+			// "<C->>" is not supported; every other notation ends with the first '>'
+			if end > 0 {
+				chord, err := parseKeyNotation(rest[1 : end+1])
+				if err != nil {
+					return keybinding.Keybinding{}, err
+				}
+				chords = append(chords, chord)
+				rest = rest[end+2:]
+				continue
+			}
+		}
+
+		r := []rune(rest)[0]
+		chord, err := parseSingleKey(r)
+		if err != nil {
+			return keybinding.Keybinding{}, err
+		}
+		chords = append(chords, chord)
+		rest = rest[len(string(r)):]
+	}
+
+	return keybinding.Keybinding{KeyChords: chords}, nil
+}
+
+// parseKeyNotation parses the inside of a "<...>" notation such as "C-S-f5" or "CR".
+func parseKeyNotation(notation string) (keychord.KeyChord, error) {
+	chord := keychord.KeyChord{}
+	rest := notation
+	for len(rest) > 2 && rest[1] == '-' {
+		mod, ok := lookupModifier(rest[0])
+		if !ok {
+			break
+		}
+		if !slices.Contains(chord.Modifiers, mod) {
+			chord.Modifiers = append(chord.Modifiers, mod)
+		}
+		rest = rest[2:]
+	}
+
+	if kc, ok := vimKeyNameIndex[strings.ToLower(rest)]; ok {
+		chord.KeyCode = kc
+		return chord, nil
+	}
+
+	if len(rest) == 1 {
+		kc := keycode.KeyCode(strings.ToLower(rest))
+		if kc.IsValid() {
+			chord.KeyCode = kc
+			return chord, nil
+		}
+	}
+
+	return keychord.KeyChord{}, fmt.Errorf("%w: unsupported key notation <%s>", ErrNotSupportKeyChords, notation)
+}
+
+func parseSingleKey(r rune) (keychord.KeyChord, error) {
+	if r == ' ' {
+		return keychord.KeyChord{KeyCode: keycode.KeyCodeSpace}, nil
+	}
+	if unicode.IsUpper(r) {
+		kc := keycode.KeyCode(string(unicode.ToLower(r)))
+		if kc.IsValid() {
+			return keychord.KeyChord{Modifiers: []keycode.KeyModifier{keycode.KeyModifierShift}, KeyCode: kc}, nil
+		}
+	}
+	kc := keycode.KeyCode(string(r))
+	if kc.IsValid() {
+		return keychord.KeyChord{KeyCode: kc}, nil
+	}
+	return keychord.KeyChord{}, fmt.Errorf("%w: unsupported key %q", ErrNotSupportKeyChords, string(r))
+}
+
+func lookupModifier(c byte) (keycode.KeyModifier, bool) {
+	s := strings.ToUpper(string(c))
+	if s == "A" {
+		// <A-x> is an alias of <M-x>
+		s = "M"
+	}
+	return vimModifierMapping.Get(s)
+}
@@ -0,0 +1,78 @@
+package vim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+)
+
+func TestFormatKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "Simple", in: "ctrl+k", want: "<C-k>"},
+		{name: "Plain key", in: "j", want: "j"},
+		{name: "Special Enter", in: "ctrl+enter", want: "<C-CR>"},
+		{name: "Named key without modifier", in: "f5", want: "<F5>"},
+		{name: "ManyModifiersFunction", in: "meta+ctrl+shift+alt+f5", want: "<D-C-S-M-F5>"},
+		{name: "MultiChord", in: "ctrl+k ctrl+s", want: "<C-k><C-s>"},
+		{name: "Minus", in: "ctrl+-", want: "<C-->"},
+		{name: "Backslash", in: "\\", want: "<Bslash>"},
+		{name: "Numpad", in: "ctrl+numpad0", want: "<C-k0>"},
+		{name: "Unsupported key", in: "ctrl+mute", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := keybinding.NewKeybinding(tc.in, keybinding.ParseOption{Separator: "+"})
+			require.NoError(t, err)
+			out, err := formatKeybinding(kb)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestParseKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "Simple", in: "<C-k>", want: "ctrl+k"},
+		{name: "Case insensitive", in: "<c-K>", want: "ctrl+k"},
+		{name: "Named key", in: "<cr>", want: "enter"},
+		{name: "Alt alias", in: "<A-x>", want: "alt+x"},
+		{name: "Command key", in: "<D-s>", want: "meta+s"},
+		{name: "ManyModifiersFunction", in: "<D-C-S-M-F5>", want: "meta+ctrl+shift+alt+f5"},
+		{name: "MultiChord", in: "<C-k><C-s>", want: "ctrl+k ctrl+s"},
+		{name: "Plain sequence", in: "gd", want: "g d"},
+		{name: "Uppercase letter is shifted", in: "K", want: "shift+k"},
+		{name: "Minus", in: "<C-->", want: "ctrl+-"},
+		{name: "Leader", in: "<leader>f", wantErr: true},
+		{name: "Plug", in: "<Plug>(foo)", wantErr: true},
+		{name: "Empty", in: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := parseKeybinding(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, kb.String(keybinding.FormatOption{Separator: "+"}))
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package vim
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const luaModuleHeader = `-- Generated by onekeymap. Do not edit: this file is overwritten on every export.
+-- Load it from init.lua with: require("onekeymap")
+`
+
+//nolint:gochecknoglobals // static lookup tables; initialized once and read-only
+var (
+	// luaModeLetters is the mode short-name emitted by the Lua exporter.
+	luaModeLetters = map[Mode]string{
+		VimModeNormal: "n",
+		VimModeVisual: "v",
+		VimModeInsert: "i",
+	}
+
+	// luaModesByLetter recognizes mode short-names on import, see `:help map-table`.
+	// "" is what nvim_set_keymap uses for :map, which we treat as normal mode like the vimscript importer.
+	luaModesByLetter = map[string]Mode{
+		"":  VimModeNormal,
+		"n": VimModeNormal,
+		"v": VimModeVisual,
+		"x": VimModeVisual,
+		"i": VimModeInsert,
+	}
+
+	luaKeymapFunctions = []string{"vim.keymap.set", "vim.api.nvim_set_keymap"}
+)
+
+// writeLuaKeymaps renders mappings as a standalone Lua module of vim.keymap.set calls.
+func writeLuaKeymaps(w io.Writer, vimMappings []vimMapping) error {
+	var sb strings.Builder
+	sb.WriteString(luaModuleHeader)
+	sb.WriteString("\n")
+	for _, m := range vimMappings {
+		fmt.Fprintf(&sb, "vim.keymap.set(%s, %s, %s)\n",
+			luaQuote(luaModeLetters[m.Mode]), luaQuote(m.LHS), luaQuote(m.RHS))
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("failed to write lua keymaps: %w", err)
+	}
+	return nil
+}
+
+func luaQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// parseLuaKeymaps extracts mappings from vim.keymap.set and vim.api.nvim_set_keymap calls,
+// including calls through a local alias such as `local map = vim.keymap.set`.
+// Calls whose mode, lhs or rhs is not a string literal (e.g. a Lua function rhs) are ignored.
+func parseLuaKeymaps(reader io.Reader) ([]vimMapping, error) {
+	src, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lua config: %w", err)
+	}
+	tokens, err := tokenizeLua(string(src))
+	if err != nil {
+		return nil, err
+	}
+
+	callees := make(map[string]struct{}, len(luaKeymapFunctions))
+	for _, f := range luaKeymapFunctions {
+		callees[f] = struct{}{}
+	}
+
+	var result []vimMapping
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.kind != luaTokenName {
+			continue
+		}
+
+		// local <alias> = vim.keymap.set
+		if tok.text == "local" && i+3 < len(tokens) &&
+			tokens[i+1].kind == luaTokenName && tokens[i+2].is("=") && tokens[i+3].kind == luaTokenName {
+			if _, ok := callees[tokens[i+3].text]; ok && !(i+4 < len(tokens) && tokens[i+4].is("(")) {
+				callees[tokens[i+1].text] = struct{}{}
+				i += 3
+				continue
+			}
+		}
+
+		if _, ok := callees[tok.text]; !ok || i+1 >= len(tokens) || !tokens[i+1].is("(") {
+			continue
+		}
+		ms, next := parseKeymapCallArgs(tokens, i+2)
+		result = append(result, ms...)
+		i = next - 1
+	}
+	return result, nil
+}
+
+// parseKeymapCallArgs parses `mode, lhs, rhs` starting at tokens[start].
+// It returns the mappings (one per mode) and the index to resume scanning from.
+func parseKeymapCallArgs(tokens []luaToken, start int) ([]vimMapping, int) {
+	i := start
+	var modeLetters []string
+	switch {
+	case i < len(tokens) && tokens[i].kind == luaTokenString:
+		modeLetters = []string{tokens[i].text}
+		i++
+	case i < len(tokens) && tokens[i].is("{"):
+		i++
+		for i < len(tokens) && !tokens[i].is("}") {
+			switch {
+			case tokens[i].kind == luaTokenString:
+				modeLetters = append(modeLetters, tokens[i].text)
+			case tokens[i].is(","):
+			default:
+				return nil, i
+			}
+			i++
+		}
+		i++ // skip "}"
+	default:
+		return nil, i
+	}
+
+	if i+3 >= len(tokens) || !tokens[i].is(",") || tokens[i+1].kind != luaTokenString ||
+		!tokens[i+2].is(",") || tokens[i+3].kind != luaTokenString {
+		return nil, i
+	}
+	lhs, rhs := tokens[i+1].text, tokens[i+3].text
+	i += 4
+
+	var result []vimMapping
+	for _, letter := range modeLetters {
+		mode, ok := luaModesByLetter[letter]
+		if !ok {
+			continue
+		}
+		result = append(result, vimMapping{Mode: mode, LHS: lhs, RHS: rhs})
+	}
+	return result, i
+}
+
+type luaTokenKind int
+
+const (
+	luaTokenName luaTokenKind = iota
+	luaTokenString
+	luaTokenSymbol
+)
+
+type luaToken struct {
+	kind luaTokenKind
+	text string
+}
+
+func (t luaToken) is(symbol string) bool {
+	return t.kind == luaTokenSymbol && t.text == symbol
+}
+
+// tokenizeLua is a minimal Lua lexer: it yields dotted names, decoded string literals and
+// single-character symbols (digits included), and drops whitespace and comments.
+func tokenizeLua(src string) ([]luaToken, error) {
+	var tokens []luaToken
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "--"):
+			i += 2
+			if level, ok := longBracketLevel(src[i:]); ok {
+				end, err := skipLongBracket(src, i, level)
+				if err != nil {
+					return nil, err
+				}
+				i = end
+				continue
+			}
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			s, end, err := readQuotedString(src, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, luaToken{kind: luaTokenString, text: s})
+			i = end
+		case c == '[':
+			if level, ok := longBracketLevel(src[i:]); ok {
+				contentStart := i + level + 2
+				end, err := skipLongBracket(src, i, level)
+				if err != nil {
+					return nil, err
+				}
+				content := src[contentStart : end-level-2]
+				// A newline immediately following the opening bracket is skipped
+				content = strings.TrimPrefix(content, "\n")
+				tokens = append(tokens, luaToken{kind: luaTokenString, text: content})
+				i = end
+				continue
+			}
+			tokens = append(tokens, luaToken{kind: luaTokenSymbol, text: "["})
+			i++
+		case isLuaNameStart(c):
+			start := i
+			for i < len(src) && (isLuaNameStart(src[i]) || (src[i] >= '0' && src[i] <= '9') || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, luaToken{kind: luaTokenName, text: src[start:i]})
+		default:
+			tokens = append(tokens, luaToken{kind: luaTokenSymbol, text: string(c)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isLuaNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// longBracketLevel reports whether s starts with a long bracket like "[[" or "[==[" and its level.
+func longBracketLevel(s string) (int, bool) {
+	if !strings.HasPrefix(s, "[") {
+		return 0, false
+	}
+	level := 0
+	for 1+level < len(s) && s[1+level] == '=' {
+		level++
+	}
+	if 1+level < len(s) && s[1+level] == '[' {
+		return level, true
+	}
+	return 0, false
+}
+
+// skipLongBracket returns the index just past the closing bracket of the long bracket opened at src[start].
+func skipLongBracket(src string, start, level int) (int, error) {
+	closing := "]" + strings.Repeat("=", level) + "]"
+	idx := strings.Index(src[start+level+2:], closing)
+	if idx < 0 {
+		return 0, fmt.Errorf("unterminated long bracket in lua source at offset %d", start)
+	}
+	return start + level + 2 + idx + len(closing), nil
+}
+
+func readQuotedString(src string, start int) (string, int, error) {
+	quote := src[start]
+	var sb strings.Builder
+	i := start + 1
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == quote:
+			return sb.String(), i + 1, nil
+		case c == '\n':
+			return "", 0, fmt.Errorf("unterminated string in lua source at offset %d", start)
+		case c == '\\' && i+1 < len(src):
+			i++
+			switch src[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(src[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+		i++
+	}
+	return "", 0, fmt.Errorf("unterminated string in lua source at offset %d", start)
+}
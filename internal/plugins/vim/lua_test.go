@@ -0,0 +1,85 @@
+package vim
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLuaKeymaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []vimMapping
+		wantErr bool
+	}{
+		{
+			name:  "vim.keymap.set",
+			input: `vim.keymap.set("n", "<C-s>", ":w<CR>", { silent = true })`,
+			want:  []vimMapping{{Mode: VimModeNormal, LHS: "<C-s>", RHS: ":w<CR>"}},
+		},
+		{
+			name:  "nvim_set_keymap with single quotes and escaped quote",
+			input: `vim.api.nvim_set_keymap('x', '<D-c>', '"+y', { noremap = true })`,
+			want:  []vimMapping{{Mode: VimModeVisual, LHS: "<D-c>", RHS: `"+y`}},
+		},
+		{
+			name:  "mode table expands to one mapping per mode",
+			input: `vim.keymap.set({ "n", "i" }, "<F5>", [[<Cmd>make<CR>]])`,
+			want: []vimMapping{
+				{Mode: VimModeNormal, LHS: "<F5>", RHS: "<Cmd>make<CR>"},
+				{Mode: VimModeInsert, LHS: "<F5>", RHS: "<Cmd>make<CR>"},
+			},
+		},
+		{
+			name: "local alias",
+			input: `local map = vim.keymap.set
+map("n", "<C-p>", ":Files<CR>")`,
+			want: []vimMapping{{Mode: VimModeNormal, LHS: "<C-p>", RHS: ":Files<CR>"}},
+		},
+		{
+			name: "comments, function rhs and unknown modes are ignored",
+			input: `-- vim.keymap.set("n", "<C-a>", "ggVG")
+--[[ vim.keymap.set("n", "<C-b>", "b") ]]
+vim.keymap.set("n", "<leader>f", function() print("hi") end)
+vim.keymap.set("t", "<Esc>", "<C-\\><C-n>")
+vim.keymap.set("n", "<C-->", "<C-w>-")`,
+			want: []vimMapping{{Mode: VimModeNormal, LHS: "<C-->", RHS: "<C-w>-"}},
+		},
+		{
+			name:    "unterminated string",
+			input:   `vim.keymap.set("n", "<C-s>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLuaKeymaps(strings.NewReader(tt.input))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteLuaKeymaps_RoundTrip(t *testing.T) {
+	in := []vimMapping{
+		{Mode: VimModeVisual, LHS: "<D-c>", RHS: `"+y`},
+		{Mode: VimModeNormal, LHS: "<Bslash>", RHS: `:echo "a\b"<CR>`},
+		{Mode: VimModeInsert, LHS: "<C-s>", RHS: "<Esc>:w<CR>"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, writeLuaKeymaps(&buf, in))
+	assert.Contains(t, buf.String(), `vim.keymap.set("v", "<D-c>", "\"+y")`)
+
+	out, err := parseLuaKeymaps(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+}
@@ -0,0 +1,21 @@
+package vim
+
+import (
+	"fmt"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+// actionIDFromVim converts a Vim mapping rhs and mode to a universal action ID.
+func actionIDFromVim(mappingConfig *mappings.MappingConfig, command string, mode Mode) (string, error) {
+	for _, mapping := range mappingConfig.Mappings {
+		vconf := mapping.Vim
+		if vconf.DisableImport || vconf.Command == "" {
+			continue
+		}
+		if vconf.Command == command && Mode(vconf.EffectiveMode()) == mode {
+			return mapping.ID, nil
+		}
+	}
+	return "", fmt.Errorf("no mapping found for vim command: %s (mode %s)", command, mode)
+}
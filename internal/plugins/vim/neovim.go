@@ -0,0 +1,40 @@
+package vim
+
+import (
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+var _ pluginapi.Plugin = (*neovimPlugin)(nil)
+
+// neovimPlugin is the Neovim variant of the Vim plugin. It shares the vim action
+// mappings but reads and writes a Lua module of vim.keymap.set calls.
+type neovimPlugin struct {
+	mappingConfig *mappings.MappingConfig
+	importer      pluginapi.PluginImporter
+	exporter      pluginapi.PluginExporter
+	logger        *slog.Logger
+}
+
+// NewNeovim creates a Neovim plugin instance.
+func NewNeovim(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) pluginapi.Plugin {
+	return &neovimPlugin{
+		mappingConfig: mappingConfig,
+		importer:      newLuaImporter(mappingConfig, logger, recorder),
+		exporter:      newLuaExporter(mappingConfig, logger, diff.NewJSONASCIIDiffer()),
+		logger:        logger,
+	}
+}
+
+// EditorType returns the unique identifier for Neovim.
+func (p *neovimPlugin) EditorType() pluginapi.EditorType { return pluginapi.EditorTypeNeovim }
+
+// Importer returns the importer for this plugin.
+func (p *neovimPlugin) Importer() (pluginapi.PluginImporter, error) { return p.importer, nil }
+
+// Exporter returns the exporter for this plugin.
+func (p *neovimPlugin) Exporter() (pluginapi.PluginExporter, error) { return p.exporter, nil }
@@ -0,0 +1,60 @@
+package vim
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/internal/export"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+type luaExporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	differ        diff.Differ
+}
+
+func newLuaExporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	differ diff.Differ,
+) pluginapi.PluginExporter {
+	return &luaExporter{mappingConfig: mappingConfig, logger: logger, differ: differ}
+}
+
+// Export writes the keymap as a generated onekeymap.lua module.
+// The module is owned by onekeymap, so the existing file is only read for the diff report.
+func (e *luaExporter) Export(
+	ctx context.Context,
+	destination io.Writer,
+	setting keymap.Keymap,
+	opts pluginapi.PluginExportOption,
+) (*pluginapi.PluginExportReport, error) {
+	var existing []vimMapping
+	if opts.ExistingConfig != nil {
+		var err error
+		existing, err = parseLuaKeymaps(opts.ExistingConfig)
+		if err != nil {
+			e.logger.WarnContext(ctx, "Failed to parse existing config, it will be overwritten", "error", err)
+			existing = nil
+		}
+	}
+
+	marker := export.NewMarker(&setting)
+	managed := buildManagedMappings(ctx, e.logger, e.mappingConfig, pluginapi.EditorTypeNeovim, &setting, marker)
+
+	if err := writeLuaKeymaps(destination, managed); err != nil {
+		return nil, err
+	}
+
+	return &pluginapi.PluginExportReport{
+		BaseEditorConfig:   existing,
+		ExportEditorConfig: managed,
+		SkipReport:         marker.Report(),
+		ExportedReport:     marker.ExportedReport(),
+	}, nil
+}
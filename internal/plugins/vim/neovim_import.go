@@ -0,0 +1,40 @@
+package vim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+type luaImporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	reporter      *metrics.UnknownActionReporter
+}
+
+func newLuaImporter(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) *luaImporter {
+	return &luaImporter{
+		mappingConfig: mappingConfig,
+		logger:        logger,
+		reporter:      metrics.NewUnknownActionReporter(recorder),
+	}
+}
+
+// Import reads a Lua file (init.lua or the generated onekeymap.lua) and converts its
+// vim.keymap.set / vim.api.nvim_set_keymap calls into the universal KeymapSetting format.
+func (i *luaImporter) Import(
+	ctx context.Context,
+	source io.Reader,
+	_ pluginapi.PluginImportOption,
+) (pluginapi.PluginImportResult, error) {
+	vimMappings, err := parseLuaKeymaps(source)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, fmt.Errorf("failed to parse neovim lua config: %w", err)
+	}
+	return importMappings(ctx, i.logger, i.reporter, i.mappingConfig, pluginapi.EditorTypeNeovim, vimMappings), nil
+}
@@ -0,0 +1,66 @@
+package vim
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func TestNeovimExport(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := NewNeovim(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	require.Equal(t, pluginapi.EditorTypeNeovim, p.EditorType())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "meta+c"),
+		newAction("actions.test.parentNotSupported", "ctrl+shift+h"),
+		newAction("actions.test.withArgs", "ctrl+e"),
+	}}
+	var buf bytes.Buffer
+	report, err := exporter.Export(context.Background(), &buf, setting, pluginapi.PluginExportOption{
+		// The generated module is fully owned by onekeymap and gets replaced
+		ExistingConfig: strings.NewReader(`vim.keymap.set("n", "<C-p>", ":Files<CR>")`),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, luaModuleHeader+`
+vim.keymap.set("v", "<D-c>", "\"+y")
+vim.keymap.set("n", "<C-S-h>", ":ChildSupported<CR>")
+`, buf.String())
+	require.Len(t, report.SkipReport.SkipActions, 1)
+	assert.Equal(t, "actions.test.withArgs", report.SkipReport.SkipActions[0].Action)
+}
+
+func TestNeovimImport(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := NewNeovim(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	input := `local map = vim.keymap.set
+map({ "v", "x" }, "<D-c>", '"+y', { desc = "copy" })
+vim.api.nvim_set_keymap("n", "<A-h>", ":ChildSupported<CR>", { noremap = true })
+vim.keymap.set("n", "<C-p>", ":Files<CR>")
+`
+	result, err := importer.Import(context.Background(), strings.NewReader(input), pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []keymap.Action{
+		newAction("actions.edit.copy", "meta+c"),
+		newAction("actions.test.childSupported", "alt+h"),
+	}, result.Keymap.Actions)
+	require.Len(t, result.Report.SkipReport.SkipActions, 1)
+}
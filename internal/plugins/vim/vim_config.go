@@ -0,0 +1,206 @@
+package vim
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Mode enumerates the Vim map modes onekeymap manages.
+type Mode string
+
+const (
+	VimModeNormal Mode = "normal"
+	VimModeVisual Mode = "visual"
+	VimModeInsert Mode = "insert"
+)
+
+const (
+	managedBlockBegin = `" >>> onekeymap managed block >>>`
+	managedBlockEnd   = `" <<< onekeymap managed block <<<`
+	managedBlockNote  = `" Generated by onekeymap. Changes inside this block will be overwritten.`
+)
+
+//nolint:gochecknoglobals // static lookup tables; initialized once and read-only
+var (
+	// noremapCommands is the command emitted on export for each mode.
+	noremapCommands = map[Mode]string{
+		VimModeNormal: "nnoremap",
+		VimModeVisual: "vnoremap",
+		VimModeInsert: "inoremap",
+	}
+
+	// mapCommandModes recognizes map commands (and their abbreviations) on import.
+	// `map`/`noremap` apply to normal, visual and operator-pending mode; we treat them as normal mode.
+	mapCommandModes = map[string]Mode{
+		"map": VimModeNormal, "noremap": VimModeNormal, "no": VimModeNormal, "nor": VimModeNormal,
+		"nmap": VimModeNormal, "nm": VimModeNormal, "nnoremap": VimModeNormal, "nn": VimModeNormal, "nno": VimModeNormal,
+		"vmap": VimModeVisual, "vm": VimModeVisual, "vnoremap": VimModeVisual, "vn": VimModeVisual, "vno": VimModeVisual,
+		"xmap": VimModeVisual, "xm": VimModeVisual, "xnoremap": VimModeVisual, "xn": VimModeVisual, "xno": VimModeVisual,
+		"imap": VimModeInsert, "im": VimModeInsert, "inoremap": VimModeInsert, "ino": VimModeInsert,
+	}
+
+	// mapArguments are the special arguments that may precede the lhs, see `:help :map-arguments`.
+	mapArguments = map[string]struct{}{
+		"<buffer>": {}, "<nowait>": {}, "<silent>": {}, "<special>": {},
+		"<script>": {}, "<expr>": {}, "<unique>": {},
+	}
+)
+
+// vimMapping is a single key mapping line, e.g. `nnoremap <C-s> :w<CR>`.
+type vimMapping struct {
+	Mode Mode   `json:"mode"`
+	LHS  string `json:"lhs"`
+	RHS  string `json:"rhs"`
+}
+
+// String renders the mapping as a vimscript command.
+func (m vimMapping) String() string {
+	return fmt.Sprintf("%s %s %s", noremapCommands[m.Mode], m.LHS, m.RHS)
+}
+
+// vimrc is a vimrc/init.vim split around the onekeymap managed block.
+// Lines outside the block belong to the user and are preserved verbatim.
+type vimrc struct {
+	Before   []string
+	Managed  []vimMapping
+	After    []string
+	HasBlock bool
+}
+
+// parseVimrc reads a vimrc and splits it around the managed block.
+func parseVimrc(reader io.Reader) (vimrc, error) {
+	var rc vimrc
+	inBlock := false
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == managedBlockBegin && !rc.HasBlock:
+			inBlock = true
+			rc.HasBlock = true
+		case trimmed == managedBlockEnd && inBlock:
+			inBlock = false
+		case inBlock:
+			if m, ok := parseMappingLine(line); ok {
+				rc.Managed = append(rc.Managed, m)
+			}
+		case rc.HasBlock:
+			rc.After = append(rc.After, line)
+		default:
+			rc.Before = append(rc.Before, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return vimrc{}, fmt.Errorf("failed to read vim config: %w", err)
+	}
+	if inBlock {
+		return vimrc{}, fmt.Errorf("unterminated onekeymap managed block, missing %q", managedBlockEnd)
+	}
+	return rc, nil
+}
+
+// unmanagedMappings returns mappings declared outside the managed block.
+func (rc vimrc) unmanagedMappings() []vimMapping {
+	var out []vimMapping
+	for _, lines := range [][]string{rc.Before, rc.After} {
+		for _, line := range lines {
+			if m, ok := parseMappingLine(line); ok {
+				out = append(out, m)
+			}
+		}
+	}
+	return out
+}
+
+// allMappings returns every mapping in the file, in file order.
+func (rc vimrc) allMappings() []vimMapping {
+	var out []vimMapping
+	for _, line := range rc.Before {
+		if m, ok := parseMappingLine(line); ok {
+			out = append(out, m)
+		}
+	}
+	out = append(out, rc.Managed...)
+	for _, line := range rc.After {
+		if m, ok := parseMappingLine(line); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// write renders the vimrc. When the source had no managed block, the block is appended at the end.
+func (rc vimrc) write(w io.Writer) error {
+	var sb strings.Builder
+	for _, line := range rc.Before {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if !rc.HasBlock && len(rc.Before) > 0 && strings.TrimSpace(rc.Before[len(rc.Before)-1]) != "" {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(managedBlockBegin + "\n")
+	sb.WriteString(managedBlockNote + "\n")
+	for _, m := range rc.Managed {
+		sb.WriteString(m.String())
+		sb.WriteString("\n")
+	}
+	sb.WriteString(managedBlockEnd + "\n")
+	for _, line := range rc.After {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("failed to write vim config: %w", err)
+	}
+	return nil
+}
+
+// parseMappingLine parses a map command like `nnoremap <silent> <C-s> :w<CR>`.
+// Returns false for any line that is not a supported map command.
+func parseMappingLine(line string) (vimMapping, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, `"`) {
+		return vimMapping{}, false
+	}
+	cmd, rest, ok := cutField(trimmed)
+	if !ok {
+		return vimMapping{}, false
+	}
+	mode, ok := mapCommandModes[cmd]
+	if !ok {
+		return vimMapping{}, false
+	}
+
+	var lhs string
+	for {
+		lhs, rest, ok = cutField(rest)
+		if !ok {
+			return vimMapping{}, false
+		}
+		if _, isArg := mapArguments[strings.ToLower(lhs)]; !isArg {
+			break
+		}
+	}
+	rhs := strings.TrimSpace(rest)
+	if rhs == "" {
+		return vimMapping{}, false
+	}
+	return vimMapping{Mode: mode, LHS: lhs, RHS: rhs}, true
+}
+
+// cutField splits s into its first whitespace-delimited field and the remainder.
+func cutField(s string) (string, string, bool) {
+	s = strings.TrimLeft(s, " \t")
+	if s == "" {
+		return "", "", false
+	}
+	idx := strings.IndexAny(s, " \t")
+	if idx < 0 {
+		return s, "", true
+	}
+	return s[:idx], s[idx+1:], true
+}
@@ -0,0 +1,51 @@
+package visualstudio
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+)
+
+const currentSettingsFileName = "CurrentSettings.vssettings"
+
+// ConfigDetect returns the CurrentSettings.vssettings that Visual Studio loads at startup and saves on exit,
+// newest Visual Studio version first. Visual Studio only runs on Windows.
+func (p *visualStudioPlugin) ConfigDetect(
+	opts pluginapi.ConfigDetectOptions,
+) (paths []string, installed bool, err error) {
+	if runtime.GOOS != "windows" {
+		return nil, false, fmt.Errorf("visual studio is only available on Windows, %w", pluginapi.ErrNotSupported)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, false, err
+	}
+
+	// e.g. Documents\Visual Studio 2022\Settings\CurrentSettings.vssettings
+	matches, _ := filepath.Glob(filepath.Join(home, "Documents", "Visual Studio *", "Settings", currentSettingsFileName))
+	if len(matches) == 0 {
+		return nil, false, errors.New("could not locate Visual Studio settings directory")
+	}
+	// Newer versions have a larger year in the directory name
+	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+
+	if opts.Sandbox {
+		installed = false
+	} else {
+		_, err = exec.LookPath("devenv")
+		installed = err == nil
+		if !installed {
+			vswhere := filepath.Join(os.Getenv("ProgramFiles(x86)"), "Microsoft Visual Studio", "Installer", "vswhere.exe")
+			_, statErr := os.Stat(vswhere)
+			installed = statErr == nil
+		}
+	}
+
+	return matches, installed, nil
+}
@@ -0,0 +1,5 @@
+package visualstudio
+
+import "errors"
+
+var ErrNotSupportKeyChords = errors.New("key chord is not supported by visual studio")
@@ -0,0 +1,230 @@
+package visualstudio
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/internal/export"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+var _ pluginapi.PluginExporter = (*visualStudioExporter)(nil)
+
+type visualStudioExporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	differ        diff.Differ
+}
+
+func newExporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	differ diff.Differ,
+) pluginapi.PluginExporter {
+	return &visualStudioExporter{mappingConfig: mappingConfig, logger: logger, differ: differ}
+}
+
+// Export writes the keymap into the UserShortcuts section of a .vssettings file. Default shortcuts
+// that onekeymap rebinds are removed with <RemoveShortcut>; other settings and shortcuts that are
+// not managed by onekeymap are preserved.
+func (e *visualStudioExporter) Export(
+	ctx context.Context,
+	destination io.Writer,
+	setting keymap.Keymap,
+	opts pluginapi.PluginExportOption,
+) (*pluginapi.PluginExportReport, error) {
+	doc, _ := parseVSSettings(strings.NewReader(""))
+	if opts.ExistingConfig != nil {
+		parsed, err := parseVSSettings(opts.ExistingConfig)
+		if err != nil {
+			e.logger.WarnContext(
+				ctx,
+				"Failed to parse existing config, proceeding with destructive export",
+				"error",
+				err,
+			)
+		} else {
+			doc = parsed
+		}
+	}
+
+	var unmanaged []vsShortcut
+	for _, s := range doc.UserShortcuts.Shortcuts {
+		if !isManagedShortcut(e.mappingConfig, s) {
+			unmanaged = append(unmanaged, s)
+		}
+	}
+
+	marker := export.NewMarker(&setting)
+	managed := e.identifyManagedShortcuts(ctx, &setting, marker)
+
+	final := vsUserShortcuts{
+		Shortcuts:       e.nonDestructiveMerge(ctx, managed, unmanaged),
+		RemoveShortcuts: e.removedDefaults(managed, doc.UserShortcuts.RemoveShortcuts),
+	}
+	if err := doc.write(destination, final); err != nil {
+		return nil, err
+	}
+
+	return &pluginapi.PluginExportReport{
+		BaseEditorConfig:   doc.UserShortcuts,
+		ExportEditorConfig: final,
+		SkipReport:         marker.Report(),
+		ExportedReport:     marker.ExportedReport(),
+	}, nil
+}
+
+// identifyManagedShortcuts generates Visual Studio shortcuts from KeymapSetting.
+func (e *visualStudioExporter) identifyManagedShortcuts(
+	ctx context.Context,
+	setting *keymap.Keymap,
+	marker *export.Marker,
+) []vsShortcut {
+	var result []vsShortcut
+	seen := make(map[vsShortcut]struct{})
+
+	for _, km := range setting.Actions {
+		mapping, usedFallback := e.mappingConfig.GetExportAction(km.Name, pluginapi.EditorTypeVisualStudio)
+		if mapping == nil || mapping.VisualStudio.Command == "" {
+			for _, b := range km.Bindings {
+				if len(b.KeyChords) > 0 {
+					marker.MarkSkippedForReason(km.Name, &b, pluginapi.ErrActionNotSupported)
+				}
+			}
+			continue
+		}
+
+		if usedFallback {
+			e.logger.InfoContext(ctx, "Action not directly supported, using fallback action",
+				"originalAction", km.Name,
+				"fallbackAction", mapping.ID,
+			)
+		}
+
+		for _, b := range km.Bindings {
+			if len(b.KeyChords) == 0 {
+				continue
+			}
+			keys, err := formatKeybinding(b)
+			if err != nil {
+				e.logger.DebugContext(ctx, "Skipping keybinding with un-formattable key", "action", km.Name, "error", err)
+				marker.MarkSkippedForReason(km.Name, &b, &pluginapi.UnsupportedExportActionError{Note: err.Error()})
+				continue
+			}
+			marker.MarkExported(km.Name, b)
+
+			s := vsShortcut{
+				Command: mapping.VisualStudio.Command,
+				Scope:   mapping.VisualStudio.EffectiveScope(),
+				Keys:    keys,
+			}
+			if _, dup := seen[s]; dup {
+				continue
+			}
+			seen[s] = struct{}{}
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// nonDestructiveMerge keeps unmanaged shortcuts after the managed ones, dropping those that bind
+// the same keys in the same scope.
+func (e *visualStudioExporter) nonDestructiveMerge(
+	ctx context.Context,
+	managed, unmanaged []vsShortcut,
+) []vsShortcut {
+	managedKeys := make(map[string]bool, len(managed))
+	for _, s := range managed {
+		managedKeys[conflictKey(s.Scope, s.Keys)] = true
+	}
+
+	result := make([]vsShortcut, 0, len(managed)+len(unmanaged))
+	result = append(result, managed...)
+	for _, s := range unmanaged {
+		if managedKeys[conflictKey(s.Scope, s.Keys)] {
+			e.logger.DebugContext(ctx, "Conflict resolved: managed keybinding takes priority",
+				"keys", s.Keys, "unmanaged_command", s.Command)
+			continue
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+// removedDefaults returns the RemoveShortcut entries to write. Existing entries are kept unless they
+// would remove a shortcut we export. In addition, a default shortcut is removed when onekeymap rebinds
+// its command, or when we bind its keys to another command in the same scope.
+func (e *visualStudioExporter) removedDefaults(managed, existing []vsShortcut) []vsShortcut {
+	exportedKeys := make(map[string]string, len(managed)) // scope+keys -> command
+	exportedCommands := make(map[string]bool, len(managed))
+	for _, s := range managed {
+		exportedKeys[conflictKey(s.Scope, s.Keys)] = s.Command
+		exportedCommands[s.Command+"|"+s.Scope] = true
+	}
+
+	var result []vsShortcut
+	seen := make(map[vsShortcut]bool)
+	add := func(s vsShortcut) {
+		normalized := vsShortcut{Command: s.Command, Scope: s.Scope, Keys: normalizeKeys(s.Keys)}
+		if seen[normalized] {
+			return
+		}
+		seen[normalized] = true
+		result = append(result, s)
+	}
+
+	for _, s := range existing {
+		if exportedKeys[conflictKey(s.Scope, s.Keys)] == s.Command {
+			continue
+		}
+		add(s)
+	}
+
+	ids := make([]string, 0, len(e.mappingConfig.Mappings))
+	for id := range e.mappingConfig.Mappings {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		vc := e.mappingConfig.Mappings[id].VisualStudio
+		if vc.Command == "" {
+			continue
+		}
+		scope := vc.EffectiveScope()
+		for _, d := range vc.DefaultShortcuts {
+			boundTo, bound := exportedKeys[conflictKey(scope, d)]
+			rebound := exportedCommands[vc.Command+"|"+scope] && boundTo != vc.Command
+			overridden := bound && boundTo != vc.Command
+			if rebound || overridden {
+				add(vsShortcut{Command: vc.Command, Scope: scope, Keys: normalizeKeys(d)})
+			}
+		}
+	}
+	return result
+}
+
+func conflictKey(scope, keys string) string {
+	return scope + "|" + normalizeKeys(keys)
+}
+
+// normalizeKeys rewrites a shortcut in the canonical form used by the exporter, e.g. "ctrl+shift+f"
+// becomes "Ctrl+Shift+F", so that hand-written and generated shortcuts compare equal.
+func normalizeKeys(keys string) string {
+	kb, err := parseKeybinding(keys)
+	if err != nil {
+		return keys
+	}
+	formatted, err := formatKeybinding(kb)
+	if err != nil {
+		return keys
+	}
+	return formatted
+}
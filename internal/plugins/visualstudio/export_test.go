@@ -0,0 +1,200 @@
+package visualstudio
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+func newAction(actionName string, keybindings ...string) keymap.Action {
+	var bindings []keybinding.Keybinding
+	for _, kb := range keybindings {
+		b, err := keybinding.NewKeybinding(kb, keybinding.ParseOption{Separator: "+"})
+		if err != nil {
+			panic(err)
+		}
+		bindings = append(bindings, b)
+	}
+	return keymap.Action{
+		Name:     actionName,
+		Bindings: bindings,
+	}
+}
+
+func TestExportVisualStudioShortcuts(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	const (
+		copyCtrlShiftC = `<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Shift+C</Shortcut>`
+		copyCtrlC      = `<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+C</Shortcut>`
+		childKS        = `<Shortcut Command="Test.ChildSupported" Scope="Text Editor">Ctrl+K, Ctrl+S</Shortcut>`
+		removeCtrlC    = `<RemoveShortcut Command="Edit.Copy" Scope="Global">Ctrl+C</RemoveShortcut>`
+		removeCtrlIns  = `<RemoveShortcut Command="Edit.Copy" Scope="Global">Ctrl+Ins</RemoveShortcut>`
+		unknownCtrlU   = `<Shortcut Command="Tools.Unknown" Scope="Global">Ctrl+U</Shortcut>`
+		otherInEditor  = `<Shortcut Command="Tools.Other" Scope="Text Editor">Ctrl+Shift+C</Shortcut>`
+		removeOther    = `<RemoveShortcut Command="Tools.Other" Scope="Global">Ctrl+Q</RemoveShortcut>`
+	)
+
+	tests := []struct {
+		name        string
+		setting     keymap.Keymap
+		existing    string
+		expected    string
+		wantSkipped []string
+	}{
+		{
+			name: "rebinding removes the defaults",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+shift+c", "ctrl+shift+c"),
+				newAction("actions.test.parentNotSupported", "ctrl+k ctrl+s"),
+				newAction("actions.test.withArgs", "ctrl+e"),
+			}},
+			existing:    vssettings(),
+			expected:    vssettings(copyCtrlShiftC, childKS, removeCtrlC, removeCtrlIns),
+			wantSkipped: []string{"actions.test.withArgs"},
+		},
+		{
+			name: "keeping a default only removes the others",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+c"),
+			}},
+			existing: vssettings(),
+			expected: vssettings(copyCtrlC, removeCtrlIns),
+		},
+		{
+			name: "defaults in another scope are kept",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.test.childSupported", "ctrl+c"),
+			}},
+			existing: vssettings(),
+			expected: vssettings(
+				`<Shortcut Command="Test.ChildSupported" Scope="Text Editor">Ctrl+C</Shortcut>`,
+			),
+		},
+		{
+			name: "preserves unmanaged shortcuts and removals",
+			setting: keymap.Keymap{Actions: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+shift+c"),
+			}},
+			existing: vssettings(
+				// Replaced: managed command
+				`<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Ins</Shortcut>`,
+				// Dropped: same keys in the same scope
+				`<Shortcut Command="Tools.Other" Scope="Global">ctrl+shift+c</Shortcut>`,
+				// Kept: different scope, unknown command, unrelated removal
+				otherInEditor, unknownCtrlU, removeOther,
+				// Dropped: would remove the shortcut we export
+				`<RemoveShortcut Command="Edit.Copy" Scope="Global">Ctrl+Shift+C</RemoveShortcut>`,
+				// Kept once: already removed
+				removeCtrlC,
+			),
+			expected: vssettings(
+				copyCtrlShiftC, otherInEditor, unknownCtrlU,
+				removeOther, removeCtrlC, removeCtrlIns,
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			exporter, err := p.Exporter()
+			require.NoError(t, err)
+
+			opts := pluginapi.PluginExportOption{}
+			if tt.existing != "" {
+				opts.ExistingConfig = strings.NewReader(tt.existing)
+			}
+			var sb strings.Builder
+			report, err := exporter.Export(context.Background(), &sb, tt.setting, opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sb.String())
+
+			var skipped []string
+			for _, s := range report.SkipReport.SkipActions {
+				skipped = append(skipped, s.Action)
+			}
+			assert.ElementsMatch(t, tt.wantSkipped, skipped)
+		})
+	}
+}
+
+func TestExportRemovesDefaultsTakenByOtherCommands(t *testing.T) {
+	mappingConfig := &mappings.MappingConfig{Mappings: map[string]mappings.ActionMappingConfig{
+		"actions.edit.find": {ID: "actions.edit.find", VisualStudio: mappings.VisualStudioMappingConfig{
+			Command:          "Edit.Find",
+			DefaultShortcuts: []string{"Ctrl+F"},
+		}},
+		"actions.edit.findInFiles": {ID: "actions.edit.findInFiles", VisualStudio: mappings.VisualStudioMappingConfig{
+			Command:          "Edit.FindinFiles",
+			DefaultShortcuts: []string{"Ctrl+Shift+F"},
+		}},
+	}}
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+
+	// Edit.Find is not part of the keymap, but its default Ctrl+F must go for Edit.FindinFiles to win
+	setting := keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.findInFiles", "ctrl+f")}}
+	var sb strings.Builder
+	_, err = exporter.Export(context.Background(), &sb, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	doc, err := parseVSSettings(strings.NewReader(sb.String()))
+	require.NoError(t, err)
+	assert.Equal(t, vsUserShortcuts{
+		Shortcuts: []vsShortcut{{Command: "Edit.FindinFiles", Scope: "Global", Keys: "Ctrl+F"}},
+		RemoveShortcuts: []vsShortcut{
+			{Command: "Edit.Find", Scope: "Global", Keys: "Ctrl+F"},
+			{Command: "Edit.FindinFiles", Scope: "Global", Keys: "Ctrl+Shift+F"},
+		},
+	}, doc.UserShortcuts)
+}
+
+func TestExportVisualStudioMetaIsSkipped(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "meta+c")}}
+	var sb strings.Builder
+	report, err := exporter.Export(context.Background(), &sb, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+	require.Len(t, report.SkipReport.SkipActions, 1)
+	assert.Equal(t, "actions.edit.copy", report.SkipReport.SkipActions[0].Action)
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	exporter, err := p.Exporter()
+	require.NoError(t, err)
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.edit.copy", "ctrl+shift+c", "ctrl+k ctrl+/"),
+		newAction("actions.test.childSupported", "ctrl+alt+shift+f5"),
+	}}
+	var sb strings.Builder
+	_, err = exporter.Export(context.Background(), &sb, setting, pluginapi.PluginExportOption{})
+	require.NoError(t, err)
+
+	result, err := importer.Import(context.Background(), strings.NewReader(sb.String()), pluginapi.PluginImportOption{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, setting.Actions, result.Keymap.Actions)
+}
@@ -0,0 +1,79 @@
+package visualstudio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/dedup"
+	"github.com/xinnjie/onekeymap-cli/internal/imports"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+type visualStudioImporter struct {
+	mappingConfig *mappings.MappingConfig
+	logger        *slog.Logger
+	reporter      *metrics.UnknownActionReporter
+}
+
+func newImporter(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) *visualStudioImporter {
+	return &visualStudioImporter{
+		mappingConfig: mappingConfig,
+		logger:        logger,
+		reporter:      metrics.NewUnknownActionReporter(recorder),
+	}
+}
+
+// Import reads a .vssettings file and converts the <Shortcut> entries of its UserShortcuts section
+// into the universal KeymapSetting format. <RemoveShortcut> entries only remove defaults of the
+// keyboard mapping scheme, so there is nothing to import from them.
+func (i *visualStudioImporter) Import(
+	ctx context.Context,
+	source io.Reader,
+	_ pluginapi.PluginImportOption,
+) (pluginapi.PluginImportResult, error) {
+	doc, err := parseVSSettings(source)
+	if err != nil {
+		return pluginapi.PluginImportResult{}, err
+	}
+
+	setting := keymap.Keymap{}
+	marker := imports.NewMarker()
+	for _, s := range doc.UserShortcuts.Shortcuts {
+		kb, err := parseKeybinding(s.Keys)
+		if err != nil {
+			i.logger.DebugContext(ctx, "failed to parse visual studio shortcut", "keys", s.Keys, "error", err)
+			marker.MarkSkipped(s.Command, nil, fmt.Errorf("failed to parse shortcut '%s': %w", s.Keys, err))
+			continue
+		}
+
+		mapping := findMappingByVisualStudio(i.mappingConfig, s.Command, s.Scope)
+		if mapping == nil {
+			i.logger.DebugContext(ctx, "failed to find action", "command", s.Command, "scope", s.Scope)
+			i.reporter.ReportUnknownCommand(ctx, pluginapi.EditorTypeVisualStudio, s.Command)
+			marker.MarkSkipped(s.Command, &kb, pluginapi.ErrActionNotSupported)
+			continue
+		}
+
+		setting.Actions = append(setting.Actions, keymap.Action{
+			Name:     mapping.ID,
+			Bindings: []keybinding.Keybinding{kb},
+		})
+		marker.MarkImported(mapping.ID, s.Command, kb, kb)
+	}
+
+	setting.Actions = dedup.Actions(setting.Actions)
+	result := pluginapi.PluginImportResult{Keymap: setting}
+	result.Report.SkipReport = marker.Report()
+	result.Report.ImportedReport = marker.ImportedReport()
+	return result, nil
+}
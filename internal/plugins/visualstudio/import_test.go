@@ -0,0 +1,107 @@
+package visualstudio
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+// vssettings builds a minimal .vssettings file whose UserShortcuts section holds entries.
+func vssettings(entries ...string) string {
+	var sb strings.Builder
+	sb.WriteString("<UserSettings>\n\t<KeyboardShortcuts>\n\t\t<UserShortcuts>\n")
+	for _, e := range entries {
+		sb.WriteString("\t\t\t" + e + "\n")
+	}
+	sb.WriteString("\t\t</UserShortcuts>\n\t</KeyboardShortcuts>\n</UserSettings>\n")
+	return sb.String()
+}
+
+func TestImportVisualStudioShortcuts(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    []keymap.Action
+		wantSkipped int
+	}{
+		{
+			name: "shortcuts across scopes",
+			input: vssettings(
+				`<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Shift+C</Shortcut>`,
+				`<Shortcut Command="Test.ChildSupported" Scope="Text Editor">Ctrl+K, Ctrl+S</Shortcut>`,
+				// Same command in another scope still resolves to the action
+				`<Shortcut Command="Edit.Copy" Scope="Text Editor">Alt+C</Shortcut>`,
+			),
+			expected: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+shift+c", "alt+c"),
+				newAction("actions.test.childSupported", "ctrl+k ctrl+s"),
+			},
+		},
+		{
+			name: "removed defaults are ignored",
+			input: vssettings(
+				`<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Shift+C</Shortcut>`,
+				`<RemoveShortcut Command="Edit.Copy" Scope="Global">Ctrl+C</RemoveShortcut>`,
+			),
+			expected: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+shift+c"),
+			},
+		},
+		{
+			name: "unknown command and unparsable shortcut are skipped",
+			input: vssettings(
+				`<Shortcut Command="Tools.Unknown" Scope="Global">Ctrl+U</Shortcut>`,
+				`<Shortcut Command="Edit.Cut" Scope="Global">Ctrl+Break</Shortcut>`,
+				`<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Ins</Shortcut>`,
+			),
+			expected: []keymap.Action{
+				newAction("actions.edit.copy", "ctrl+insert"),
+			},
+			wantSkipped: 2,
+		},
+		{
+			name:  "settings without keyboard shortcuts",
+			input: "<UserSettings>\n\t<ToolsOptions/>\n</UserSettings>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+			importer, err := p.Importer()
+			require.NoError(t, err)
+
+			result, err := importer.Import(
+				context.Background(),
+				strings.NewReader(tt.input),
+				pluginapi.PluginImportOption{},
+			)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, result.Keymap.Actions)
+			assert.Len(t, result.Report.SkipReport.SkipActions, tt.wantSkipped)
+		})
+	}
+}
+
+func TestImportVisualStudioInvalidXML(t *testing.T) {
+	mappingConfig, err := mappings.NewTestMappingConfig()
+	require.NoError(t, err)
+	p := New(mappingConfig, slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NewNoop())
+	importer, err := p.Importer()
+	require.NoError(t, err)
+
+	_, err = importer.Import(context.Background(), strings.NewReader("<UserSettings>"), pluginapi.PluginImportOption{})
+	require.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package visualstudio
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/internal/bimap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keychord"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keycode"
+)
+
+const (
+	vsKeyStrokeSeparator = "+"
+	// vsChordSeparator separates the strokes of a chord, e.g. "Ctrl+K, Ctrl+C".
+	vsChordSeparator = ", "
+)
+
+//nolint:gochecknoglobals // static lookup tables shared across parse/format functions; initialized once and read-only
+var (
+	vsModifierNames = map[string]keycode.KeyModifier{
+		"Ctrl":  keycode.KeyModifierCtrl,
+		"Alt":   keycode.KeyModifierAlt,
+		"Shift": keycode.KeyModifierShift,
+	}
+	vsModifierMapping = bimap.NewBiMapFromMap(vsModifierNames)
+
+	// vsModifierOrder is the order used by the Options > Keyboard dialog.
+	vsModifierOrder = []keycode.KeyModifier{
+		keycode.KeyModifierCtrl, keycode.KeyModifierAlt, keycode.KeyModifierShift,
+	}
+
+	// vsKeyNames lists keys whose Visual Studio name differs from ours. Letters are written in
+	// upper case; digits, punctuation and F1-F20 share the same name.
+	vsKeyNames = map[string]keycode.KeyCode{
+		"Up Arrow":    keycode.KeyCodeUp,
+		"Down Arrow":  keycode.KeyCodeDown,
+		"Left Arrow":  keycode.KeyCodeLeft,
+		"Right Arrow": keycode.KeyCodeRight,
+		"PgUp":        keycode.KeyCodePageUp,
+		"PgDn":        keycode.KeyCodePageDown,
+		"Home":        keycode.KeyCodeHome,
+		"End":         keycode.KeyCodeEnd,
+		"Ins":         keycode.KeyCodeInsert,
+		"Del":         keycode.KeyCodeDelete,
+		"Bkspce":      keycode.KeyCodeBackspace,
+		"Enter":       keycode.KeyCodeEnter,
+		"Esc":         keycode.KeyCodeEscape,
+		"Space":       keycode.KeyCodeSpace,
+		"Tab":         keycode.KeyCodeTab,
+		"Num 0":       keycode.KeyCodeNumpad0,
+		"Num 1":       keycode.KeyCodeNumpad1,
+		"Num 2":       keycode.KeyCodeNumpad2,
+		"Num 3":       keycode.KeyCodeNumpad3,
+		"Num 4":       keycode.KeyCodeNumpad4,
+		"Num 5":       keycode.KeyCodeNumpad5,
+		"Num 6":       keycode.KeyCodeNumpad6,
+		"Num 7":       keycode.KeyCodeNumpad7,
+		"Num 8":       keycode.KeyCodeNumpad8,
+		"Num 9":       keycode.KeyCodeNumpad9,
+		"Num +":       keycode.KeyCodeNumpadAdd,
+		"Num -":       keycode.KeyCodeNumpadSubtract,
+		"Num *":       keycode.KeyCodeNumpadMultiply,
+		"Num /":       keycode.KeyCodeNumpadDivide,
+		"Num .":       keycode.KeyCodeNumpadDecimal,
+	}
+	vsKeyMapping = bimap.NewBiMapFromMap(vsKeyNames)
+
+	// vsUnsupportedKeys have no Visual Studio key name.
+	vsUnsupportedKeys = []keycode.KeyCode{
+		keycode.KeyCodeCapsLock, keycode.KeyCodeFn, keycode.KeyCodeShift, keycode.KeyCodeCtrl, keycode.KeyCodeAlt,
+		keycode.KeyCodeCmd, keycode.KeyCodeRightCmd, keycode.KeyCodeRightAlt, keycode.KeyCodeRightCtrl,
+		keycode.KeyCodeRightShift, keycode.KeyCodeMute, keycode.KeyCodeVolumeUp, keycode.KeyCodeVolumeDown,
+		keycode.KeyCodeNumpadClear, keycode.KeyCodeNumpadEnter, keycode.KeyCodeNumpadEquals,
+	}
+)
+
+// formatKeybinding formats a keybinding as a Visual Studio shortcut, e.g. "Ctrl+Shift+F" or "Ctrl+K, Ctrl+C".
+// Visual Studio only runs on Windows, so the meta (Windows) key cannot be bound.
+func formatKeybinding(kb keybinding.Keybinding) (string, error) {
+	strokes := make([]string, 0, len(kb.KeyChords))
+	for _, chord := range kb.KeyChords {
+		if chord.KeyCode == "" || slices.Contains(vsUnsupportedKeys, chord.KeyCode) ||
+			slices.Contains(chord.Modifiers, keycode.KeyModifierMeta) {
+			return "", fmt.Errorf("%w: %q", ErrNotSupportKeyChords, chord.String(keychord.FormatOption{
+				Separator: vsKeyStrokeSeparator,
+			}))
+		}
+
+		var parts []string
+		for _, mod := range vsModifierOrder {
+			if slices.Contains(chord.Modifiers, mod) {
+				name, _ := vsModifierMapping.GetInverse(mod)
+				parts = append(parts, name)
+			}
+		}
+
+		key := strings.ToUpper(string(chord.KeyCode))
+		if name, ok := vsKeyMapping.GetInverse(chord.KeyCode); ok {
+			key = name
+		}
+		parts = append(parts, key)
+		strokes = append(strokes, strings.Join(parts, vsKeyStrokeSeparator))
+	}
+	return strings.Join(strokes, vsChordSeparator), nil
+}
+
+// parseKeybinding parses a Visual Studio shortcut such as "Ctrl+Shift+Up Arrow" or "Ctrl+K, Ctrl+C".
+func parseKeybinding(shortcut string) (keybinding.Keybinding, error) {
+	shortcut = strings.TrimSpace(shortcut)
+	if shortcut == "" {
+		return keybinding.Keybinding{}, errors.New("cannot parse empty shortcut")
+	}
+
+	strokes := strings.Split(shortcut, vsChordSeparator)
+	chords := make([]keychord.KeyChord, 0, len(strokes))
+	for _, s := range strokes {
+		chord, err := parseKeyStroke(strings.TrimSpace(s))
+		if err != nil {
+			return keybinding.Keybinding{}, err
+		}
+		chords = append(chords, chord)
+	}
+	return keybinding.Keybinding{KeyChords: chords}, nil
+}
+
+// parseKeyStroke parses a single stroke like "Ctrl+Shift+F", "Alt+Num +" or "Ctrl++".
+// Modifiers are a known prefix; whatever follows is the key, which may itself contain '+' or spaces.
+func parseKeyStroke(s string) (keychord.KeyChord, error) {
+	chord := keychord.KeyChord{}
+	rest := s
+	for {
+		idx := strings.Index(rest, vsKeyStrokeSeparator)
+		if idx <= 0 {
+			break
+		}
+		mod, ok := lookupModifier(rest[:idx])
+		if !ok {
+			break
+		}
+		if !slices.Contains(chord.Modifiers, mod) {
+			chord.Modifiers = append(chord.Modifiers, mod)
+		}
+		rest = rest[idx+1:]
+	}
+
+	kc, err := fromVSKey(rest)
+	if err != nil {
+		return keychord.KeyChord{}, fmt.Errorf("%w: %q", err, s)
+	}
+	chord.KeyCode = kc
+	return chord, nil
+}
+
+func lookupModifier(name string) (keycode.KeyModifier, bool) {
+	// Names are matched case-insensitively, e.g. "CTRL" or "ctrl" in hand-edited files
+	for candidate, mod := range vsModifierNames {
+		if strings.EqualFold(candidate, name) {
+			return mod, true
+		}
+	}
+	return "", false
+}
+
+func fromVSKey(key string) (keycode.KeyCode, error) {
+	for name, kc := range vsKeyNames {
+		if strings.EqualFold(name, key) {
+			return kc, nil
+		}
+	}
+	kc := keycode.KeyCode(strings.ToLower(key))
+	if kc.IsValid() && !slices.Contains(vsUnsupportedKeys, kc) && !vsKeyMapping.ExistsInverse(kc) {
+		return kc, nil
+	}
+	return "", errors.New("unsupported visual studio key")
+}
@@ -0,0 +1,76 @@
+package visualstudio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+)
+
+func TestFormatKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "Letter", in: "ctrl+c", want: "Ctrl+C"},
+		{name: "ModifierOrder", in: "shift+alt+ctrl+f5", want: "Ctrl+Alt+Shift+F5"},
+		{name: "MultiChord", in: "ctrl+k ctrl+c", want: "Ctrl+K, Ctrl+C"},
+		{name: "NamedKeys", in: "alt+up", want: "Alt+Up Arrow"},
+		{name: "Backspace", in: "alt+backspace", want: "Alt+Bkspce"},
+		{name: "Numpad", in: "ctrl+numpad_add", want: "Ctrl+Num +"},
+		{name: "Punctuation", in: "ctrl+/", want: "Ctrl+/"},
+		{name: "Plus", in: "ctrl++", want: "Ctrl++"},
+		{name: "Meta", in: "meta+c", wantErr: true},
+		{name: "Unsupported", in: "ctrl+volumeup", wantErr: true},
+		{name: "ModifierOnly", in: "shift", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := keybinding.NewKeybinding(tc.in, keybinding.ParseOption{Separator: "+"})
+			require.NoError(t, err)
+			out, err := formatKeybinding(kb)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestParseKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "Simple", in: "Ctrl+Shift+F", want: "ctrl+shift+f"},
+		{name: "MultiChord", in: "Ctrl+K, Ctrl+C", want: "ctrl+k ctrl+c"},
+		{name: "CaseInsensitive", in: "CTRL+shift+r", want: "ctrl+shift+r"},
+		{name: "KeyWithSpace", in: "Ctrl+Shift+Up Arrow", want: "ctrl+shift+up"},
+		{name: "NumpadPlus", in: "Alt+Num +", want: "alt+numpad_add"},
+		{name: "Plus", in: "Ctrl++", want: "ctrl++"},
+		{name: "Function", in: "F12", want: "f12"},
+		{name: "UnknownKey", in: "Ctrl+Break", wantErr: true},
+		{name: "UnknownModifier", in: "Win+K", wantErr: true},
+		{name: "Empty", in: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kb, err := parseKeybinding(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, kb.String(keybinding.FormatOption{Separator: "+"}))
+		})
+	}
+}
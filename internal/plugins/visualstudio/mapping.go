@@ -0,0 +1,51 @@
+package visualstudio
+
+import (
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+// findMappingByVisualStudio finds the action for a Visual Studio command bound in the given scope.
+// Configs whose scope also matches are preferred over those with a different scope;
+// ties are broken by action ID to keep the result deterministic.
+func findMappingByVisualStudio(
+	mappingConfig *mappings.MappingConfig,
+	command, scope string,
+) *mappings.ActionMappingConfig {
+	var exact, ignoreScope []string
+	for id, mapping := range mappingConfig.Mappings {
+		vc := mapping.VisualStudio
+		if vc.DisableImport || vc.Command == "" || vc.Command != command {
+			continue
+		}
+		if vc.EffectiveScope() == scope {
+			exact = append(exact, id)
+		} else {
+			ignoreScope = append(ignoreScope, id)
+		}
+	}
+
+	candidates := exact
+	if len(candidates) == 0 {
+		candidates = ignoreScope
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Strings(candidates)
+	m := mappingConfig.Mappings[candidates[0]]
+	return &m
+}
+
+// isManagedShortcut reports whether a shortcut from an existing settings file
+// corresponds exactly to one of our action mappings, including export-only ones.
+func isManagedShortcut(mappingConfig *mappings.MappingConfig, s vsShortcut) bool {
+	for _, mapping := range mappingConfig.Mappings {
+		vc := mapping.VisualStudio
+		if vc.Command != "" && vc.Command == s.Command && vc.EffectiveScope() == s.Scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package visualstudio
+
+import (
+	"log/slog"
+
+	"github.com/xinnjie/onekeymap-cli/internal/diff"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
+)
+
+var _ pluginapi.Plugin = (*visualStudioPlugin)(nil)
+
+// visualStudioPlugin implements the plugins.Plugin interface for Visual Studio.
+type visualStudioPlugin struct {
+	mappingConfig *mappings.MappingConfig
+	importer      pluginapi.PluginImporter
+	exporter      pluginapi.PluginExporter
+	logger        *slog.Logger
+}
+
+// New creates a new Visual Studio plugin instance.
+func New(mappingConfig *mappings.MappingConfig, logger *slog.Logger, recorder metrics.Recorder) pluginapi.Plugin {
+	return &visualStudioPlugin{
+		mappingConfig: mappingConfig,
+		importer:      newImporter(mappingConfig, logger, recorder),
+		exporter:      newExporter(mappingConfig, logger, diff.NewJSONASCIIDiffer()),
+		logger:        logger,
+	}
+}
+
+// EditorType returns the unique identifier for Visual Studio.
+func (p *visualStudioPlugin) EditorType() pluginapi.EditorType {
+	return pluginapi.EditorTypeVisualStudio
+}
+
+// Importer returns the importer for this plugin.
+func (p *visualStudioPlugin) Importer() (pluginapi.PluginImporter, error) { return p.importer, nil }
+
+// Exporter returns the exporter for this plugin.
+func (p *visualStudioPlugin) Exporter() (pluginapi.PluginExporter, error) { return p.exporter, nil }
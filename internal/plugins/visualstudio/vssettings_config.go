@@ -0,0 +1,187 @@
+package visualstudio
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// vsShortcut is a <Shortcut> or <RemoveShortcut> element, e.g.
+// <Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Shift+C</Shortcut>.
+type vsShortcut struct {
+	Command string `xml:"Command,attr" json:"command"`
+	Scope   string `xml:"Scope,attr"   json:"scope"`
+	Keys    string `xml:",chardata"    json:"keys"`
+}
+
+// vsUserShortcuts is the <UserShortcuts> section: shortcuts added on top of the keyboard
+// mapping scheme, and default shortcuts removed from it.
+type vsUserShortcuts struct {
+	Shortcuts       []vsShortcut `xml:"Shortcut"       json:"shortcuts,omitempty"`
+	RemoveShortcuts []vsShortcut `xml:"RemoveShortcut" json:"removeShortcuts,omitempty"`
+}
+
+// vssettingsDocument is a .vssettings file. Only its <UserShortcuts> section is interpreted;
+// everything else is kept as raw text so that other settings survive an export.
+type vssettingsDocument struct {
+	raw string
+	// userShortcutsStart and userShortcutsEnd delimit the <UserShortcuts> element in raw, or are -1.
+	userShortcutsStart, userShortcutsEnd int
+	// keyboardShortcutsClose and userSettingsClose are the offsets of the closing tags in raw, or -1.
+	keyboardShortcutsClose, userSettingsClose int
+
+	UserShortcuts vsUserShortcuts
+}
+
+const utf8BOM = "\ufeff"
+
+const vssettingsTemplate = `<?xml version="1.0" encoding="utf-8"?>
+<UserSettings>
+	<ApplicationIdentity version="17.0"/>
+	<ToolsOptions/>
+	<Category name="Environment_Group" RegisteredName="Environment_Group">
+		<Category name="Environment_KeyBindings" Category="{F09035F1-80D2-4312-8EC4-4D354A4BCB4C}" Package="{DA9FB551-C724-11d0-AE1F-00A0C90FFFC3}" RegisteredName="Environment_KeyBindings" PackageName="Visual Studio Environment Package">
+			<Version>17.0.0.0</Version>
+			<KeyboardShortcuts>
+				%s
+			</KeyboardShortcuts>
+		</Category>
+	</Category>
+</UserSettings>
+`
+
+// keyBindingsCategoryTemplate is inserted into an existing settings file that has no keyboard settings yet.
+const keyBindingsCategoryTemplate = `<Category name="Environment_Group" RegisteredName="Environment_Group">
+%[1]s	<Category name="Environment_KeyBindings" Category="{F09035F1-80D2-4312-8EC4-4D354A4BCB4C}" Package="{DA9FB551-C724-11d0-AE1F-00A0C90FFFC3}" RegisteredName="Environment_KeyBindings" PackageName="Visual Studio Environment Package">
+%[1]s		<KeyboardShortcuts>
+%[1]s			%[2]s
+%[1]s		</KeyboardShortcuts>
+%[1]s	</Category>
+%[1]s</Category>`
+
+func parseVSSettings(reader io.Reader) (*vssettingsDocument, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vssettings: %w", err)
+	}
+	doc := &vssettingsDocument{
+		raw:                    string(data),
+		userShortcutsStart:     -1,
+		userShortcutsEnd:       -1,
+		keyboardShortcutsClose: -1,
+		userSettingsClose:      -1,
+	}
+	if strings.TrimSpace(doc.raw) == "" {
+		return doc, nil
+	}
+
+	// Visual Studio writes a UTF-8 byte order mark, which the XML decoder does not accept
+	bom := 0
+	if bytes.HasPrefix(data, []byte(utf8BOM)) {
+		bom = len(utf8BOM)
+	}
+	dec := xml.NewDecoder(bytes.NewReader(data[bom:]))
+	var parents []string
+	for {
+		offset := bom + int(dec.InputOffset())
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse vssettings: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "UserShortcuts" && len(parents) > 0 && parents[len(parents)-1] == "KeyboardShortcuts" {
+				if err := dec.DecodeElement(&doc.UserShortcuts, &t); err != nil {
+					return nil, fmt.Errorf("failed to parse UserShortcuts: %w", err)
+				}
+				doc.userShortcutsStart, doc.userShortcutsEnd = offset, bom+int(dec.InputOffset())
+				continue
+			}
+			parents = append(parents, t.Name.Local)
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "KeyboardShortcuts":
+				doc.keyboardShortcutsClose = offset
+			case "UserSettings":
+				doc.userSettingsClose = offset
+			}
+			parents = parents[:len(parents)-1]
+		}
+	}
+	if doc.userSettingsClose < 0 {
+		return nil, errors.New("failed to parse vssettings: missing UserSettings element")
+	}
+	return doc, nil
+}
+
+// write renders the document with its <UserShortcuts> section replaced by shortcuts.
+// Missing sections are created, keeping the indentation of the surrounding elements.
+func (d *vssettingsDocument) write(w io.Writer, shortcuts vsUserShortcuts) error {
+	var out string
+	switch {
+	case d.userShortcutsStart >= 0:
+		indent := lineIndent(d.raw, d.userShortcutsStart)
+		out = d.raw[:d.userShortcutsStart] + formatUserShortcuts(shortcuts, indent) + d.raw[d.userShortcutsEnd:]
+	case d.keyboardShortcutsClose >= 0:
+		indent := lineIndent(d.raw, d.keyboardShortcutsClose)
+		out = d.raw[:d.keyboardShortcutsClose] + "\t" + formatUserShortcuts(shortcuts, indent+"\t") + "\n" + indent +
+			d.raw[d.keyboardShortcutsClose:]
+	case d.userSettingsClose >= 0:
+		closeIndent := lineIndent(d.raw, d.userSettingsClose)
+		indent := closeIndent + "\t"
+		category := fmt.Sprintf(keyBindingsCategoryTemplate, indent, formatUserShortcuts(shortcuts, indent+"\t\t\t"))
+		out = d.raw[:d.userSettingsClose] + "\t" + category + "\n" + closeIndent + d.raw[d.userSettingsClose:]
+	default:
+		out = fmt.Sprintf(vssettingsTemplate, formatUserShortcuts(shortcuts, "\t\t\t\t"))
+	}
+
+	if _, err := io.WriteString(w, out); err != nil {
+		return fmt.Errorf("failed to write vssettings: %w", err)
+	}
+	return nil
+}
+
+// formatUserShortcuts renders the <UserShortcuts> element; indent is the indentation of its own line.
+func formatUserShortcuts(shortcuts vsUserShortcuts, indent string) string {
+	if len(shortcuts.Shortcuts) == 0 && len(shortcuts.RemoveShortcuts) == 0 {
+		return "<UserShortcuts/>"
+	}
+	var sb strings.Builder
+	sb.WriteString("<UserShortcuts>\n")
+	for _, s := range shortcuts.Shortcuts {
+		writeShortcut(&sb, indent+"\t", "Shortcut", s)
+	}
+	for _, s := range shortcuts.RemoveShortcuts {
+		writeShortcut(&sb, indent+"\t", "RemoveShortcut", s)
+	}
+	sb.WriteString(indent)
+	sb.WriteString("</UserShortcuts>")
+	return sb.String()
+}
+
+func writeShortcut(sb *strings.Builder, indent, element string, s vsShortcut) {
+	fmt.Fprintf(sb, "%s<%s Command=\"%s\" Scope=\"%s\">%s</%s>\n",
+		indent, element, xmlEscape(s.Command), xmlEscape(s.Scope), xmlEscape(s.Keys), element)
+}
+
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// lineIndent returns the whitespace between the start of the line containing offset and offset.
+func lineIndent(raw string, offset int) string {
+	start := strings.LastIndex(raw[:offset], "\n") + 1
+	indent := raw[start:offset]
+	if strings.TrimLeft(indent, " \t") != "" {
+		return ""
+	}
+	return indent
+}
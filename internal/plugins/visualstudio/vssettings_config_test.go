@@ -0,0 +1,154 @@
+package visualstudio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVSSettings(t *testing.T) {
+	input := utf8BOM + `<?xml version="1.0" encoding="utf-8"?>
+<UserSettings>
+	<Category name="Environment_Group" RegisteredName="Environment_Group">
+		<Category name="Environment_KeyBindings" RegisteredName="Environment_KeyBindings">
+			<KeyboardShortcuts>
+				<ScopeDefinitions/>
+				<UserShortcuts>
+					<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Shift+C</Shortcut>
+					<RemoveShortcut Command="Edit.Copy" Scope="Global">Ctrl+C</RemoveShortcut>
+				</UserShortcuts>
+			</KeyboardShortcuts>
+		</Category>
+	</Category>
+</UserSettings>
+`
+	doc, err := parseVSSettings(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, vsUserShortcuts{
+		Shortcuts:       []vsShortcut{{Command: "Edit.Copy", Scope: "Global", Keys: "Ctrl+Shift+C"}},
+		RemoveShortcuts: []vsShortcut{{Command: "Edit.Copy", Scope: "Global", Keys: "Ctrl+C"}},
+	}, doc.UserShortcuts)
+	assert.True(t, strings.HasPrefix(input[doc.userShortcutsStart:], "<UserShortcuts>"))
+	assert.True(t, strings.HasPrefix(input[doc.userShortcutsEnd:], "\n\t\t\t</KeyboardShortcuts>"))
+
+	_, err = parseVSSettings(strings.NewReader(`<Settings/>`))
+	require.Error(t, err)
+	_, err = parseVSSettings(strings.NewReader(`<UserSettings>`))
+	require.Error(t, err)
+}
+
+func TestVSSettingsWrite(t *testing.T) {
+	shortcuts := vsUserShortcuts{
+		Shortcuts:       []vsShortcut{{Command: "Edit.Copy", Scope: "Global", Keys: "Ctrl+Shift+C"}},
+		RemoveShortcuts: []vsShortcut{{Command: "Edit.Copy", Scope: "Global", Keys: "Ctrl+C"}},
+	}
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name: "replaces UserShortcuts",
+			existing: `<UserSettings>
+	<ToolsOptions/>
+	<KeyboardShortcuts>
+		<UserShortcuts>
+			<Shortcut Command="Edit.Cut" Scope="Global">Ctrl+X</Shortcut>
+		</UserShortcuts>
+	</KeyboardShortcuts>
+</UserSettings>
+`,
+			want: `<UserSettings>
+	<ToolsOptions/>
+	<KeyboardShortcuts>
+		<UserShortcuts>
+			<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Shift+C</Shortcut>
+			<RemoveShortcut Command="Edit.Copy" Scope="Global">Ctrl+C</RemoveShortcut>
+		</UserShortcuts>
+	</KeyboardShortcuts>
+</UserSettings>
+`,
+		},
+		{
+			name: "adds UserShortcuts to KeyboardShortcuts",
+			existing: `<UserSettings>
+	<KeyboardShortcuts>
+		<ShortcutsScheme>Visual C#</ShortcutsScheme>
+	</KeyboardShortcuts>
+</UserSettings>
+`,
+			want: `<UserSettings>
+	<KeyboardShortcuts>
+		<ShortcutsScheme>Visual C#</ShortcutsScheme>
+		<UserShortcuts>
+			<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Shift+C</Shortcut>
+			<RemoveShortcut Command="Edit.Copy" Scope="Global">Ctrl+C</RemoveShortcut>
+		</UserShortcuts>
+	</KeyboardShortcuts>
+</UserSettings>
+`,
+		},
+		{
+			name: "adds keyboard settings category",
+			existing: `<UserSettings>
+	<ToolsOptions/>
+</UserSettings>
+`,
+			want: `<UserSettings>
+	<ToolsOptions/>
+	<Category name="Environment_Group" RegisteredName="Environment_Group">
+		<Category name="Environment_KeyBindings" Category="{F09035F1-80D2-4312-8EC4-4D354A4BCB4C}" Package="{DA9FB551-C724-11d0-AE1F-00A0C90FFFC3}" RegisteredName="Environment_KeyBindings" PackageName="Visual Studio Environment Package">
+			<KeyboardShortcuts>
+				<UserShortcuts>
+					<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Shift+C</Shortcut>
+					<RemoveShortcut Command="Edit.Copy" Scope="Global">Ctrl+C</RemoveShortcut>
+				</UserShortcuts>
+			</KeyboardShortcuts>
+		</Category>
+	</Category>
+</UserSettings>
+`,
+		},
+		{
+			name:     "expands empty UserShortcuts",
+			existing: `<UserSettings><KeyboardShortcuts><UserShortcuts/></KeyboardShortcuts></UserSettings>`,
+			want: `<UserSettings><KeyboardShortcuts><UserShortcuts>
+	<Shortcut Command="Edit.Copy" Scope="Global">Ctrl+Shift+C</Shortcut>
+	<RemoveShortcut Command="Edit.Copy" Scope="Global">Ctrl+C</RemoveShortcut>
+</UserShortcuts></KeyboardShortcuts></UserSettings>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parseVSSettings(strings.NewReader(tt.existing))
+			require.NoError(t, err)
+			var sb strings.Builder
+			require.NoError(t, doc.write(&sb, shortcuts))
+			assert.Equal(t, tt.want, sb.String())
+
+			// The written file must parse back to the same shortcuts
+			reparsed, err := parseVSSettings(strings.NewReader(sb.String()))
+			require.NoError(t, err)
+			assert.Equal(t, shortcuts, reparsed.UserShortcuts)
+		})
+	}
+}
+
+func TestVSSettingsWriteNewFile(t *testing.T) {
+	doc, err := parseVSSettings(strings.NewReader(""))
+	require.NoError(t, err)
+	var sb strings.Builder
+	shortcuts := vsUserShortcuts{
+		Shortcuts: []vsShortcut{{Command: "Edit.Find", Scope: "Text Editor", Keys: "Ctrl+Alt+F"}},
+	}
+	require.NoError(t, doc.write(&sb, shortcuts))
+
+	reparsed, err := parseVSSettings(strings.NewReader(sb.String()))
+	require.NoError(t, err)
+	assert.Equal(t, shortcuts, reparsed.UserShortcuts)
+	assert.Contains(t, sb.String(), `<Category name="Environment_KeyBindings"`)
+}
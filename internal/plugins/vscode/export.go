@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"slices"
 	"sort"
 
 	"github.com/tailscale/hujson"
@@ -176,8 +177,13 @@ func (e *vscodeLikeExporter) identifyUnmanagedKeybindings(existingKeybindings []
 // findMappingByVSCodeKeybinding performs reverse lookup to find if a VSCode keybinding
 // corresponds to any action in our mappings.
 func (e *vscodeLikeExporter) findMappingByVSCodeKeybinding(kb vscodeKeybinding) *mappings.ActionMappingConfig {
+	removed, isRemoval := removedCommand(kb)
 	for _, mapping := range e.mappingConfig.Mappings {
 		for _, vscodeConfig := range mapping.GetVSCodeConfigs(e.editorType) {
+			// Removal rules of managed commands are regenerated from unbind entries.
+			if isRemoval && vscodeConfig.Command == removed {
+				return &mapping
+			}
 			if vscodeConfig.Command == kb.Command &&
 				vscodeConfig.When == kb.When &&
 				equalVSCodeArgs(vscodeConfig.Args, kb.Args) {
@@ -240,6 +246,20 @@ func (e *vscodeLikeExporter) identifyManagedKeybindings(
 				continue
 			}
 			marker.MarkExported(km.Name, b)
+			if !km.Scope.IsZero() {
+				// A scope replaces the mapping's when clauses, so only the primary command is bound.
+				if i := slices.IndexFunc(vscodeConfigs, func(vc mappings.VscodeMappingConfig) bool {
+					return vc.Command != ""
+				}); i >= 0 {
+					vscodeKeybindings = append(vscodeKeybindings, vscodeKeybinding{
+						Key:     keys,
+						Command: vscodeConfigs[i].Command,
+						When:    formatWhen(km.Scope),
+						Args:    vscodeConfigs[i].Args,
+					})
+				}
+				continue
+			}
 			for _, vscodeConfig := range vscodeConfigs {
 				if vscodeConfig.Command == "" {
 					continue
@@ -252,6 +272,7 @@ func (e *vscodeLikeExporter) identifyManagedKeybindings(
 				})
 			}
 		}
+		vscodeKeybindings = append(vscodeKeybindings, removalKeybindings(km, vscodeConfigs, targetPlatform)...)
 	}
 
 	return vscodeKeybindings
@@ -262,6 +283,10 @@ func (e *vscodeLikeExporter) nonDestructiveMerge(managed, unmanaged []vscodeKeyb
 	// Create a map to track managed keybindings by their key combination
 	managedKeys := make(map[string]bool)
 	for _, kb := range managed {
+		// A removal rule frees its key rather than occupying it.
+		if _, isRemoval := removedCommand(kb); isRemoval {
+			continue
+		}
 		managedKeys[kb.Key] = true
 	}
 
@@ -271,7 +296,8 @@ func (e *vscodeLikeExporter) nonDestructiveMerge(managed, unmanaged []vscodeKeyb
 
 	// Add unmanaged keybindings that don't conflict with managed ones
 	for _, kb := range unmanaged {
-		if !managedKeys[kb.Key] {
+		_, isRemoval := removedCommand(kb)
+		if isRemoval || !managedKeys[kb.Key] {
 			result = append(result, kb)
 		} else {
 			// Log conflict - managed keybinding takes priority
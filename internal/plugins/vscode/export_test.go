@@ -55,6 +55,86 @@ func TestExporter_Export(t *testing.T) {
 			  }
 			]`,
 		},
+		{
+			name: "scope replaces the mapping when clause",
+			keymapSetting: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.edit.copy",
+						Bindings: []keybinding.Keybinding{parseKB("meta+c")},
+						Scope:    "editorFocus && !terminalFocus && vimNormal",
+					},
+				},
+			},
+			expectedJSON: `[
+			  {
+			    "key": "cmd+c",
+			    "command": "editor.action.clipboardCopyAction",
+			    "when": "editorTextFocus && !terminalFocus && vim.mode == 'Normal'"
+			  }
+			]`,
+		},
+		{
+			name: "unbind entries become removal rules",
+			keymapSetting: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.edit.copy",
+						Bindings: []keybinding.Keybinding{parseKB("meta+c")},
+						Unbind:   []keybinding.Keybinding{parseKB("meta+insert")},
+					},
+					{
+						Name:      "actions.test.mutipleActions",
+						UnbindAll: true,
+					},
+				},
+			},
+			expectedJSON: `[
+			  {
+			    "key": "cmd+c",
+			    "command": "editor.action.clipboardCopyAction",
+			    "when": "editorTextFocus && condition > 0"
+			  },
+			  {
+			    "key": "cmd+insert",
+			    "command": "-editor.action.clipboardCopyAction"
+			  },
+			  {
+			    "key": "",
+			    "command": "-command1"
+			  },
+			  {
+			    "key": "",
+			    "command": "-command2"
+			  }
+			]`,
+		},
+		{
+			name: "removal rules of managed commands are regenerated",
+			keymapSetting: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.edit.copy",
+						Bindings: []keybinding.Keybinding{parseKB("meta+c")},
+					},
+				},
+			},
+			existingConfig: `[
+				{"key": "cmd+insert", "command": "-editor.action.clipboardCopyAction"},
+				{"key": "cmd+c", "command": "-custom.user.command"}
+			]`,
+			expectedJSON: `[
+			  {
+			    "key": "cmd+c",
+			    "command": "editor.action.clipboardCopyAction",
+			    "when": "editorTextFocus && condition > 0"
+			  },
+			  {
+			    "key": "cmd+c",
+			    "command": "-custom.user.command"
+			  }
+			]`,
+		},
 		{
 			name: "correctly exports multiple actions",
 			keymapSetting: keymap.Keymap{
@@ -529,6 +609,9 @@ func TestExporter_Export_VSCodeVariant(t *testing.T) {
 		Cursor: mappings.VscodeConfigs{
 			{Command: "cursor.specific.command", When: "cursorContext"},
 		},
+		VSCodium: mappings.VscodeConfigs{
+			{Command: "vscodium.specific.command", When: "editorTextFocus"},
+		},
 	}
 
 	mappingConfig.Mappings["actions.test.variantFallback"] = mappings.ActionMappingConfig{
@@ -668,6 +751,44 @@ func TestExporter_Export_VSCodeVariant(t *testing.T) {
 				}
 			]`,
 		},
+		{
+			name:       "vscodium uses vscodium-specific config",
+			editorType: pluginapi.EditorTypeVSCodium,
+			keymapSetting: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.test.variantConfig",
+						Bindings: []keybinding.Keybinding{parseKB("meta+m")},
+					},
+				},
+			},
+			expectedJSON: `[
+				{
+					"key": "cmd+m",
+					"command": "vscodium.specific.command",
+					"when": "editorTextFocus"
+				}
+			]`,
+		},
+		{
+			name:       "positron falls back to vscode config when no positron config",
+			editorType: pluginapi.EditorTypePositron,
+			keymapSetting: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.test.variantConfig",
+						Bindings: []keybinding.Keybinding{parseKB("meta+m")},
+					},
+				},
+			},
+			expectedJSON: `[
+				{
+					"key": "cmd+m",
+					"command": "vscode.default.command",
+					"when": "editorTextFocus"
+				}
+			]`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -683,6 +804,10 @@ func TestExporter_Export_VSCodeVariant(t *testing.T) {
 				plugin = NewWindsurfNext(mappingConfig, logger, recorder)
 			case pluginapi.EditorTypeCursor:
 				plugin = NewCursor(mappingConfig, logger, recorder)
+			case pluginapi.EditorTypeVSCodium:
+				plugin = NewVSCodium(mappingConfig, logger, recorder)
+			case pluginapi.EditorTypePositron:
+				plugin = NewPositron(mappingConfig, logger, recorder)
 			default:
 				plugin = New(mappingConfig, logger, recorder)
 			}
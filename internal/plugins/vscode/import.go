@@ -59,6 +59,15 @@ func (i *vscodeLikeImporter) Import(
 	setting := keymap.Keymap{}
 	marker := imports.NewMarker()
 	for _, binding := range vscodeKeybindings {
+		if command, isRemoval := removedCommand(binding); isRemoval {
+			if action, ok := i.importRemoval(ctx, binding, command, opts); ok {
+				setting.Actions = append(setting.Actions, action)
+			} else {
+				marker.MarkSkipped(binding.Command, nil, pluginapi.ErrActionNotSupported)
+			}
+			continue
+		}
+
 		kb, err := ParseKeybinding(binding.Key, opts.SourcePlatform)
 		if err != nil {
 			i.logger.WarnContext(ctx, "Skipping keybinding with unparsable key", "key", binding.Key, "error", err)
@@ -91,6 +100,7 @@ func (i *vscodeLikeImporter) Import(
 			Bindings: []keybinding.Keybinding{
 				*kb,
 			},
+			Scope: i.scopeOf(mapping, binding),
 		}
 		setting.Actions = append(setting.Actions, newKeymap)
 
@@ -104,3 +114,45 @@ func (i *vscodeLikeImporter) Import(
 	result.Report.ImportedReport = marker.ImportedReport()
 	return result, nil
 }
+
+// importRemoval turns a removal rule of a mapped command into an unbind entry of its action.
+func (i *vscodeLikeImporter) importRemoval(
+	ctx context.Context,
+	binding vscodeKeybinding,
+	command string,
+	opts pluginapi.PluginImportOption,
+) (keymap.Action, bool) {
+	mapping := i.FindByVSCodeActionWithArgs(command, binding.When, binding.Args)
+	if mapping == nil {
+		i.logger.DebugContext(ctx, "Skipping removal rule of unknown action", "action", command)
+		return keymap.Action{}, false
+	}
+	if binding.Key == "" {
+		return keymap.Action{Name: mapping.ID, UnbindAll: true}, true
+	}
+	kb, err := ParseKeybinding(binding.Key, opts.SourcePlatform)
+	if err != nil {
+		i.logger.WarnContext(ctx, "Skipping removal rule with unparsable key", "key", binding.Key, "error", err)
+		return keymap.Action{}, false
+	}
+	return keymap.Action{Name: mapping.ID, Unbind: []keybinding.Keybinding{*kb}}, true
+}
+
+// scopeOf returns the scope of a binding whose `when` differs from the mapping's own clauses.
+// Clauses that cannot be expressed as a scope are dropped, as before scopes existed.
+func (i *vscodeLikeImporter) scopeOf(mapping *mappings.ActionMappingConfig, binding vscodeKeybinding) keymap.Scope {
+	if binding.When == "" {
+		return ""
+	}
+	for _, vc := range mapping.GetVSCodeConfigs(i.editorType) {
+		if vc.Command == binding.Command && vc.When == binding.When {
+			return ""
+		}
+	}
+	scope, ok := scopeFromWhen(binding.When)
+	if !ok {
+		i.logger.Debug("Dropping when clause that has no scope equivalent", "command", binding.Command, "when", binding.When)
+		return ""
+	}
+	return scope
+}
@@ -46,7 +46,44 @@ func TestImporter_Import(t *testing.T) {
 				{
 					"key": "cmd+c",
 					"command": "editor.action.clipboardCopyAction",
-					"when": "editorTextFocus"
+					"when": "editorTextFocus && condition > 0"
+				}
+			]`,
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.edit.copy",
+						Bindings: []keybinding.Keybinding{parseKB("meta+c")},
+					},
+				},
+			},
+		},
+		{
+			name: "when clause expressible as a scope is preserved",
+			jsonContent: `[
+				{
+					"key": "cmd+c",
+					"command": "editor.action.clipboardCopyAction",
+					"when": "editorTextFocus && vim.mode != \"Visual\""
+				}
+			]`,
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.edit.copy",
+						Bindings: []keybinding.Keybinding{parseKB("meta+c")},
+						Scope:    "editorFocus && !vimVisual",
+					},
+				},
+			},
+		},
+		{
+			name: "when clause without a scope equivalent is dropped",
+			jsonContent: `[
+				{
+					"key": "cmd+c",
+					"command": "editor.action.clipboardCopyAction",
+					"when": "editorTextFocus || inQuickOpen"
 				}
 			]`,
 			expected: keymap.Keymap{
@@ -64,12 +101,12 @@ func TestImporter_Import(t *testing.T) {
 				{
 					"key": "cmd+c",
 					"command": "editor.action.clipboardCopyAction",
-					"when": "editorTextFocus"
+					"when": "editorTextFocus && condition > 0"
 				},
 				{
 					"key": "ctrl+c",
 					"command": "editor.action.clipboardCopyAction",
-					"when": "editorTextFocus"
+					"when": "editorTextFocus && condition > 0"
 				}
 			]`,
 			expected: keymap.Keymap{
@@ -89,7 +126,7 @@ func TestImporter_Import(t *testing.T) {
 			    {
 			        "key": "cmd+k up",
 			        "command": "editor.action.clipboardCopyAction",
-			        "when": "editorTextFocus"
+			        "when": "editorTextFocus && condition > 0"
 			    }
 			]`,
 			expected: keymap.Keymap{
@@ -109,7 +146,7 @@ func TestImporter_Import(t *testing.T) {
 			    {
 			        "key": "shift shift",
 			        "command": "editor.action.clipboardCopyAction",
-			        "when": "editorTextFocus"
+			        "when": "editorTextFocus && condition > 0"
 			    }
 			]`,
 			expected: keymap.Keymap{
@@ -127,7 +164,7 @@ func TestImporter_Import(t *testing.T) {
 			jsonContent: `{
 				"key": "cmd+c",
 				"command": "editor.action.clipboardCopyAction",
-				"when": "editorTextFocus"
+				"when": "editorTextFocus && condition > 0"
 			}`,
 			expectError: true,
 		},
@@ -178,6 +215,35 @@ func TestImporter_Import(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "removal rules become unbind entries",
+			jsonContent: `[
+				{
+					"key": "cmd+insert",
+					"command": "-editor.action.clipboardCopyAction"
+				},
+				{
+					"key": "",
+					"command": "-command1"
+				},
+				{
+					"key": "cmd+k",
+					"command": "-unknown.command"
+				}
+			]`,
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:   "actions.edit.copy",
+						Unbind: []keybinding.Keybinding{parseKB("meta+insert")},
+					},
+					{
+						Name:      "actions.test.mutipleActions",
+						UnbindAll: true,
+					},
+				},
+			},
+		},
 		{
 			name: "Command with args",
 			jsonContent: `[
@@ -204,7 +270,7 @@ func TestImporter_Import(t *testing.T) {
 				{
 					"key": "cmd+c",
 					"command": "editor.action.clipboardCopyAction",
-					"when": "editorTextFocus"
+					"when": "editorTextFocus && condition > 0"
 				},
 				{
 					"key": "cmd+end",
@@ -329,6 +395,9 @@ func TestImporter_Import_VSCodeVariant(t *testing.T) {
 		Cursor: mappings.VscodeConfigs{
 			{Command: "cursor.specific.command", When: "cursorContext"},
 		},
+		VSCodium: mappings.VscodeConfigs{
+			{Command: "vscodium.specific.command", When: "editorTextFocus"},
+		},
 	}
 
 	mappingConfig.Mappings["actions.test.variantFallback"] = mappings.ActionMappingConfig{
@@ -468,6 +537,44 @@ func TestImporter_Import_VSCodeVariant(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "vscodium uses vscodium-specific config",
+			editorType: pluginapi.EditorTypeVSCodium,
+			jsonContent: `[
+				{
+					"key": "cmd+m",
+					"command": "vscodium.specific.command",
+					"when": "editorTextFocus"
+				}
+			]`,
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.test.variantConfig",
+						Bindings: []keybinding.Keybinding{parseKB("meta+m")},
+					},
+				},
+			},
+		},
+		{
+			name:       "positron falls back to vscode config when no positron config",
+			editorType: pluginapi.EditorTypePositron,
+			jsonContent: `[
+				{
+					"key": "cmd+m",
+					"command": "vscode.default.command",
+					"when": "editorTextFocus"
+				}
+			]`,
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.test.variantConfig",
+						Bindings: []keybinding.Keybinding{parseKB("meta+m")},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -483,6 +590,10 @@ func TestImporter_Import_VSCodeVariant(t *testing.T) {
 				plugin = NewWindsurfNext(mappingConfig, logger, recorder)
 			case pluginapi.EditorTypeCursor:
 				plugin = NewCursor(mappingConfig, logger, recorder)
+			case pluginapi.EditorTypeVSCodium:
+				plugin = NewVSCodium(mappingConfig, logger, recorder)
+			case pluginapi.EditorTypePositron:
+				plugin = NewPositron(mappingConfig, logger, recorder)
 			default:
 				plugin = New(mappingConfig, logger, recorder)
 			}
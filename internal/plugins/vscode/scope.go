@@ -0,0 +1,68 @@
+package vscode
+
+import (
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+)
+
+//nolint:gochecknoglobals // static lookup table; initialized once and read-only
+var vscodeScopeClauses = map[keymap.ScopePredicate]string{
+	keymap.ScopeEditorFocus:   "editorTextFocus",
+	keymap.ScopeTerminalFocus: "terminalFocus",
+	keymap.ScopeHasSelection:  "editorHasSelection",
+	keymap.ScopeVimNormal:     "vim.mode == 'Normal'",
+	keymap.ScopeVimInsert:     "vim.mode == 'Insert'",
+	keymap.ScopeVimVisual:     "vim.mode == 'Visual'",
+}
+
+// formatWhen translates a scope to a `when` clause, e.g. "editorTextFocus && vim.mode == 'Normal'".
+func formatWhen(scope keymap.Scope) string {
+	terms := scope.Terms()
+	clauses := make([]string, 0, len(terms))
+	for _, t := range terms {
+		clause := vscodeScopeClauses[t.Predicate]
+		if t.Negated {
+			if strings.Contains(clause, " == ") {
+				clause = strings.Replace(clause, " == ", " != ", 1)
+			} else {
+				clause = "!" + clause
+			}
+		}
+		clauses = append(clauses, clause)
+	}
+	return strings.Join(clauses, " && ")
+}
+
+// scopeFromWhen translates a `when` clause back to a scope. It only succeeds for conjunctions of
+// clauses produced by formatWhen; anything else, such as `||` or unknown context keys, reports false.
+func scopeFromWhen(when string) (keymap.Scope, bool) {
+	if strings.TrimSpace(when) == "" || strings.ContainsAny(when, "|()") {
+		return "", false
+	}
+	var terms []keymap.ScopeTerm
+	for _, clause := range strings.Split(when, "&&") {
+		clause = strings.Join(strings.Fields(strings.ReplaceAll(clause, `"`, "'")), " ")
+		term := keymap.ScopeTerm{}
+		if rest, ok := strings.CutPrefix(clause, "!"); ok {
+			term.Negated = true
+			clause = strings.TrimSpace(rest)
+		} else if strings.Contains(clause, " != ") {
+			term.Negated = true
+			clause = strings.Replace(clause, " != ", " == ", 1)
+		}
+		found := false
+		for predicate, c := range vscodeScopeClauses {
+			if c == clause {
+				term.Predicate = predicate
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", false
+		}
+		terms = append(terms, term)
+	}
+	return keymap.NewScope(terms...), true
+}
@@ -0,0 +1,57 @@
+package vscode
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
+)
+
+// removalPrefix marks a keybindings.json entry as a removal rule, e.g. {"key": "cmd+k", "command": "-foo"}.
+// A removal rule without a key removes every default binding of the command.
+const removalPrefix = "-"
+
+// removedCommand returns the command a removal rule targets.
+func removedCommand(kb vscodeKeybinding) (string, bool) {
+	return strings.CutPrefix(kb.Command, removalPrefix)
+}
+
+// removalKeybindings builds removal rules for the action's unbind entries, one per mapped command.
+// Removal rules carry no `when` so that they match the default binding whatever its context.
+func removalKeybindings(
+	km keymap.Action,
+	vscodeConfigs []mappings.VscodeMappingConfig,
+	targetPlatform platform.Platform,
+) []vscodeKeybinding {
+	if !km.HasUnbind() {
+		return nil
+	}
+	var commands []string
+	for _, vc := range vscodeConfigs {
+		if vc.Command != "" && !slices.Contains(commands, vc.Command) {
+			commands = append(commands, vc.Command)
+		}
+	}
+
+	var keys []string
+	if km.UnbindAll {
+		keys = append(keys, "")
+	}
+	for _, b := range km.Unbind {
+		key, err := FormatKeybinding(&b, targetPlatform)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	var removals []vscodeKeybinding
+	for _, command := range commands {
+		for _, key := range keys {
+			removals = append(removals, vscodeKeybinding{Key: key, Command: removalPrefix + command})
+		}
+	}
+	return removals
+}
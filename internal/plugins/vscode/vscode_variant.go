@@ -59,6 +59,18 @@ func (p *vsCodeVariantPlugin) ConfigDetect(
 		return detectConfigForVSCodeVariant("Windsurf-Next", "windsurf-next", opts)
 	case pluginapi.EditorTypeCursor:
 		return detectConfigForVSCodeVariant("Cursor", "cursor", opts)
+	case pluginapi.EditorTypeVSCodeInsiders:
+		return detectConfigForVSCodeVariant("Code - Insiders", "code-insiders", opts)
+	case pluginapi.EditorTypeVSCodium:
+		return detectConfigForVSCodeVariant("VSCodium", "codium", opts)
+	case pluginapi.EditorTypeCodeOSS:
+		return detectConfigForVSCodeVariant("Code - OSS", "code-oss", opts)
+	case pluginapi.EditorTypePositron:
+		return detectConfigForVSCodeVariant("Positron", "positron", opts)
+	case pluginapi.EditorTypeTrae:
+		return detectConfigForVSCodeVariant("Trae", "trae", opts)
+	case pluginapi.EditorTypeKiro:
+		return detectConfigForVSCodeVariant("Kiro", "kiro", opts)
 	case pluginapi.EditorTypeVSCode:
 		return detectConfigForVSCodeVariant("Code", "code", opts)
 	default:
@@ -92,3 +104,57 @@ func NewCursor(
 ) pluginapi.Plugin {
 	return newVSCodeVariantPlugin(pluginapi.EditorTypeCursor, mappingConfig, logger, recorder)
 }
+
+// NewVSCodeInsiders creates a VSCode Insiders plugin instance.
+func NewVSCodeInsiders(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newVSCodeVariantPlugin(pluginapi.EditorTypeVSCodeInsiders, mappingConfig, logger, recorder)
+}
+
+// NewVSCodium creates a VSCodium plugin instance.
+func NewVSCodium(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newVSCodeVariantPlugin(pluginapi.EditorTypeVSCodium, mappingConfig, logger, recorder)
+}
+
+// NewCodeOSS creates a Code - OSS plugin instance.
+func NewCodeOSS(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newVSCodeVariantPlugin(pluginapi.EditorTypeCodeOSS, mappingConfig, logger, recorder)
+}
+
+// NewPositron creates a Positron plugin instance.
+func NewPositron(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newVSCodeVariantPlugin(pluginapi.EditorTypePositron, mappingConfig, logger, recorder)
+}
+
+// NewTrae creates a Trae plugin instance.
+func NewTrae(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newVSCodeVariantPlugin(pluginapi.EditorTypeTrae, mappingConfig, logger, recorder)
+}
+
+// NewKiro creates a Kiro plugin instance.
+func NewKiro(
+	mappingConfig *mappings.MappingConfig,
+	logger *slog.Logger,
+	recorder metrics.Recorder,
+) pluginapi.Plugin {
+	return newVSCodeVariantPlugin(pluginapi.EditorTypeKiro, mappingConfig, logger, recorder)
+}
@@ -148,6 +148,26 @@ func (p *zedExporter) identifyManagedKeybindings(
 	targetPlatform platform.Platform,
 ) zedKeymapConfig {
 	keymapsByContext := make(map[string]map[string]zedActionValue)
+	bind := func(context, keys string, value zedActionValue) {
+		if _, ok := keymapsByContext[context]; !ok {
+			keymapsByContext[context] = make(map[string]zedActionValue)
+		}
+		// A removal never replaces a binding exported for the same key.
+		if _, exists := keymapsByContext[context][keys]; exists && value.Unbind {
+			return
+		}
+		keymapsByContext[context][keys] = value
+	}
+
+	// Keymap-level removals go to the section without context.
+	for _, b := range setting.Unbind {
+		keys, err := FormatZedKeybind(b, targetPlatform)
+		if err != nil {
+			p.logger.Warn("failed to format unbind key", "error", err)
+			continue
+		}
+		bind("", keys, zedActionValue{Unbind: true})
+	}
 
 	for _, km := range setting.Actions {
 		// Use GetExportAction to support fallback
@@ -185,13 +205,23 @@ func (p *zedExporter) identifyManagedKeybindings(
 			}
 			marker.MarkExported(km.Name, b)
 
+			// A scope replaces the mapping's contexts, so only the primary action is bound.
+			scopeContext, scoped := "", false
+			if !km.Scope.IsZero() {
+				scopeContext, scoped = formatContext(km.Scope)
+				if !scoped {
+					p.logger.Warn("Scope has no Zed equivalent, using mapping context",
+						"action", km.Name, "scope", km.Scope)
+				}
+			}
+
 			// For each Zed mapping config, create a binding under its context
 			for _, zconf := range mapping.Zed {
 				if zconf.Action == "" {
 					continue
 				}
-				if _, ok := keymapsByContext[zconf.Context]; !ok {
-					keymapsByContext[zconf.Context] = make(map[string]zedActionValue)
+				if scoped {
+					zconf.Context = scopeContext
 				}
 
 				// Create action value - either string or array with args
@@ -204,9 +234,14 @@ func (p *zedExporter) identifyManagedKeybindings(
 					actionValue = zedActionValue{Action: zconf.Action}
 				}
 
-				keymapsByContext[zconf.Context][keys] = actionValue
+				bind(zconf.Context, keys, actionValue)
+				if scoped {
+					break
+				}
 			}
 		}
+
+		p.exportUnbind(km, mapping, targetPlatform, bind)
 	}
 
 	result := make(zedKeymapConfig, 0, len(keymapsByContext))
@@ -220,6 +255,31 @@ func (p *zedExporter) identifyManagedKeybindings(
 	return result
 }
 
+// exportUnbind unbinds the action's unbind keys in each context of the mapping. Zed removes
+// bindings per key, so removing every binding of an action cannot be expressed.
+func (p *zedExporter) exportUnbind(
+	km keymap.Action,
+	mapping *mappings2.ActionMappingConfig,
+	targetPlatform platform.Platform,
+	bind func(context, keys string, value zedActionValue),
+) {
+	if km.UnbindAll {
+		p.logger.Debug("Zed cannot unbind all bindings of an action; ignoring", "action", km.Name)
+	}
+	for _, b := range km.Unbind {
+		keys, err := FormatZedKeybind(b, targetPlatform)
+		if err != nil {
+			p.logger.Warn("failed to format unbind key", "action", km.Name, "error", err)
+			continue
+		}
+		for _, zconf := range mapping.Zed {
+			if zconf.Action != "" {
+				bind(zconf.Context, keys, zedActionValue{Unbind: true})
+			}
+		}
+	}
+}
+
 // nonDestructiveMerge merges managed and existing keybindings, with managed taking priority.
 func (p *zedExporter) nonDestructiveMerge(managed, existing zedKeymapConfig) zedKeymapConfig {
 	// Create a map for quick lookup of existing contexts
@@ -56,6 +56,73 @@ func TestExportZedKeymap(t *testing.T) {
 ]`,
 			wantErr: false,
 		},
+		{
+			name: "scope replaces the mapping context",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.edit.copy",
+						Bindings: []keybinding.Keybinding{parseKB("meta+c")},
+						Scope:    "editorFocus && vimNormal",
+					},
+				},
+			},
+			wantJSON: `[
+  {
+    "context": "Editor && vim_mode == normal",
+    "bindings": {
+      "cmd-c": "editor::Copy"
+    }
+  }
+]`,
+		},
+		{
+			name: "scope without a zed equivalent uses the mapping context",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.edit.copy",
+						Bindings: []keybinding.Keybinding{parseKB("meta+c")},
+						Scope:    "hasSelection",
+					},
+				},
+			},
+			wantJSON: `[
+  {
+    "context": "Editor",
+    "bindings": {
+      "cmd-c": "editor::Copy"
+    }
+  }
+]`,
+		},
+		{
+			name: "unbind entries become null bindings",
+			setting: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.edit.copy",
+						Bindings: []keybinding.Keybinding{parseKB("meta+c")},
+						Unbind:   []keybinding.Keybinding{parseKB("meta+c"), parseKB("meta+insert")},
+					},
+				},
+				Unbind: []keybinding.Keybinding{parseKB("meta+k")},
+			},
+			wantJSON: `[
+  {
+    "bindings": {
+      "cmd-k": null
+    }
+  },
+  {
+    "context": "Editor",
+    "bindings": {
+      "cmd-c": "editor::Copy",
+      "cmd-insert": null
+    }
+  }
+]`,
+		},
 		{
 			name: "exports multiple keybindings for same action",
 			setting: keymap.Keymap{
@@ -72,6 +72,12 @@ func (p *zedImporter) Import(
 				continue
 			}
 
+			if action.Unbind || action.Action == noAction {
+				// A removal names no action, so it becomes a keymap-level unbind entry.
+				setting.Unbind = append(setting.Unbind, kb)
+				continue
+			}
+
 			// Use strong-typed action value parsed via UnmarshalJSON
 			actionStr := action.Action
 			actionArgs := action.Args
@@ -81,7 +87,13 @@ func (p *zedImporter) Import(
 				continue
 			}
 
+			var scope keymap.Scope
 			actionID, err := p.actionIDFromZedWithArgs(actionStr, zk.Context, actionArgs)
+			if err != nil {
+				if id, s, ok := p.scopedActionIDFromZed(actionStr, zk.Context, actionArgs); ok {
+					actionID, scope, err = id, s, nil
+				}
+			}
 			if err != nil {
 				// If a mapping is not found, we simply skip it for now.
 				// In the future, this could be logged or added to a report.
@@ -106,6 +118,7 @@ func (p *zedImporter) Import(
 				Bindings: []keybinding.Keybinding{
 					kb,
 				},
+				Scope: scope,
 			}
 
 			setting.Actions = append(setting.Actions, keymapEntry)
@@ -113,6 +126,7 @@ func (p *zedImporter) Import(
 		}
 	}
 	setting.Actions = dedup.Actions(setting.Actions)
+	setting.Unbind = keymap.UnionKeybindings(nil, setting.Unbind)
 	result := pluginapi.PluginImportResult{Keymap: setting}
 	result.Report.SkipReport = marker.Report()
 	result.Report.ImportedReport = marker.ImportedReport()
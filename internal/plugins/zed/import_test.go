@@ -60,6 +60,28 @@ func TestImportZedKeymap(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "context expressible as a scope is preserved",
+			input: `[
+				{
+					"context": "Editor && vim_mode == normal",
+					"bindings": {
+						"cmd-c": "editor::Copy"
+					}
+				}
+			]`,
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name: "actions.edit.copy",
+						Bindings: []keybinding.Keybinding{
+							parseKB("meta+c"),
+						},
+						Scope: "editorFocus && vimNormal",
+					},
+				},
+			},
+		},
 		{
 			name: "Bind one action to multiple keys",
 			input: `[
@@ -228,6 +250,33 @@ func TestImportZedKeymap(t *testing.T) {
 			expected:  keymap.Keymap{},
 			expectErr: false,
 		},
+		{
+			name: "null and NoAction bindings become unbind entries",
+			input: `[
+				{
+					"context": "Editor",
+					"bindings": {
+						"cmd-c": "editor::Copy",
+						"cmd-k": null,
+						"cmd-insert": "zed::NoAction"
+					}
+				},
+				{
+					"bindings": {
+						"cmd-k": null
+					}
+				}
+			]`,
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{
+					{
+						Name:     "actions.edit.copy",
+						Bindings: []keybinding.Keybinding{parseKB("meta+c")},
+					},
+				},
+				Unbind: []keybinding.Keybinding{parseKB("meta+insert"), parseKB("meta+k")},
+			},
+		},
 		{
 			name:      "Malformed JSON",
 			input:     `[{"context": "Editor", "bindings": {"cmd-s": "editor::Save"}`,
@@ -3,21 +3,52 @@ package zed
 import (
 	"fmt"
 	"reflect"
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
 )
 
 // actionIDFromZedWithArgs converts a Zed action, context, and args to a universal action ID.
 func (p *zedImporter) actionIDFromZedWithArgs(action, context string, args map[string]interface{}) (string, error) {
 	for _, mapping := range p.mappingConfig.Mappings {
 		for _, zconf := range mapping.Zed {
-			if zconf.Action == action && zconf.Context == context {
-				if zconf.Args == nil && args == nil {
-					return mapping.ID, nil
-				} else if zconf.Args != nil && args != nil && reflect.DeepEqual(zconf.Args, args) {
-					return mapping.ID, nil
-				}
+			if zconf.Action == action && zconf.Context == context && equalArgs(zconf.Args, args) {
+				return mapping.ID, nil
 			}
 		}
 	}
 
 	return "", fmt.Errorf("no mapping found for zed action: %s", action)
 }
+
+// scopedActionIDFromZed matches a Zed action and args regardless of context, for bindings whose
+// context is a scope rather than the mapping's own context. Matches are tried in ID order.
+func (p *zedImporter) scopedActionIDFromZed(
+	action, context string,
+	args map[string]interface{},
+) (string, keymap.Scope, bool) {
+	scope, ok := scopeFromContext(context)
+	if !ok {
+		return "", "", false
+	}
+	ids := make([]string, 0, len(p.mappingConfig.Mappings))
+	for id := range p.mappingConfig.Mappings {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		for _, zconf := range p.mappingConfig.Mappings[id].Zed {
+			if zconf.Action == action && !zconf.DisableImport && equalArgs(zconf.Args, args) {
+				return id, scope, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func equalArgs(a, b map[string]interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,74 @@
+package zed
+
+import (
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+)
+
+// zedScopeContexts translates scope predicates to Zed context predicates. Zed has no context for a
+// non-empty selection, so scopes using hasSelection cannot be exported to Zed.
+//
+//nolint:gochecknoglobals // static lookup table; initialized once and read-only
+var zedScopeContexts = map[keymap.ScopePredicate]string{
+	keymap.ScopeEditorFocus:   "Editor",
+	keymap.ScopeTerminalFocus: "Terminal",
+	keymap.ScopeVimNormal:     "vim_mode == normal",
+	keymap.ScopeVimInsert:     "vim_mode == insert",
+	keymap.ScopeVimVisual:     "vim_mode == visual",
+}
+
+// formatContext translates a scope to a Zed context, e.g. "Editor && vim_mode == normal".
+// It reports false when a predicate has no Zed equivalent.
+func formatContext(scope keymap.Scope) (string, bool) {
+	terms := scope.Terms()
+	predicates := make([]string, 0, len(terms))
+	for _, t := range terms {
+		predicate, ok := zedScopeContexts[t.Predicate]
+		if !ok {
+			return "", false
+		}
+		if t.Negated {
+			if strings.Contains(predicate, " == ") {
+				predicate = strings.Replace(predicate, " == ", " != ", 1)
+			} else {
+				predicate = "!" + predicate
+			}
+		}
+		predicates = append(predicates, predicate)
+	}
+	return strings.Join(predicates, " && "), true
+}
+
+// scopeFromContext translates a Zed context back to a scope. It only succeeds for conjunctions of
+// predicates produced by formatContext.
+func scopeFromContext(context string) (keymap.Scope, bool) {
+	if strings.TrimSpace(context) == "" || strings.ContainsAny(context, "|()>") {
+		return "", false
+	}
+	var terms []keymap.ScopeTerm
+	for _, predicate := range strings.Split(context, "&&") {
+		predicate = strings.Join(strings.Fields(predicate), " ")
+		term := keymap.ScopeTerm{}
+		if rest, ok := strings.CutPrefix(predicate, "!"); ok {
+			term.Negated = true
+			predicate = strings.TrimSpace(rest)
+		} else if strings.Contains(predicate, " != ") {
+			term.Negated = true
+			predicate = strings.Replace(predicate, " != ", " == ", 1)
+		}
+		found := false
+		for p, c := range zedScopeContexts {
+			if c == predicate {
+				term.Predicate = p
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", false
+		}
+		terms = append(terms, term)
+	}
+	return keymap.NewScope(terms...), true
+}
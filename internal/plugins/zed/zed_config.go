@@ -1,9 +1,15 @@
 package zed
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+)
 
 type zedKeymapConfig = []zedKeymapOfContext
 
+// noAction is Zed's explicit "do nothing" action; like null it unbinds the key in its context.
+const noAction = "zed::NoAction"
+
 // zedKeymapOfContext represents the structure of Zed's keymap.json file.
 type zedKeymapOfContext struct {
 	Context  string                    `json:"context,omitempty"`
@@ -25,12 +31,18 @@ type zedKeymapOfContext struct {
 // ],
 // action: "pane::DeploySearch"
 // args: {"replace_enabled": true}
+//
+// A null value unbinds the key in its context and is represented by Unbind.
 type zedActionValue struct {
 	Action string
 	Args   map[string]interface{}
+	Unbind bool
 }
 
 func (z zedActionValue) MarshalJSON() ([]byte, error) {
+	if z.Unbind {
+		return []byte("null"), nil
+	}
 	if len(z.Args) > 0 {
 		return json.Marshal([]interface{}{z.Action, z.Args})
 	}
@@ -41,6 +53,11 @@ func (z zedActionValue) MarshalJSON() ([]byte, error) {
 // ["action", {args}] and maps it into the strong type. Invalid shapes are
 // treated as zero value without returning an error to allow robust import.
 func (z *zedActionValue) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*z = zedActionValue{Unbind: true}
+		return nil
+	}
+
 	// Try simple string first
 	var s string
 	if err := json.Unmarshal(data, &s); err == nil {
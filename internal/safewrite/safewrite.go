@@ -0,0 +1,194 @@
+// Package safewrite replaces config files so that neither a crash nor a concurrent onekeymap-cli
+// run can leave them truncated or silently drop edits made in the meantime.
+//
+// A write goes to a temporary file in the same directory, is synced, and is renamed over the
+// target, all while holding an advisory lock file (<target>.lock). A File remembers the content it
+// read, and writing it aborts with ErrChanged if the target was modified since.
+package safewrite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	defaultPerm = 0o600
+	lockSuffix  = ".lock"
+)
+
+//nolint:gochecknoglobals // tests shorten these
+var (
+	// lockTimeout is how long to wait for another process to release a lock.
+	lockTimeout = 10 * time.Second
+	// staleLockAge is the age after which a lock is assumed to be left over from a crashed process.
+	staleLockAge = time.Minute
+	lockRetry    = 50 * time.Millisecond
+)
+
+var (
+	// ErrChanged is returned when the target was modified after it was read.
+	ErrChanged = errors.New("file changed on disk since it was read")
+	// ErrLocked is returned when another process holds the lock for too long.
+	ErrLocked = errors.New("file is locked by another process")
+)
+
+// File is a target file together with the content it had when it was read.
+type File struct {
+	path    string
+	content []byte
+	exists  bool
+}
+
+// Open reads the file at path. A missing file is not an error; writing then fails with ErrChanged
+// if the file was created in the meantime.
+func Open(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &File{path: path}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &File{path: path, content: data, exists: true}, nil
+}
+
+// Path returns the path the file was opened with.
+func (f *File) Path() string { return f.path }
+
+// Exists reports whether the file existed when it was read.
+func (f *File) Exists() bool { return f.exists }
+
+// Content returns the content the file had when it was read, or nil if it did not exist.
+func (f *File) Content() []byte { return f.content }
+
+// Write replaces the file with data, unless it changed since it was read, in which case it returns
+// an error wrapping ErrChanged and leaves the file untouched.
+func (f *File) Write(data []byte) error {
+	err := write(f.path, data, func(current []byte, exists bool) error {
+		if exists != f.exists || !bytes.Equal(current, f.content) {
+			return fmt.Errorf("%s: %w", f.path, ErrChanged)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	f.content, f.exists = bytes.Clone(data), true
+	return nil
+}
+
+// WriteFile replaces the file at path with data, without checking what it contained.
+func WriteFile(path string, data []byte) error {
+	return write(path, data, nil)
+}
+
+func write(path string, data []byte, check func(current []byte, exists bool) error) error {
+	// Write through symlinks, so keymaps managed in a dotfiles repository stay linked.
+	target, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, os.ErrNotExist) {
+		target = path
+	} else if err != nil {
+		return err
+	}
+	dir := filepath.Dir(target)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	unlock, err := lock(target)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	perm := os.FileMode(defaultPerm)
+	current, err := os.ReadFile(target)
+	exists := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if exists {
+		if info, err := os.Stat(target); err == nil {
+			perm = info.Mode().Perm()
+		}
+	}
+	if check != nil {
+		if err := check(current, exists); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(target)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return err
+	}
+	syncDir(dir)
+	return nil
+}
+
+// Lock takes the advisory lock of path, as held while writing it, and returns the function
+// releasing it. Use it to guard updates that do not go through Write, such as appends.
+func Lock(path string) (func(), error) {
+	return lock(path)
+}
+
+// lock takes the advisory lock of path and returns the function releasing it.
+func lock(path string) (func(), error) {
+	lockPath := path + lockSuffix
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, defaultPerm)
+		if err == nil {
+			_, _ = fmt.Fprintf(f, "%d\n", os.Getpid())
+			_ = f.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			msg := "lock file " + lockPath
+			if holder, _ := os.ReadFile(lockPath); len(bytes.TrimSpace(holder)) > 0 {
+				msg = fmt.Sprintf("pid %s, %s", bytes.TrimSpace(holder), msg)
+			}
+			return nil, fmt.Errorf("%s: %w (%s)", path, ErrLocked, msg)
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// syncDir makes a rename in dir durable. It is best effort: not every platform can sync a directory.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
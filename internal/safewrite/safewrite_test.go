@@ -0,0 +1,96 @@
+package safewrite
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_Write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keybindings.json")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o644))
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	assert.True(t, f.Exists())
+	assert.Equal(t, "old", string(f.Content()))
+
+	require.NoError(t, f.Write([]byte("new")))
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(got))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm(), "permissions are kept")
+
+	// A second write checks against what the first one wrote.
+	require.NoError(t, f.Write([]byte("newer")))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary or lock files are left behind")
+}
+
+func TestFile_WriteAbortsWhenChanged(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keybindings.json")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("edited elsewhere"), 0o600))
+
+	err = f.Write([]byte("new"))
+	require.ErrorIs(t, err, ErrChanged)
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "edited elsewhere", string(got))
+
+	// A file that did not exist when read must not exist when written.
+	missing, err := Open(filepath.Join(dir, "missing.json"))
+	require.NoError(t, err)
+	assert.False(t, missing.Exists())
+	require.NoError(t, os.WriteFile(missing.Path(), []byte("created elsewhere"), 0o600))
+	require.ErrorIs(t, missing.Write([]byte("new")), ErrChanged)
+}
+
+func TestWriteFile_FollowsSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	real := filepath.Join(dir, "dotfiles", "keymap.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(real), 0o750))
+	require.NoError(t, os.WriteFile(real, []byte("old"), 0o600))
+	link := filepath.Join(dir, "keymap.json")
+	require.NoError(t, os.Symlink(real, link))
+
+	require.NoError(t, WriteFile(link, []byte("new")))
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&os.ModeSymlink, "the link is kept")
+	got, err := os.ReadFile(real)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(got))
+}
+
+func TestWriteFile_Lock(t *testing.T) {
+	lockTimeout, staleLockAge = 100*time.Millisecond, time.Hour
+	t.Cleanup(func() { lockTimeout, staleLockAge = 10*time.Second, time.Minute })
+
+	path := filepath.Join(t.TempDir(), "keymap.json")
+	unlock, err := lock(path)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, WriteFile(path, []byte("new")), ErrLocked)
+	unlock()
+	require.NoError(t, WriteFile(path, []byte("new")))
+
+	// Locks left behind by a crashed process are taken over.
+	staleLockAge = 0
+	require.NoError(t, os.WriteFile(path+lockSuffix, []byte("12345\n"), 0o600))
+	require.NoError(t, WriteFile(path, []byte("newer")))
+}
@@ -0,0 +1,88 @@
+package syncstate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/xinnjie/onekeymap-cli/internal/safewrite"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+// Snapshot records an editor right after a sync: the keymap that was exported to it, and its keymap
+// as it imports back. The next sync diffs against both to find the edits made since.
+type Snapshot struct {
+	Keymap keymap.Keymap
+	Editor keymap.Keymap
+}
+
+type snapshotFile struct {
+	Keymap json.RawMessage `json:"keymap"`
+	Editor json.RawMessage `json:"editor"`
+}
+
+// Dir returns the directory that holds the snapshots for a onekeymap.json.
+func Dir(onekeymapPath string) string {
+	return filepath.Join(filepath.Dir(onekeymapPath), ".onekeymap-sync")
+}
+
+// Load reads the snapshot of an editor. It reports false when the editor was never synced.
+func Load(dir, editor string) (Snapshot, bool, error) {
+	data, err := os.ReadFile(path(dir, editor))
+	if errors.Is(err, os.ErrNotExist) {
+		return Snapshot{}, false, nil
+	}
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	var f snapshotFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return Snapshot{}, false, fmt.Errorf("failed to parse sync snapshot of %s: %w", editor, err)
+	}
+	var s Snapshot
+	if s.Keymap, err = keymap.Load(bytes.NewReader(f.Keymap), keymap.LoadOptions{}); err != nil {
+		return Snapshot{}, false, fmt.Errorf("failed to parse sync snapshot of %s: %w", editor, err)
+	}
+	if s.Editor, err = keymap.Load(bytes.NewReader(f.Editor), keymap.LoadOptions{}); err != nil {
+		return Snapshot{}, false, fmt.Errorf("failed to parse sync snapshot of %s: %w", editor, err)
+	}
+	return s, true, nil
+}
+
+// Save writes the snapshot of an editor, replacing the previous one.
+func Save(dir, editor string, s Snapshot) error {
+	var f snapshotFile
+	var err error
+	if f.Keymap, err = encode(s.Keymap); err != nil {
+		return err
+	}
+	if f.Editor, err = encode(s.Editor); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	return safewrite.WriteFile(path(dir, editor), data)
+}
+
+func encode(km keymap.Keymap) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	// Snapshots hold effective entries: no extends chain or override layers.
+	flat := keymap.Keymap{Actions: km.Actions, Unbind: km.Unbind}
+	if err := keymap.Save(&buf, flat, keymap.SaveOptions{Platform: platform.PlatformMacOS}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func path(dir, editor string) string {
+	return filepath.Join(dir, editor+".json")
+}
@@ -0,0 +1,34 @@
+package syncstate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/internal/syncstate"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	_, ok, err := syncstate.Load(dir, "zed")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	kb, err := keybinding.NewKeybinding("cmd+c", keybinding.ParseOption{Separator: "+"})
+	require.NoError(t, err)
+	s := syncstate.Snapshot{
+		Keymap: keymap.Keymap{Actions: []keymap.Action{{Name: "actions.edit.copy", Bindings: []keybinding.Keybinding{kb}}}},
+		Editor: keymap.Keymap{Actions: []keymap.Action{}},
+	}
+	require.NoError(t, syncstate.Save(dir, "zed", s))
+
+	got, ok, err := syncstate.Load(dir, "zed")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, got.Keymap.Actions, 1)
+	assert.True(t, got.Keymap.Actions[0].SameKeys(s.Keymap.Actions[0]))
+	assert.Empty(t, got.Editor.Actions)
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
 )
@@ -15,12 +16,21 @@ var editorTypesDisplayOrder = [...]pluginapi.EditorType{
 	pluginapi.EditorTypeIntelliJ,
 	pluginapi.EditorTypeZed,
 	pluginapi.EditorTypeHelix,
+	pluginapi.EditorTypeVim,
+	pluginapi.EditorTypeSublime,
+	pluginapi.EditorTypeEmacs,
+	pluginapi.EditorTypeEclipse,
+	pluginapi.EditorTypeVisualStudio,
+	pluginapi.EditorTypeLapce,
+	pluginapi.EditorTypeKakoune,
+	pluginapi.EditorTypePulsar,
 }
 
 type ActionDetailsViewModel struct {
 	actionID      string
 	description   string
 	category      string
+	metadata      keymap.Metadata
 	editorSupport map[pluginapi.EditorType]editorSupportInfo
 	mappingConfig *mappings.ActionMappingConfig
 }
@@ -30,9 +40,14 @@ type editorSupportInfo struct {
 	note      string
 }
 
-func newActionDetailsViewModel(actionID string, mc *mappings.MappingConfig) *ActionDetailsViewModel {
+func newActionDetailsViewModel(
+	actionID string,
+	metadata keymap.Metadata,
+	mc *mappings.MappingConfig,
+) *ActionDetailsViewModel {
 	d := &ActionDetailsViewModel{
 		actionID:      actionID,
+		metadata:      metadata,
 		editorSupport: make(map[pluginapi.EditorType]editorSupportInfo),
 	}
 	if mc == nil {
@@ -83,6 +98,12 @@ func (d *ActionDetailsViewModel) View() string {
 	if d.category != "" {
 		fmt.Fprintf(&b, "%s %s\n", labelStyle.Render("Category:"), d.category)
 	}
+	if d.metadata.Comment != "" {
+		fmt.Fprintf(&b, "%s %s\n", labelStyle.Render("Comment:"), d.metadata.Comment)
+	}
+	if len(d.metadata.Tags) > 0 {
+		fmt.Fprintf(&b, "%s %s\n", labelStyle.Render("Tags:"), strings.Join(d.metadata.Tags, ", "))
+	}
 
 	// Display editor support
 	if len(d.editorSupport) > 0 {
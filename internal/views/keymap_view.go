@@ -3,6 +3,7 @@ package views
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -188,7 +189,7 @@ func (m *KeymapViewModel) View() string {
 		rightPanelContent = m.actionTable.View()
 		selectedID := m.selectedActionID()
 		if selectedID != "" {
-			details := newActionDetailsViewModel(selectedID, m.mc)
+			details := newActionDetailsViewModel(selectedID, m.actionMetadata(selectedID), m.mc)
 			rightPanelContent += "\n" + details.View()
 		}
 	}
@@ -217,6 +218,17 @@ func (m *KeymapViewModel) selectedActionID() string {
 	return ""
 }
 
+// actionMetadata merges the metadata of every entry for actionID, e.g. one per scope.
+func (m *KeymapViewModel) actionMetadata(actionID string) keymap.Metadata {
+	var metadata keymap.Metadata
+	for _, a := range m.setting.Actions {
+		if a.Name == actionID {
+			metadata = metadata.Merge(a.Metadata)
+		}
+	}
+	return metadata
+}
+
 func (m *KeymapViewModel) initCategories() {
 	catSet := map[string]struct{}{}
 	for _, kb := range m.setting.Actions {
@@ -331,7 +343,7 @@ func (m *KeymapViewModel) reloadKeymap() error {
 	}
 	defer file.Close()
 
-	keymap, err := keymap.Load(file, keymap.LoadOptions{})
+	keymap, err := keymap.Load(file, keymap.LoadOptions{Dir: filepath.Dir(m.filePath)})
 	if err != nil {
 		return fmt.Errorf("failed to parse keymap: %w", err)
 	}
@@ -0,0 +1,103 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/xinnjie/onekeymap-cli/pkg/merge"
+)
+
+var (
+	_ tea.Model = (*syncConflictsModel)(nil)
+)
+
+// syncConflictsModel walks through sync conflicts one at a time and lets the user pick the version
+// to keep for each.
+type syncConflictsModel struct {
+	conflicts []merge.Conflict
+	current   int
+	cursor    int
+
+	choices   []int
+	confirmed *bool
+}
+
+// NewSyncConflictsModel returns a resolver for conflicts. choices must have one element per conflict;
+// choices[i] receives the index of the version picked for conflicts[i]. confirmed is set once every
+// conflict is resolved, and stays false when the user quits.
+func NewSyncConflictsModel(conflicts []merge.Conflict, choices []int, confirmed *bool) tea.Model {
+	return syncConflictsModel{conflicts: conflicts, choices: choices, confirmed: confirmed}
+}
+
+func (m syncConflictsModel) Init() tea.Cmd { return nil }
+
+func (m syncConflictsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	versions := m.conflicts[m.current].Versions
+	switch keyMsg.String() {
+	case "q", "esc", "ctrl+c":
+		*m.confirmed = false
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(versions)-1 {
+			m.cursor++
+		}
+	case "left", "backspace":
+		if m.current > 0 {
+			m.current--
+			m.cursor = m.choices[m.current]
+		}
+	case "enter":
+		m.choices[m.current] = m.cursor
+		if m.current == len(m.conflicts)-1 {
+			*m.confirmed = true
+			return m, tea.Quit
+		}
+		m.current++
+		m.cursor = m.choices[m.current]
+	}
+	return m, nil
+}
+
+func (m syncConflictsModel) View() string {
+	c := m.conflicts[m.current]
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Sync conflict %d of %d", m.current+1, len(m.conflicts))))
+	b.WriteString("\n\n")
+	name := actionStyle.Render(c.Name)
+	if !c.Scope.IsZero() {
+		name += fmt.Sprintf(" (scope %s)", c.Scope)
+	}
+	b.WriteString(fmt.Sprintf("  %s was changed differently since the last sync. Keep:\n\n", name))
+	for i, v := range c.Versions {
+		prefix := "    "
+		if i == m.cursor {
+			prefix = "  > "
+		}
+		keys := formatKeyBinding(&v.Action)
+		switch {
+		case v.Removed:
+			keys = "(removed)"
+		case keys == "":
+			keys = "(no keys)"
+		}
+		line := fmt.Sprintf("%s%-10s %s", prefix, v.Source, keyStyle.Render(keys))
+		if i == m.cursor {
+			line = labelStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(helpStyle.Render(
+		"↑/↓ choose • enter keep and continue • ← previous conflict • q quit without writing",
+	))
+	b.WriteString("\n")
+	return b.String()
+}
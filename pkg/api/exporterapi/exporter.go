@@ -6,6 +6,7 @@ import (
 
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
 )
 
@@ -58,6 +59,15 @@ type ExportOptions struct {
 	DiffType DiffType
 	// file path for the keymap config
 	FilePath string
+	// Optional, platform the exported keymap is for. It selects the "platforms" override layer and
+	// how modifier keys are written. If empty, defaults to the current runtime platform.
+	TargetPlatform platform.Platform
+	// Optional, how to translate modifiers from KeymapPlatform to TargetPlatform, e.g. cmd+c to ctrl+c.
+	// If empty, bindings are kept as they are.
+	ModifierStrategy keymap.ModifierStrategy
+	// Optional, platform the keymap's bindings were written for. If empty, defaults to the current
+	// runtime platform.
+	KeymapPlatform platform.Platform
 }
 
 // ExportReport details issues encountered during an export operation.
@@ -70,6 +80,9 @@ type ExportReport struct {
 
 	// SkipActions reports actions that were not exported and why.
 	SkipActions []pluginapi.ExportSkipAction
+
+	// Collisions resolved while translating modifiers.
+	Translation keymap.TranslationReport
 }
 
 // ExportCoverage summarizes export success rate.
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/validateapi"
 )
@@ -25,6 +26,14 @@ type ImportOptions struct {
 	InputStream io.Reader
 	// Optional, existing onekeymap base setting
 	Base keymap.Keymap
+	// Optional, platform the input keymap was written for, e.g. when importing a Windows
+	// keybindings.json on Linux. If empty, defaults to the current runtime platform.
+	SourcePlatform platform.Platform
+	// Optional, how to translate modifiers from SourcePlatform to KeymapPlatform, e.g. cmd+c to ctrl+c.
+	// If empty, bindings are kept as they are.
+	ModifierStrategy keymap.ModifierStrategy
+	// Optional, platform the onekeymap.json is written for. If empty, defaults to the current runtime platform.
+	KeymapPlatform platform.Platform
 }
 
 // ImportResult represents the result of an import operation.
@@ -38,6 +47,9 @@ type ImportResult struct {
 	Changes *KeymapChanges
 
 	SkipReport pluginapi.ImportSkipReport
+
+	// Collisions resolved while translating modifiers.
+	Translation keymap.TranslationReport
 }
 
 // KeymapChanges represents the changes to a keymap setting.
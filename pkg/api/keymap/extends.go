@@ -0,0 +1,231 @@
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/xinnjie/onekeymap-cli/config/base"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+)
+
+// ExtendsBasePrefix marks an "extends" reference to a bundled base keymap, e.g. "base:vscode-mac".
+// Other references are paths to onekeymap.json files, relative to the file that names them.
+const ExtendsBasePrefix = "base:"
+
+var errExtendsCycle = errors.New("extends cycle")
+
+// resolveExtends loads the keymaps named by refs and merges them in order, later ones overriding
+// earlier ones. dir is the directory relative paths are resolved against; stack holds the
+// references being loaded, to detect cycles.
+func resolveExtends(refs []string, dir string, stack []string) (Keymap, error) {
+	var inherited Keymap
+	for _, ref := range refs {
+		parent, err := loadExtends(ref, dir, stack)
+		if err != nil {
+			return Keymap{}, err
+		}
+		inherited = inherit(inherited, parent)
+	}
+	return inherited, nil
+}
+
+func loadExtends(ref, dir string, stack []string) (Keymap, error) {
+	var (
+		data []byte
+		id   string
+		err  error
+	)
+	if name, ok := strings.CutPrefix(ref, ExtendsBasePrefix); ok {
+		id = ref
+		data, err = base.Read(name)
+	} else {
+		path := ref
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if id, err = filepath.Abs(path); err == nil {
+			dir = filepath.Dir(id)
+			data, err = os.ReadFile(id)
+		}
+	}
+	if err != nil {
+		return Keymap{}, fmt.Errorf("failed to read extends '%s': %w", ref, err)
+	}
+	if slices.Contains(stack, id) {
+		return Keymap{}, fmt.Errorf("%w: %s", errExtendsCycle, strings.Join(append(stack, id), " -> "))
+	}
+
+	friendlyData, err := parseOneKeymapSetting(data)
+	if err != nil {
+		return Keymap{}, fmt.Errorf("failed to parse extends '%s': %w", ref, err)
+	}
+	km, err := buildKeymapFromFriendly(friendlyData, dir, append(stack, id))
+	if err != nil {
+		return Keymap{}, fmt.Errorf("failed to load extends '%s': %w", ref, err)
+	}
+	return km.flatten(), nil
+}
+
+// flatten drops the extends chain, keeping the merged entries.
+func (k Keymap) flatten() Keymap {
+	k.Extends, k.Inherited = nil, nil
+	return k
+}
+
+// inherit returns child on top of parent: child entries replace parent entries with the same ID
+// and scope, in the top-level keymaps and in each override layer.
+func inherit(parent, child Keymap) Keymap {
+	merged := parent.apply(Overrides{Actions: child.Actions, Unbind: child.Unbind})
+	merged.Platforms = inheritLayers(parent.Platforms, child.Platforms)
+	merged.Editors = inheritLayers(parent.Editors, child.Editors)
+	return merged
+}
+
+func inheritLayers[K comparable](parent, child map[K]Overrides) map[K]Overrides {
+	if len(parent) == 0 && len(child) == 0 {
+		return nil
+	}
+	merged := maps.Clone(parent)
+	if merged == nil {
+		merged = make(map[K]Overrides, len(child))
+	}
+	for key, o := range child {
+		km := Keymap{Actions: merged[key].Actions, Unbind: merged[key].Unbind}.apply(o)
+		merged[key] = Overrides{Actions: km.Actions, Unbind: km.Unbind}
+	}
+	return merged
+}
+
+// delta returns the entries of k that differ from its inherited keymap: what Save writes for a
+// keymap with an extends chain. Inherited entries missing from k are written without bindings.
+// Inherited keymap-level unbind keys missing from k are returned in the keymap-level unbind lists
+// of restore, to be written as "restore".
+func (k Keymap) delta() (Keymap, Keymap) {
+	if k.Inherited == nil {
+		return k, Keymap{}
+	}
+	own, restore := deltaOverrides(
+		Overrides{Actions: k.Actions, Unbind: k.Unbind},
+		Overrides{Actions: k.Inherited.Actions, Unbind: k.Inherited.Unbind},
+	)
+	platforms, restorePlatforms := deltaLayers(k.Platforms, k.Inherited.Platforms)
+	editors, restoreEditors := deltaLayers(k.Editors, k.Inherited.Editors)
+	return Keymap{
+		Actions:   own.Actions,
+		Unbind:    own.Unbind,
+		Platforms: platforms,
+		Editors:   editors,
+		Extends:   k.Extends,
+	}, Keymap{
+		Unbind:    restore,
+		Platforms: restorePlatforms,
+		Editors:   restoreEditors,
+	}
+}
+
+func deltaLayers[K comparable](effective, inherited map[K]Overrides) (map[K]Overrides, map[K]Overrides) {
+	var own, restore map[K]Overrides
+	for key, o := range effective {
+		d, r := deltaOverrides(o, inherited[key])
+		if len(d.Actions) > 0 || len(d.Unbind) > 0 {
+			if own == nil {
+				own = make(map[K]Overrides)
+			}
+			own[key] = d
+		}
+		if len(r) > 0 {
+			if restore == nil {
+				restore = make(map[K]Overrides)
+			}
+			restore[key] = Overrides{Unbind: r}
+		}
+	}
+	return own, restore
+}
+
+func deltaOverrides(effective, inherited Overrides) (Overrides, []keybinding.Keybinding) {
+	inheritedByKey := make(map[actionKey]Action, len(inherited.Actions))
+	for _, a := range inherited.Actions {
+		inheritedByKey[actionKey{Name: a.Name, Scope: a.Scope}] = a
+	}
+
+	var own Overrides
+	present := make(map[actionKey]bool, len(effective.Actions))
+	for _, a := range effective.Actions {
+		key := actionKey{Name: a.Name, Scope: a.Scope}
+		present[key] = true
+		if p, ok := inheritedByKey[key]; ok && sameEntry(a, p) {
+			continue
+		}
+		own.Actions = append(own.Actions, a)
+	}
+	for _, a := range inherited.Actions {
+		if !present[actionKey{Name: a.Name, Scope: a.Scope}] {
+			own.Actions = append(own.Actions, Action{Name: a.Name, Scope: a.Scope})
+		}
+	}
+
+	inheritedUnbind := canonicalKeybindings(inherited.Unbind)
+	for _, kb := range effective.Unbind {
+		if !slices.Contains(inheritedUnbind, canonicalKeybinding(kb)) {
+			own.Unbind = append(own.Unbind, kb)
+		}
+	}
+	effectiveUnbind := canonicalKeybindings(effective.Unbind)
+	var restore []keybinding.Keybinding
+	for _, kb := range inherited.Unbind {
+		if !slices.Contains(effectiveUnbind, canonicalKeybinding(kb)) {
+			restore = append(restore, kb)
+		}
+	}
+	return own, restore
+}
+
+// withoutUnbind returns k with the keymap-level unbind keys of restore removed from the matching
+// sections, so that an extending keymap can give an inherited unbind key its default binding back.
+// k is not modified.
+func (k Keymap) withoutUnbind(restore Keymap) Keymap {
+	k.Unbind = removeKeybindings(k.Unbind, restore.Unbind)
+	k.Platforms = withoutLayerUnbind(k.Platforms, restore.Platforms)
+	k.Editors = withoutLayerUnbind(k.Editors, restore.Editors)
+	return k
+}
+
+func withoutLayerUnbind[K comparable](layers, restore map[K]Overrides) map[K]Overrides {
+	if len(restore) == 0 {
+		return layers
+	}
+	layers = maps.Clone(layers)
+	for key, r := range restore {
+		if o, ok := layers[key]; ok {
+			o.Unbind = removeKeybindings(o.Unbind, r.Unbind)
+			layers[key] = o
+		}
+	}
+	return layers
+}
+
+// removeKeybindings returns the keybindings of kbs that are not in remove, as a new slice.
+func removeKeybindings(kbs, remove []keybinding.Keybinding) []keybinding.Keybinding {
+	removed := canonicalKeybindings(remove)
+	var kept []keybinding.Keybinding
+	for _, kb := range kbs {
+		if !slices.Contains(removed, canonicalKeybinding(kb)) {
+			kept = append(kept, kb)
+		}
+	}
+	return kept
+}
+
+// sameEntry reports whether two entries for the same action and scope would be saved identically,
+// ignoring the order of keys.
+func sameEntry(a, b Action) bool {
+	return a.SameKeys(b) &&
+		a.Metadata.Comment == b.Metadata.Comment &&
+		slices.Equal(a.Metadata.Tags, b.Metadata.Tags)
+}
@@ -0,0 +1,136 @@
+package keymap_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestLoadAndSave_Extends(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "shared", "team.json"), `{
+  "keymaps": [
+    {"id": "actions.edit.copy", "keybinding": "cmd+c"},
+    {"id": "actions.edit.paste", "keybinding": "cmd+v"},
+    {"id": "actions.edit.cut", "keybinding": "cmd+x"}
+  ]
+}`)
+
+	userJSON := `{
+  "version": "1.0",
+  "extends": ["./shared/team.json"],
+  "keymaps": [
+    {"id": "actions.edit.paste", "keybinding": "cmd+shift+v"}
+  ]
+}`
+	km, err := keymap.Load(strings.NewReader(userJSON), keymap.LoadOptions{Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"./shared/team.json"}, km.Extends)
+	require.NotNil(t, km.Inherited)
+	require.Len(t, km.Actions, 3)
+	assert.Equal(t, "actions.edit.paste", km.Actions[1].Name)
+	assert.Equal(t, []keybinding.Keybinding{mustNewKeybinding("cmd+shift+v")}, km.Actions[1].Bindings)
+
+	t.Run("save writes only deltas", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, keymap.Save(&buf, km, keymap.SaveOptions{Platform: platform.PlatformMacOS}))
+		assert.JSONEq(t, userJSON, buf.String())
+	})
+
+	t.Run("removed inherited entry is saved without keybinding", func(t *testing.T) {
+		edited := km
+		edited.Actions = []keymap.Action{km.Actions[0], km.Actions[1]}
+
+		var buf bytes.Buffer
+		require.NoError(t, keymap.Save(&buf, edited, keymap.SaveOptions{Platform: platform.PlatformMacOS}))
+		assert.JSONEq(t, `{
+  "version": "1.0",
+  "extends": ["./shared/team.json"],
+  "keymaps": [
+    {"id": "actions.edit.cut"},
+    {"id": "actions.edit.paste", "keybinding": "cmd+shift+v"}
+  ]
+}`, buf.String())
+
+		reloaded, err := keymap.Load(&buf, keymap.LoadOptions{Dir: dir})
+		require.NoError(t, err)
+		require.Len(t, reloaded.Actions, 3)
+		assert.Empty(t, reloaded.Actions[2].Bindings)
+	})
+}
+
+func TestLoad_ExtendsBase(t *testing.T) {
+	km, err := keymap.Load(strings.NewReader(`{"extends": ["base:vscode-mac"], "keymaps": []}`),
+		keymap.LoadOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, km.Actions)
+
+	var buf bytes.Buffer
+	require.NoError(t, keymap.Save(&buf, km, keymap.SaveOptions{Platform: platform.PlatformMacOS}))
+	assert.JSONEq(t, `{"version": "1.0", "extends": ["base:vscode-mac"], "keymaps": []}`, buf.String())
+}
+
+func TestLoad_ExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.json"), `{"extends": ["./b.json"], "keymaps": []}`)
+	writeFile(t, filepath.Join(dir, "b.json"), `{"extends": ["./a.json"], "keymaps": []}`)
+
+	_, err := keymap.Load(strings.NewReader(`{"extends": ["./a.json"], "keymaps": []}`),
+		keymap.LoadOptions{Dir: dir})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "extends cycle")
+}
+
+func TestLoad_ExtendsMissingFile(t *testing.T) {
+	_, err := keymap.Load(strings.NewReader(`{"extends": ["./missing.json"], "keymaps": []}`),
+		keymap.LoadOptions{Dir: t.TempDir()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read extends './missing.json'")
+}
+
+func TestLoadAndSave_ExtendsRestoresInheritedUnbind(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "team.json"), `{
+  "keymaps": [],
+  "unbind": ["cmd+k", "cmd+j"],
+  "editors": {"zed": {"keymaps": [], "unbind": ["ctrl+t"]}}
+}`)
+	km, err := keymap.Load(strings.NewReader(`{"extends": ["./team.json"], "keymaps": []}`),
+		keymap.LoadOptions{Dir: dir})
+	require.NoError(t, err)
+	require.Len(t, km.Unbind, 2)
+
+	edited := km
+	edited.Unbind = []keybinding.Keybinding{mustNewKeybinding("cmd+j")}
+	edited.Editors = map[string]keymap.Overrides{"zed": {}}
+
+	var buf bytes.Buffer
+	require.NoError(t, keymap.Save(&buf, edited, keymap.SaveOptions{Platform: platform.PlatformMacOS}))
+	assert.JSONEq(t, `{
+  "version": "1.0",
+  "extends": ["./team.json"],
+  "keymaps": [],
+  "restore": ["cmd+k"],
+  "editors": {"zed": {"keymaps": [], "restore": ["ctrl+t"]}}
+}`, buf.String())
+
+	reloaded, err := keymap.Load(&buf, keymap.LoadOptions{Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, []keybinding.Keybinding{mustNewKeybinding("cmd+j")}, reloaded.Unbind)
+	assert.Equal(t, []keybinding.Keybinding{mustNewKeybinding("cmd+j")},
+		reloaded.Resolve(platform.PlatformMacOS, "zed").Unbind)
+}
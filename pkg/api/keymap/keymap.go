@@ -14,6 +14,18 @@ import (
 
 type Keymap struct {
 	Actions []Action
+	// Unbind lists keys whose editor default bindings are removed, whatever action they run.
+	// Editors that remove bindings per key, such as Zed and Helix, import their removals here.
+	Unbind []keybinding.Keybinding
+	// Platforms and Editors hold the override layers of the "platforms" and "editors" sections,
+	// keyed by platform and editor type. Use Resolve to apply them.
+	Platforms map[platform.Platform]Overrides
+	Editors   map[string]Overrides
+	// Extends lists the keymaps this one inherits from, e.g. "base:vscode-mac" or "./team.json".
+	// Load merges them into the fields above and keeps the merged result in Inherited, so that
+	// Save writes only what differs from it.
+	Extends   []string
+	Inherited *Keymap
 }
 
 // HasAction returns true if the keymap contains an action with the given name.
@@ -29,6 +41,16 @@ func (k *Keymap) HasAction(name string) bool {
 type Action struct {
 	Name     string
 	Bindings []keybinding.Keybinding
+	// Scope optionally restricts where Bindings apply. Actions with the same Name but different
+	// scopes are kept apart.
+	Scope Scope
+	// Metadata is carried through import and dedup so user notes are not lost when the file is rewritten.
+	Metadata Metadata
+	// Unbind lists keys whose editor default binding to this action is removed.
+	Unbind []keybinding.Keybinding
+	// UnbindAll removes every editor default binding of the action. It is written as "keybinding": null,
+	// or as "unbindAll": true when the action also has bindings.
+	UnbindAll bool
 }
 
 const (
@@ -41,11 +63,17 @@ var (
 
 // LoadOptions provides advanced options for loading a OneKeymap config.
 type LoadOptions struct {
-	// Reserved for future use
+	// Platform and Editor, when set, make Load return the effective keymap for that platform and
+	// editor type instead of the keymap with its override layers. See Keymap.Resolve.
+	Platform platform.Platform
+	Editor   string
+	// Dir is the directory relative "extends" paths are resolved against, usually the directory
+	// of the file being loaded. Empty means the working directory.
+	Dir string
 }
 
 // Load reads from reader and builds a keymap.
-func Load(reader io.Reader, _ LoadOptions) (Keymap, error) {
+func Load(reader io.Reader, opt LoadOptions) (Keymap, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return Keymap{}, err
@@ -60,7 +88,14 @@ func Load(reader io.Reader, _ LoadOptions) (Keymap, error) {
 		return Keymap{}, err
 	}
 
-	return buildKeymapFromFriendly(friendlyData)
+	km, err := buildKeymapFromFriendly(friendlyData, opt.Dir, nil)
+	if err != nil {
+		return Keymap{}, err
+	}
+	if opt.Platform != "" || opt.Editor != "" {
+		km = km.Resolve(opt.Platform, opt.Editor)
+	}
+	return km, nil
 }
 
 // SaveOptions provides advanced options for saving a OneKeymap config.
@@ -68,71 +103,190 @@ type SaveOptions struct {
 	Platform platform.Platform
 }
 
-// Save writes the keymap to the writer in JSON format.
+// Save writes the keymap to the writer in JSON format. A keymap with an extends chain is written
+// as its extends list plus the entries that differ from the inherited keymap, with inherited
+// keymap-level unbind keys it no longer has listed in "restore".
 func Save(writer io.Writer, km Keymap, opt SaveOptions) error {
+	km, restore := km.delta()
 	friendlyData := oneKeymapSetting{}
-	friendlyData.Keymaps = make([]oneKeymapConfig, 0)
 	friendlyData.Version = configVersion
+	friendlyData.Extends = km.Extends
 
-	// Group keybindings by action name
-	grouped := make(map[string]*oneKeymapConfig)
-	var order []string
+	p := opt.Platform
+	if p == "" {
+		p = platform.PlatformMacOS
+	}
+	formatOpt := keybinding.FormatOption{
+		Platform:  p,
+		Separator: "+",
+	}
 
-	for _, action := range km.Actions {
-		if _, exists := grouped[action.Name]; !exists {
-			grouped[action.Name] = &oneKeymapConfig{
-				ID:         action.Name,
-				Keybinding: make(keybindingStrings, 0),
-			}
-			order = append(order, action.Name)
+	friendlyData.Keymaps, friendlyData.Unbind = friendlyLayer(km.Actions, km.Unbind, formatOpt)
+	friendlyData.Restore = formatKeybindings(restore.Unbind, formatOpt)
+	for p, o := range km.Platforms {
+		if friendlyData.Platforms == nil {
+			friendlyData.Platforms = make(map[string]oneKeymapLayer)
+		}
+		friendlyData.Platforms[string(p)] = newOneKeymapLayer(o, formatOpt)
+	}
+	for p, r := range restore.Platforms {
+		if friendlyData.Platforms == nil {
+			friendlyData.Platforms = make(map[string]oneKeymapLayer)
+		}
+		layer := friendlyData.Platforms[string(p)]
+		layer.Restore = formatKeybindings(r.Unbind, formatOpt)
+		if layer.Keymaps == nil {
+			layer.Keymaps = []oneKeymapConfig{}
 		}
+		friendlyData.Platforms[string(p)] = layer
+	}
+	for editor, o := range km.Editors {
+		if friendlyData.Editors == nil {
+			friendlyData.Editors = make(map[string]oneKeymapLayer)
+		}
+		friendlyData.Editors[editor] = newOneKeymapLayer(o, formatOpt)
+	}
+	for editor, r := range restore.Editors {
+		if friendlyData.Editors == nil {
+			friendlyData.Editors = make(map[string]oneKeymapLayer)
+		}
+		layer := friendlyData.Editors[editor]
+		layer.Restore = formatKeybindings(r.Unbind, formatOpt)
+		if layer.Keymaps == nil {
+			layer.Keymaps = []oneKeymapConfig{}
+		}
+		friendlyData.Editors[editor] = layer
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ") // Use 2 spaces for indentation
+	return encoder.Encode(friendlyData)
+}
 
-		config := grouped[action.Name]
-		p := opt.Platform
-		if p == "" {
-			p = platform.PlatformMacOS
+func newOneKeymapLayer(o Overrides, formatOpt keybinding.FormatOption) oneKeymapLayer {
+	var layer oneKeymapLayer
+	layer.Keymaps, layer.Unbind = friendlyLayer(o.Actions, o.Unbind, formatOpt)
+	return layer
+}
+
+// friendlyLayer converts actions and keymap-level unbind keys to the file format, grouping
+// bindings by action name and scope and sorting entries by ID.
+func friendlyLayer(
+	actions []Action,
+	unbind []keybinding.Keybinding,
+	formatOpt keybinding.FormatOption,
+) ([]oneKeymapConfig, []string) {
+	grouped := make(map[actionKey]*oneKeymapConfig)
+	var order []actionKey
+
+	for _, action := range actions {
+		key := actionKey{Name: action.Name, Scope: action.Scope}
+		if _, exists := grouped[key]; !exists {
+			grouped[key] = &oneKeymapConfig{
+				ID:    action.Name,
+				Scope: string(action.Scope),
+			}
+			order = append(order, key)
 		}
 
+		config := grouped[key]
+		config.setMetadata(config.metadata().Merge(action.Metadata))
+
 		for _, binding := range action.Bindings {
-			keys := binding.String(keybinding.FormatOption{
-				Platform:  p,
-				Separator: "+",
-			})
-			config.Keybinding = append(config.Keybinding, keys)
+			config.Keybinding = append(config.Keybinding, binding.String(formatOpt))
+		}
+		for _, binding := range action.Unbind {
+			config.Unbind = append(config.Unbind, binding.String(formatOpt))
 		}
+		config.UnbindAll = config.UnbindAll || action.UnbindAll
 	}
 
-	for _, name := range order {
-		friendlyData.Keymaps = append(friendlyData.Keymaps, *grouped[name])
+	configs := make([]oneKeymapConfig, 0, len(order))
+	for _, key := range order {
+		config := grouped[key]
+		if config.UnbindAll && len(config.Keybinding) == 0 {
+			// An empty, non-nil list is written as "keybinding": null.
+			config.Keybinding = keybindingStrings{}
+			config.UnbindAll = false
+		}
+		configs = append(configs, *config)
 	}
+	unbindStrings := formatKeybindings(unbind, formatOpt)
 
-	sort.Slice(friendlyData.Keymaps, func(i, j int) bool {
-		return friendlyData.Keymaps[i].ID < friendlyData.Keymaps[j].ID
+	sort.SliceStable(configs, func(i, j int) bool {
+		if configs[i].ID != configs[j].ID {
+			return configs[i].ID < configs[j].ID
+		}
+		return configs[i].Scope < configs[j].Scope
 	})
+	return configs, unbindStrings
+}
 
-	encoder := json.NewEncoder(writer)
-	encoder.SetIndent("", "  ") // Use 2 spaces for indentation
-	return encoder.Encode(friendlyData)
+func formatKeybindings(kbs []keybinding.Keybinding, formatOpt keybinding.FormatOption) []string {
+	var strs []string
+	for _, kb := range kbs {
+		strs = append(strs, kb.String(formatOpt))
+	}
+	return strs
 }
 
 // oneKeymapSetting is the root struct for the user config file.
 type oneKeymapSetting struct {
 	Version string            `json:"version"`
+	Extends []string          `json:"extends,omitempty"`
 	Keymaps []oneKeymapConfig `json:"keymaps"`
+	Unbind  []string          `json:"unbind,omitempty"`
+	// Restore lists keymap-level unbind keys inherited through extends that this keymap does not unbind.
+	Restore   []string                  `json:"restore,omitempty"`
+	Platforms map[string]oneKeymapLayer `json:"platforms,omitempty"`
+	Editors   map[string]oneKeymapLayer `json:"editors,omitempty"`
+}
+
+// oneKeymapLayer is an override section, e.g. "platforms": {"linux": {...}}.
+type oneKeymapLayer struct {
+	Keymaps []oneKeymapConfig `json:"keymaps"`
+	Unbind  []string          `json:"unbind,omitempty"`
+	Restore []string          `json:"restore,omitempty"`
 }
 
 // oneKeymapConfig is a struct that matches the user config file format.
 type oneKeymapConfig struct {
-	ID         string            `json:"id,omitempty"`
-	Keybinding keybindingStrings `json:"keybinding,omitempty"`
-	Comment    string            `json:"comment,omitempty"`
+	ID string `json:"id,omitempty"`
+	// Keybinding is nil when absent and empty but non-nil when the file says "keybinding": null.
+	Keybinding keybindingStrings `json:"keybinding,omitzero"`
+	Unbind     []string          `json:"unbind,omitempty"`
+	// UnbindAll removes every default binding of an action that also has bindings.
+	UnbindAll bool     `json:"unbindAll,omitempty"`
+	Scope     string   `json:"scope,omitempty"`
+	Comment   string   `json:"comment,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+func (c *oneKeymapConfig) metadata() Metadata {
+	return Metadata{Comment: c.Comment, Tags: c.Tags}
+}
+
+func (c *oneKeymapConfig) setMetadata(m Metadata) {
+	c.Comment, c.Tags = m.Comment, m.Tags
+}
+
+// actionKey identifies the entry an action's bindings are grouped under.
+type actionKey struct {
+	Name  string
+	Scope Scope
 }
 
 // keybindingStrings is a custom type to handle single or multiple keybindings.
 type keybindingStrings []string
 
 // UnmarshalJSON allows keybindingStrings to be unmarshalled from either a single string or an array of strings.
+// null unmarshals to an empty, non-nil list, which marks the action as unbound; an empty array is left nil.
 func (ks *keybindingStrings) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*ks = keybindingStrings{}
+		return nil
+	}
+
 	var s string
 	if err := json.Unmarshal(data, &s); err == nil {
 		*ks = []string{s}
@@ -141,6 +295,9 @@ func (ks *keybindingStrings) UnmarshalJSON(data []byte) error {
 
 	var ss []string
 	if err := json.Unmarshal(data, &ss); err == nil {
+		if len(ss) == 0 {
+			ss = nil
+		}
 		*ks = ss
 		return nil
 	}
@@ -149,7 +306,11 @@ func (ks *keybindingStrings) UnmarshalJSON(data []byte) error {
 }
 
 // MarshalJSON allows keybindingStrings to be marshalled to a single string if it contains only one element.
+// An empty list is marshalled as null.
 func (ks keybindingStrings) MarshalJSON() ([]byte, error) {
+	if len(ks) == 0 {
+		return []byte("null"), nil
+	}
 	if len(ks) == 1 {
 		return json.Marshal(ks[0])
 	}
@@ -171,56 +332,164 @@ func parseOneKeymapSetting(data []byte) (oneKeymapSetting, error) {
 	var unknownFieldsPresent bool
 	for field := range raw {
 		switch field {
-		case "keymaps", "version":
+		case "keymaps", "version", "extends", "unbind", "restore", "platforms", "editors":
 			// allowed fields
 		default:
 			unknownFieldsPresent = true
 		}
 	}
 
-	if len(friendlyData.Keymaps) == 0 && unknownFieldsPresent {
+	if len(friendlyData.Keymaps) == 0 && len(friendlyData.Unbind) == 0 && unknownFieldsPresent {
 		return oneKeymapSetting{}, errInvalidConfig
 	}
 
 	return friendlyData, nil
 }
 
-// buildKeymapFromFriendly converts the friendly format to the API Keymap.
-func buildKeymapFromFriendly(friendlyData oneKeymapSetting) (Keymap, error) {
-	km := Keymap{}
-	grouped := make(map[string]*Action)
-	var order []string
+// buildKeymapFromFriendly converts the friendly format to the API Keymap, resolving its extends
+// chain against dir. stack holds the files being loaded, to detect extends cycles.
+func buildKeymapFromFriendly(friendlyData oneKeymapSetting, dir string, stack []string) (Keymap, error) {
+	actions, unbind, err := buildLayerFromFriendly(friendlyData.Keymaps, friendlyData.Unbind)
+	if err != nil {
+		return Keymap{}, err
+	}
+	km := Keymap{Actions: actions, Unbind: unbind}
+	// restore holds the "restore" lists in the keymap-level unbind lists of each section.
+	var restore Keymap
+	if restore.Unbind, err = parseRestore(friendlyData.Restore); err != nil {
+		return Keymap{}, err
+	}
+
+	for name, layer := range friendlyData.Platforms {
+		p := platform.Platform(name)
+		if !p.IsValid() {
+			return Keymap{}, fmt.Errorf("unknown platform '%s' in platforms", name)
+		}
+		o, err := buildOverridesFromFriendly(layer)
+		if err != nil {
+			return Keymap{}, fmt.Errorf("platforms.%s: %w", name, err)
+		}
+		r, err := parseRestore(layer.Restore)
+		if err != nil {
+			return Keymap{}, fmt.Errorf("platforms.%s: %w", name, err)
+		}
+		if km.Platforms == nil {
+			km.Platforms = make(map[platform.Platform]Overrides)
+			restore.Platforms = make(map[platform.Platform]Overrides)
+		}
+		km.Platforms[p] = o
+		restore.Platforms[p] = Overrides{Unbind: r}
+	}
+	for editor, layer := range friendlyData.Editors {
+		o, err := buildOverridesFromFriendly(layer)
+		if err != nil {
+			return Keymap{}, fmt.Errorf("editors.%s: %w", editor, err)
+		}
+		r, err := parseRestore(layer.Restore)
+		if err != nil {
+			return Keymap{}, fmt.Errorf("editors.%s: %w", editor, err)
+		}
+		if km.Editors == nil {
+			km.Editors = make(map[string]Overrides)
+			restore.Editors = make(map[string]Overrides)
+		}
+		km.Editors[editor] = o
+		restore.Editors[editor] = Overrides{Unbind: r}
+	}
+
+	if len(friendlyData.Extends) == 0 {
+		return km, nil
+	}
+	inherited, err := resolveExtends(friendlyData.Extends, dir, stack)
+	if err != nil {
+		return Keymap{}, err
+	}
+	km = inherit(inherited.withoutUnbind(restore), km)
+	km.Extends = friendlyData.Extends
+	km.Inherited = &inherited
+	return km, nil
+}
+
+func buildOverridesFromFriendly(layer oneKeymapLayer) (Overrides, error) {
+	actions, unbind, err := buildLayerFromFriendly(layer.Keymaps, layer.Unbind)
+	if err != nil {
+		return Overrides{}, err
+	}
+	return Overrides{Actions: actions, Unbind: unbind}, nil
+}
 
-	for _, fk := range friendlyData.Keymaps {
-		action, exists := grouped[fk.ID]
+// buildLayerFromFriendly converts entries and keymap-level unbind keys of one section,
+// merging entries with the same ID and scope.
+func buildLayerFromFriendly(
+	configs []oneKeymapConfig,
+	unbindStrings []string,
+) ([]Action, []keybinding.Keybinding, error) {
+	var actions []Action
+	grouped := make(map[actionKey]*Action)
+	var order []actionKey
+
+	for _, fk := range configs {
+		scope, err := ParseScope(fk.Scope)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid scope for id '%s': %w", fk.ID, err)
+		}
+		key := actionKey{Name: fk.ID, Scope: scope}
+		action, exists := grouped[key]
 		if !exists {
 			action = &Action{
 				Name:     fk.ID,
 				Bindings: make([]keybinding.Keybinding, 0),
+				Scope:    scope,
 			}
-			grouped[fk.ID] = action
-			order = append(order, fk.ID)
-		}
-
-		for _, keybindingStr := range fk.Keybinding {
-			kb, err := keybinding.NewKeybinding(keybindingStr, keybinding.ParseOption{
-				Separator: "+",
-			})
-			if err != nil {
-				return Keymap{}, fmt.Errorf(
-					"failed to parse keybinding '%s' for id '%s': %w",
-					keybindingStr,
-					fk.ID,
-					err,
-				)
-			}
-			action.Bindings = append(action.Bindings, kb)
+			grouped[key] = action
+			order = append(order, key)
+		}
+		action.Metadata = action.Metadata.Merge(fk.metadata())
+		action.UnbindAll = action.UnbindAll || fk.UnbindAll || (fk.Keybinding != nil && len(fk.Keybinding) == 0)
+
+		bindings, bad, err := parseKeybindingStrings(fk.Keybinding)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse keybinding '%s' for id '%s': %w", bad, fk.ID, err)
+		}
+		action.Bindings = append(action.Bindings, bindings...)
+
+		unbind, bad, err := parseKeybindingStrings(fk.Unbind)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse unbind '%s' for id '%s': %w", bad, fk.ID, err)
 		}
+		action.Unbind = append(action.Unbind, unbind...)
 	}
 
-	for _, name := range order {
-		km.Actions = append(km.Actions, *grouped[name])
+	for _, key := range order {
+		actions = append(actions, *grouped[key])
 	}
 
-	return km, nil
+	unbind, bad, err := parseKeybindingStrings(unbindStrings)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse unbind '%s': %w", bad, err)
+	}
+	return actions, unbind, nil
+}
+
+func parseRestore(keys []string) ([]keybinding.Keybinding, error) {
+	restore, bad, err := parseKeybindingStrings(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse restore '%s': %w", bad, err)
+	}
+	return restore, nil
+}
+
+// parseKeybindingStrings parses keys, returning the offending string on error.
+func parseKeybindingStrings(keys []string) ([]keybinding.Keybinding, string, error) {
+	var bindings []keybinding.Keybinding
+	for _, keybindingStr := range keys {
+		kb, err := keybinding.NewKeybinding(keybindingStr, keybinding.ParseOption{
+			Separator: "+",
+		})
+		if err != nil {
+			return nil, keybindingStr, err
+		}
+		bindings = append(bindings, kb)
+	}
+	return bindings, "", nil
 }
@@ -356,3 +356,200 @@ func TestSaveEmptyKeymap(t *testing.T) {
 	keymaps := result["keymaps"].([]interface{})
 	assert.Empty(t, keymaps)
 }
+
+func TestLoadAndSaveRoundTrip_Scope(t *testing.T) {
+	originalJSON := `{
+  "version": "1.0",
+  "keymaps": [
+    {
+      "id": "actions.clipboard.copy",
+      "keybinding": "cmd+c"
+    },
+    {
+      "id": "actions.clipboard.copy",
+      "keybinding": "y",
+      "scope": "editorFocus && vimNormal"
+    }
+  ]
+}`
+
+	km, err := keymap.Load(strings.NewReader(originalJSON), keymap.LoadOptions{})
+	require.NoError(t, err)
+	require.Len(t, km.Actions, 2)
+	assert.Equal(t, keymap.Scope(""), km.Actions[0].Scope)
+	assert.Equal(t, keymap.Scope("editorFocus && vimNormal"), km.Actions[1].Scope)
+
+	var buf bytes.Buffer
+	require.NoError(t, keymap.Save(&buf, km, keymap.SaveOptions{Platform: platform.PlatformMacOS}))
+	assert.JSONEq(t, originalJSON, buf.String())
+
+	_, err = keymap.Load(strings.NewReader(`{"keymaps": [{"id": "a", "keybinding": "x", "scope": "nowhere"}]}`),
+		keymap.LoadOptions{})
+	require.Error(t, err)
+}
+
+func TestLoadAndSaveRoundTrip_Metadata(t *testing.T) {
+	originalJSON := `{
+  "version": "1.0",
+  "keymaps": [
+    {
+      "id": "actions.clipboard.copy",
+      "keybinding": "cmd+c",
+      "comment": "same as the terminal",
+      "tags": ["muscle-memory", "shared"]
+    },
+    {
+      "id": "actions.clipboard.paste",
+      "keybinding": "cmd+v"
+    }
+  ]
+}`
+
+	km, err := keymap.Load(strings.NewReader(originalJSON), keymap.LoadOptions{})
+	require.NoError(t, err)
+	require.Len(t, km.Actions, 2)
+	assert.Equal(t, keymap.Metadata{
+		Comment: "same as the terminal",
+		Tags:    []string{"muscle-memory", "shared"},
+	}, km.Actions[0].Metadata)
+	assert.True(t, km.Actions[1].Metadata.IsZero())
+
+	var buf bytes.Buffer
+	require.NoError(t, keymap.Save(&buf, km, keymap.SaveOptions{Platform: platform.PlatformMacOS}))
+	assert.JSONEq(t, originalJSON, buf.String())
+}
+
+func TestLoadAndSaveRoundTrip_Unbind(t *testing.T) {
+	originalJSON := `{
+  "version": "1.0",
+  "keymaps": [
+    {
+      "id": "actions.clipboard.copy",
+      "keybinding": "cmd+c",
+      "unbind": ["cmd+insert"]
+    },
+    {
+      "id": "actions.clipboard.paste",
+      "keybinding": null
+    }
+  ],
+  "unbind": ["cmd+k"]
+}`
+
+	km, err := keymap.Load(strings.NewReader(originalJSON), keymap.LoadOptions{})
+	require.NoError(t, err)
+	require.Len(t, km.Actions, 2)
+
+	copyAction := km.Actions[0]
+	assert.Equal(t, []keybinding.Keybinding{mustNewKeybinding("cmd+insert")}, copyAction.Unbind)
+	assert.False(t, copyAction.UnbindAll)
+
+	pasteAction := km.Actions[1]
+	assert.Empty(t, pasteAction.Bindings)
+	assert.True(t, pasteAction.UnbindAll)
+
+	assert.Equal(t, []keybinding.Keybinding{mustNewKeybinding("cmd+k")}, km.Unbind)
+
+	var buf bytes.Buffer
+	require.NoError(t, keymap.Save(&buf, km, keymap.SaveOptions{Platform: platform.PlatformMacOS}))
+	assert.JSONEq(t, originalJSON, buf.String())
+}
+
+func TestLoadAndSaveRoundTrip_UnbindAllWithBindings(t *testing.T) {
+	originalJSON := `{
+  "version": "1.0",
+  "keymaps": [
+    {
+      "id": "actions.clipboard.copy",
+      "keybinding": "cmd+c",
+      "unbindAll": true
+    }
+  ]
+}`
+
+	km, err := keymap.Load(strings.NewReader(originalJSON), keymap.LoadOptions{})
+	require.NoError(t, err)
+	require.Len(t, km.Actions, 1)
+	assert.True(t, km.Actions[0].UnbindAll)
+	assert.Equal(t, []keybinding.Keybinding{mustNewKeybinding("cmd+c")}, km.Actions[0].Bindings)
+
+	var buf bytes.Buffer
+	require.NoError(t, keymap.Save(&buf, km, keymap.SaveOptions{Platform: platform.PlatformMacOS}))
+	assert.JSONEq(t, originalJSON, buf.String())
+
+	reloaded, err := keymap.Load(&buf, keymap.LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, km.Actions, reloaded.Actions)
+}
+
+func TestLoad_EmptyKeybindingArrayIsNotUnbind(t *testing.T) {
+	km, err := keymap.Load(strings.NewReader(`{"version": "1.0", "keymaps": [{"id": "a", "keybinding": []}]}`),
+		keymap.LoadOptions{})
+	require.NoError(t, err)
+	require.Len(t, km.Actions, 1)
+	assert.False(t, km.Actions[0].UnbindAll)
+
+	var buf bytes.Buffer
+	require.NoError(t, keymap.Save(&buf, km, keymap.SaveOptions{Platform: platform.PlatformMacOS}))
+	assert.JSONEq(t, `{"version": "1.0", "keymaps": [{"id": "a"}]}`, buf.String())
+}
+
+func TestLoadAndSaveRoundTrip_Overrides(t *testing.T) {
+	originalJSON := `{
+  "version": "1.0",
+  "keymaps": [
+    {
+      "id": "actions.terminal.new",
+      "keybinding": "cmd+t"
+    }
+  ],
+  "platforms": {
+    "linux": {
+      "keymaps": [
+        {
+          "id": "actions.terminal.new",
+          "keybinding": "ctrl+alt+t"
+        }
+      ]
+    }
+  },
+  "editors": {
+    "zed": {
+      "keymaps": [
+        {
+          "id": "actions.zed.only",
+          "keybinding": "cmd+x"
+        }
+      ]
+    }
+  }
+}`
+
+	km, err := keymap.Load(strings.NewReader(originalJSON), keymap.LoadOptions{})
+	require.NoError(t, err)
+	require.Len(t, km.Actions, 1)
+	require.Contains(t, km.Platforms, platform.PlatformLinux)
+	require.Contains(t, km.Editors, "zed")
+
+	var buf bytes.Buffer
+	require.NoError(t, keymap.Save(&buf, km, keymap.SaveOptions{Platform: platform.PlatformMacOS}))
+	assert.JSONEq(t, originalJSON, buf.String())
+
+	effective, err := keymap.Load(strings.NewReader(originalJSON), keymap.LoadOptions{
+		Platform: platform.PlatformLinux,
+		Editor:   "zed",
+	})
+	require.NoError(t, err)
+	assert.Nil(t, effective.Platforms)
+	assert.Nil(t, effective.Editors)
+	require.Len(t, effective.Actions, 2)
+	assert.Equal(t, []keybinding.Keybinding{mustNewKeybinding("ctrl+alt+t")}, effective.Actions[0].Bindings)
+	assert.Equal(t, "actions.zed.only", effective.Actions[1].Name)
+}
+
+func TestLoad_UnknownOverridePlatform(t *testing.T) {
+	_, err := keymap.Load(strings.NewReader(`{"keymaps": [], "platforms": {"beos": {"keymaps": []}}}`),
+		keymap.LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown platform 'beos'")
+}
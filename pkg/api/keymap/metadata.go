@@ -0,0 +1,30 @@
+package keymap
+
+import "slices"
+
+// Metadata is user-authored information about an action entry, such as why a key was chosen.
+// It is stored in onekeymap.json and has no effect on import or export.
+type Metadata struct {
+	Comment string
+	Tags    []string
+}
+
+// IsZero reports whether no metadata is set.
+func (m Metadata) IsZero() bool {
+	return m.Comment == "" && len(m.Tags) == 0
+}
+
+// Merge combines metadata of two entries for the same action. The receiver's comment wins when set;
+// tags are unioned in order of first appearance.
+func (m Metadata) Merge(other Metadata) Metadata {
+	merged := Metadata{Comment: m.Comment}
+	if merged.Comment == "" {
+		merged.Comment = other.Comment
+	}
+	for _, tag := range slices.Concat(m.Tags, other.Tags) {
+		if !slices.Contains(merged.Tags, tag) {
+			merged.Tags = append(merged.Tags, tag)
+		}
+	}
+	return merged
+}
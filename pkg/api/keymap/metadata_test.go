@@ -0,0 +1,39 @@
+package keymap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+)
+
+func TestMetadata_Merge(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b keymap.Metadata
+		want keymap.Metadata
+	}{
+		{
+			name: "receiver comment wins",
+			a:    keymap.Metadata{Comment: "first"},
+			b:    keymap.Metadata{Comment: "second"},
+			want: keymap.Metadata{Comment: "first"},
+		},
+		{
+			name: "empty comment is filled",
+			a:    keymap.Metadata{Tags: []string{"a"}},
+			b:    keymap.Metadata{Comment: "second", Tags: []string{"b", "a"}},
+			want: keymap.Metadata{Comment: "second", Tags: []string{"a", "b"}},
+		},
+		{
+			name: "zero values stay zero",
+			want: keymap.Metadata{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.a.Merge(tt.b))
+		})
+	}
+}
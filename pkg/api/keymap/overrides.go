@@ -0,0 +1,183 @@
+package keymap
+
+import (
+	"slices"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+// Overrides is an override layer of onekeymap.json, such as "platforms": {"linux": {...}} or
+// "editors": {"zed": {...}}. Each entry replaces the base entry with the same ID and scope,
+// or adds it when the base has none; "keybinding": [] leaves the action unbound in that layer.
+type Overrides struct {
+	Actions []Action
+	Unbind  []keybinding.Keybinding
+}
+
+// Resolve returns the effective keymap on platform p in the given editor type. Platform overrides
+// are applied first, then editor overrides. An empty p or editor skips that layer.
+// The result has no override layers.
+func (k Keymap) Resolve(p platform.Platform, editor string) Keymap {
+	resolved := Keymap{Actions: k.Actions, Unbind: k.Unbind}
+	if o, ok := k.Platforms[p]; ok && p != "" {
+		resolved = resolved.apply(o)
+	}
+	if o, ok := k.Editors[editor]; ok && editor != "" {
+		resolved = resolved.apply(o)
+	}
+	return resolved
+}
+
+// apply returns k with the entries of o replacing or added to its actions. k is not modified.
+func (k Keymap) apply(o Overrides) Keymap {
+	k.Actions = append([]Action(nil), k.Actions...)
+	k.Unbind = append([]keybinding.Keybinding(nil), k.Unbind...)
+	index := make(map[actionKey]int, len(k.Actions))
+	for i, a := range k.Actions {
+		index[actionKey{Name: a.Name, Scope: a.Scope}] = i
+	}
+	for _, a := range o.Actions {
+		key := actionKey{Name: a.Name, Scope: a.Scope}
+		if i, ok := index[key]; ok {
+			a.Metadata = a.Metadata.Merge(k.Actions[i].Metadata)
+			k.Actions[i] = a
+			continue
+		}
+		index[key] = len(k.Actions)
+		k.Actions = append(k.Actions, a)
+	}
+	k.Unbind = UnionKeybindings(k.Unbind, o.Unbind)
+	return k
+}
+
+// Unresolve returns k changed so that k.Resolve(p, editor) is effective, e.g. to store a keymap
+// imported back from an editor. Each entry that differs from k.Resolve(p, editor) is written to
+// the layer it resolves from: the editor layer when that has the entry, else the platform layer,
+// else the top level. Keymap-level unbind keys are added to the top level and removed from every
+// layer that has them. Entries that neither bind nor unbind keys count as absent. k is not modified.
+func (k Keymap) Unresolve(p platform.Platform, editor string, effective Keymap) Keymap {
+	before, beforeOrder := entriesWithKeys(k.Resolve(p, editor).Actions)
+	after, afterOrder := entriesWithKeys(effective.Actions)
+	k.Platforms, k.Editors = cloneLayers(k.Platforms), cloneLayers(k.Editors)
+
+	for _, key := range afterOrder {
+		if b, ok := before[key]; ok && b.SameKeys(after[key]) {
+			continue
+		}
+		k = k.setLayerEntry(p, editor, key, after[key])
+	}
+	for _, key := range beforeOrder {
+		if _, ok := after[key]; !ok {
+			k = k.setLayerEntry(p, editor, key, Action{Name: key.Name, Scope: key.Scope})
+		}
+	}
+
+	old := make(map[string]bool)
+	for _, kb := range k.Resolve(p, editor).Unbind {
+		old[canonicalKeybinding(kb)] = true
+	}
+	keep := make(map[string]bool, len(effective.Unbind))
+	var added []keybinding.Keybinding
+	for _, kb := range effective.Unbind {
+		keep[canonicalKeybinding(kb)] = true
+		if !old[canonicalKeybinding(kb)] {
+			added = append(added, kb)
+		}
+	}
+	removed := func(kb keybinding.Keybinding) bool { return !keep[canonicalKeybinding(kb)] }
+	k.Unbind = UnionKeybindings(slices.DeleteFunc(slices.Clone(k.Unbind), removed), added)
+	if o, ok := k.Platforms[p]; ok && p != "" {
+		o.Unbind = slices.DeleteFunc(slices.Clone(o.Unbind), removed)
+		k.Platforms[p] = o
+	}
+	if o, ok := k.Editors[editor]; ok && editor != "" {
+		o.Unbind = slices.DeleteFunc(slices.Clone(o.Unbind), removed)
+		k.Editors[editor] = o
+	}
+	return k
+}
+
+// setLayerEntry replaces the entries for key in the layer the effective entry on p in editor
+// resolves from. An entry without keys is dropped, unless a lower layer binds key and the entry
+// has to override it.
+func (k Keymap) setLayerEntry(p platform.Platform, editor string, key actionKey, a Action) Keymap {
+	if o, ok := k.Editors[editor]; ok && editor != "" && hasEntry(o.Actions, key) {
+		_, below := entriesWithKeys(k.Resolve(p, "").Actions)
+		o.Actions = setEntry(o.Actions, key, a, slices.Contains(below, key))
+		k.Editors[editor] = o
+		return k
+	}
+	if o, ok := k.Platforms[p]; ok && p != "" && hasEntry(o.Actions, key) {
+		_, below := entriesWithKeys(k.Actions)
+		o.Actions = setEntry(o.Actions, key, a, slices.Contains(below, key))
+		k.Platforms[p] = o
+		return k
+	}
+	k.Actions = setEntry(k.Actions, key, a, false)
+	return k
+}
+
+// setEntry replaces the entries for key with a, at the position of the first one, or appends a.
+// An a without keys is dropped unless keepEmpty is set. actions is not modified.
+func setEntry(actions []Action, key actionKey, a Action, keepEmpty bool) []Action {
+	keep := keepEmpty || len(a.Bindings) > 0 || a.HasUnbind()
+	out := make([]Action, 0, len(actions)+1)
+	placed := false
+	for _, existing := range actions {
+		if (actionKey{Name: existing.Name, Scope: existing.Scope}) != key {
+			out = append(out, existing)
+			continue
+		}
+		if !placed && keep {
+			a.Metadata = existing.Metadata.Merge(a.Metadata)
+			out = append(out, a)
+		}
+		placed = true
+	}
+	if !placed && keep {
+		out = append(out, a)
+	}
+	return out
+}
+
+func hasEntry(actions []Action, key actionKey) bool {
+	return slices.ContainsFunc(actions, func(a Action) bool {
+		return actionKey{Name: a.Name, Scope: a.Scope} == key
+	})
+}
+
+// entriesWithKeys returns the entries that bind or unbind keys, combined by action ID and scope,
+// and their keys in order of first appearance.
+func entriesWithKeys(actions []Action) (map[actionKey]Action, []actionKey) {
+	byKey := make(map[actionKey]Action, len(actions))
+	var order []actionKey
+	for _, a := range actions {
+		if len(a.Bindings) == 0 && !a.HasUnbind() {
+			continue
+		}
+		key := actionKey{Name: a.Name, Scope: a.Scope}
+		existing, ok := byKey[key]
+		if !ok {
+			byKey[key] = a
+			order = append(order, key)
+			continue
+		}
+		existing.Bindings = UnionKeybindings(slices.Clone(existing.Bindings), a.Bindings)
+		existing.Unbind = UnionKeybindings(slices.Clone(existing.Unbind), a.Unbind)
+		existing.UnbindAll = existing.UnbindAll || a.UnbindAll
+		byKey[key] = existing
+	}
+	return byKey, order
+}
+
+func cloneLayers[K comparable](layers map[K]Overrides) map[K]Overrides {
+	if layers == nil {
+		return nil
+	}
+	out := make(map[K]Overrides, len(layers))
+	for key, o := range layers {
+		out[key] = Overrides{Actions: slices.Clone(o.Actions), Unbind: slices.Clone(o.Unbind)}
+	}
+	return out
+}
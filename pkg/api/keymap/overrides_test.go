@@ -0,0 +1,132 @@
+package keymap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+func TestKeymap_Resolve(t *testing.T) {
+	bind := func(name string, keys ...string) keymap.Action {
+		a := keymap.Action{Name: name}
+		for _, k := range keys {
+			a.Bindings = append(a.Bindings, mustNewKeybinding(k))
+		}
+		return a
+	}
+
+	base := keymap.Keymap{
+		Actions: []keymap.Action{
+			bind("actions.copy", "cmd+c"),
+			bind("actions.terminal", "cmd+t"),
+		},
+		Unbind: []keybinding.Keybinding{mustNewKeybinding("cmd+k")},
+		Platforms: map[platform.Platform]keymap.Overrides{
+			platform.PlatformLinux: {
+				Actions: []keymap.Action{bind("actions.terminal", "ctrl+alt+t")},
+			},
+		},
+		Editors: map[string]keymap.Overrides{
+			"zed": {
+				Actions: []keymap.Action{bind("actions.terminal"), bind("actions.zed.only", "cmd+x")},
+				Unbind:  []keybinding.Keybinding{mustNewKeybinding("cmd+j")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		platform platform.Platform
+		editor   string
+		expected keymap.Keymap
+	}{
+		{
+			name:     "no layers",
+			expected: keymap.Keymap{Actions: base.Actions, Unbind: base.Unbind},
+		},
+		{
+			name:     "platform layer replaces bindings",
+			platform: platform.PlatformLinux,
+			editor:   "vscode",
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{bind("actions.copy", "cmd+c"), bind("actions.terminal", "ctrl+alt+t")},
+				Unbind:  base.Unbind,
+			},
+		},
+		{
+			name:     "editor layer applies after platform layer",
+			platform: platform.PlatformLinux,
+			editor:   "zed",
+			expected: keymap.Keymap{
+				Actions: []keymap.Action{
+					bind("actions.copy", "cmd+c"),
+					bind("actions.terminal"),
+					bind("actions.zed.only", "cmd+x"),
+				},
+				Unbind: []keybinding.Keybinding{mustNewKeybinding("cmd+k"), mustNewKeybinding("cmd+j")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, base.Resolve(tt.platform, tt.editor))
+		})
+	}
+}
+
+func TestKeymap_Unresolve(t *testing.T) {
+	bind := func(name string, keys ...string) keymap.Action {
+		a := keymap.Action{Name: name}
+		for _, k := range keys {
+			a.Bindings = append(a.Bindings, mustNewKeybinding(k))
+		}
+		return a
+	}
+
+	base := keymap.Keymap{
+		Actions: []keymap.Action{
+			bind("actions.copy", "cmd+c"),
+			bind("actions.terminal", "cmd+t"),
+			bind("actions.save", "cmd+s"),
+		},
+		Platforms: map[platform.Platform]keymap.Overrides{
+			platform.PlatformLinux: {Actions: []keymap.Action{bind("actions.save", "ctrl+s")}},
+		},
+		Editors: map[string]keymap.Overrides{
+			"zed": {Actions: []keymap.Action{bind("actions.terminal", "cmd+j")}},
+		},
+	}
+
+	// In zed on Linux: the terminal binding, from the zed layer, and the save binding, from the
+	// Linux layer, change; copy, from the top level, is removed and a new action is added.
+	effective := keymap.Keymap{Actions: []keymap.Action{
+		bind("actions.terminal", "cmd+shift+j"),
+		bind("actions.save", "ctrl+alt+s"),
+		bind("actions.new", "cmd+n"),
+	}}
+	got := base.Unresolve(platform.PlatformLinux, "zed", effective)
+
+	assert.Equal(t, []keymap.Action{
+		bind("actions.terminal", "cmd+t"),
+		bind("actions.save", "cmd+s"),
+		bind("actions.new", "cmd+n"),
+	}, got.Actions)
+	assert.Equal(t, []keymap.Action{bind("actions.save", "ctrl+alt+s")},
+		got.Platforms[platform.PlatformLinux].Actions)
+	assert.Equal(t, []keymap.Action{bind("actions.terminal", "cmd+shift+j")}, got.Editors["zed"].Actions)
+	assert.Equal(t, effective.Actions, got.Resolve(platform.PlatformLinux, "zed").Actions[:3])
+	assert.Len(t, base.Actions, 3, "base is not modified")
+	assert.Equal(t, []keymap.Action{bind("actions.terminal", "cmd+j")}, base.Editors["zed"].Actions)
+
+	// Removing an entry the zed layer overrides leaves it unbound in zed only.
+	got = base.Unresolve("", "zed", keymap.Keymap{Actions: []keymap.Action{
+		bind("actions.copy", "cmd+c"),
+		bind("actions.save", "cmd+s"),
+	}})
+	assert.Equal(t, base.Actions, got.Actions)
+	assert.Equal(t, []keymap.Action{bind("actions.terminal")}, got.Editors["zed"].Actions)
+}
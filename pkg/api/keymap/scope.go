@@ -0,0 +1,109 @@
+package keymap
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ScopePredicate is an editor-neutral condition that a binding can be restricted to.
+type ScopePredicate string
+
+const (
+	// ScopeEditorFocus holds while a text editor has keyboard focus.
+	ScopeEditorFocus ScopePredicate = "editorFocus"
+	// ScopeTerminalFocus holds while the integrated terminal has keyboard focus.
+	ScopeTerminalFocus ScopePredicate = "terminalFocus"
+	// ScopeHasSelection holds while the editor has a non-empty selection.
+	ScopeHasSelection ScopePredicate = "hasSelection"
+	// ScopeVimNormal, ScopeVimInsert and ScopeVimVisual hold in the corresponding vim emulation mode.
+	ScopeVimNormal ScopePredicate = "vimNormal"
+	ScopeVimInsert ScopePredicate = "vimInsert"
+	ScopeVimVisual ScopePredicate = "vimVisual"
+)
+
+//nolint:gochecknoglobals // read-only vocabulary
+var scopePredicates = []ScopePredicate{
+	ScopeEditorFocus, ScopeTerminalFocus, ScopeHasSelection, ScopeVimNormal, ScopeVimInsert, ScopeVimVisual,
+}
+
+const (
+	scopeAnd = "&&"
+	scopeNot = "!"
+)
+
+// ScopeTerm is a predicate, optionally negated.
+type ScopeTerm struct {
+	Predicate ScopePredicate
+	Negated   bool
+}
+
+func (t ScopeTerm) String() string {
+	if t.Negated {
+		return scopeNot + string(t.Predicate)
+	}
+	return string(t.Predicate)
+}
+
+// Scope restricts an action's bindings to a conjunction of predicates, e.g. "editorFocus && !hasSelection".
+// The zero value means unscoped: editors use the context from the action mapping.
+// Plugins translate scopes to their own syntax, such as VSCode `when` clauses or Zed contexts.
+type Scope string
+
+// NewScope builds a scope from terms.
+func NewScope(terms ...ScopeTerm) Scope {
+	parts := make([]string, 0, len(terms))
+	for _, t := range terms {
+		parts = append(parts, t.String())
+	}
+	return Scope(strings.Join(parts, " "+scopeAnd+" "))
+}
+
+// ParseScope parses and normalizes a scope expression. Only && and ! are supported.
+func ParseScope(s string) (Scope, error) {
+	terms, err := parseScopeTerms(s)
+	if err != nil {
+		return "", err
+	}
+	return NewScope(terms...), nil
+}
+
+// Terms returns the terms of the scope. A scope that does not parse has no terms.
+func (s Scope) Terms() []ScopeTerm {
+	terms, err := parseScopeTerms(string(s))
+	if err != nil {
+		return nil
+	}
+	return terms
+}
+
+// IsZero reports whether the scope is unscoped.
+func (s Scope) IsZero() bool {
+	return strings.TrimSpace(string(s)) == ""
+}
+
+func parseScopeTerms(s string) ([]ScopeTerm, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var terms []ScopeTerm
+	for _, part := range strings.Split(s, scopeAnd) {
+		part = strings.TrimSpace(part)
+		term := ScopeTerm{}
+		if rest, ok := strings.CutPrefix(part, scopeNot); ok {
+			term.Negated = true
+			part = strings.TrimSpace(rest)
+		}
+		term.Predicate = ScopePredicate(part)
+		if !term.Predicate.IsValid() {
+			return nil, fmt.Errorf("unknown scope predicate %q in %q", part, s)
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// IsValid reports whether p is part of the scope vocabulary.
+func (p ScopePredicate) IsValid() bool {
+	return slices.Contains(scopePredicates, p)
+}
@@ -0,0 +1,48 @@
+package keymap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+)
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    keymap.Scope
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: ""},
+		{name: "single predicate", input: "editorFocus", want: "editorFocus"},
+		{name: "normalizes spacing", input: "editorFocus&&  ! hasSelection", want: "editorFocus && !hasSelection"},
+		{name: "unknown predicate", input: "editorFocus && sidebarFocus", wantErr: true},
+		{name: "disjunction is not supported", input: "editorFocus || terminalFocus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := keymap.ParseScope(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScope_Terms(t *testing.T) {
+	scope := keymap.NewScope(
+		keymap.ScopeTerm{Predicate: keymap.ScopeEditorFocus},
+		keymap.ScopeTerm{Predicate: keymap.ScopeVimInsert, Negated: true},
+	)
+	assert.Equal(t, keymap.Scope("editorFocus && !vimInsert"), scope)
+	assert.Equal(t, []keymap.ScopeTerm{
+		{Predicate: keymap.ScopeEditorFocus},
+		{Predicate: keymap.ScopeVimInsert, Negated: true},
+	}, scope.Terms())
+}
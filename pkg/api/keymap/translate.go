@@ -0,0 +1,217 @@
+package keymap
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keychord"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keycode"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+// ModifierStrategy decides how bindings are translated when a keymap moves between platforms whose
+// primary modifier differs: cmd on macOS, ctrl elsewhere.
+type ModifierStrategy string
+
+const (
+	// ModifierKeep leaves bindings as they are.
+	ModifierKeep ModifierStrategy = "keep"
+	// ModifierMetaToCtrl replaces the source platform's primary modifier with the target's, e.g. cmd+c
+	// becomes ctrl+c when leaving macOS and ctrl+c becomes cmd+c when moving to macOS. A binding of
+	// another action that already used the translated key loses it.
+	ModifierMetaToCtrl ModifierStrategy = "meta-to-ctrl"
+	// ModifierCtrlAltOnCollision is ModifierMetaToCtrl, but a binding that already used the translated
+	// key moves to the same key with alt added, e.g. ctrl+c becomes ctrl+alt+c. It is dropped when
+	// that key is taken too.
+	ModifierCtrlAltOnCollision ModifierStrategy = "ctrl-alt-on-collision"
+)
+
+// ModifierStrategies lists the supported strategies.
+//
+//nolint:gochecknoglobals // read-only vocabulary
+var ModifierStrategies = []ModifierStrategy{ModifierKeep, ModifierMetaToCtrl, ModifierCtrlAltOnCollision}
+
+// ParseModifierStrategy parses a strategy name. An empty name is ModifierKeep.
+func ParseModifierStrategy(name string) (ModifierStrategy, error) {
+	if name == "" {
+		return ModifierKeep, nil
+	}
+	s := ModifierStrategy(name)
+	if !slices.Contains(ModifierStrategies, s) {
+		return "", fmt.Errorf("unknown modifier strategy '%s', expected one of %v", name, ModifierStrategies)
+	}
+	return s, nil
+}
+
+// TranslateOptions configures TranslateModifiers.
+type TranslateOptions struct {
+	// From is the platform the bindings were written for.
+	From platform.Platform
+	// To is the platform they are translated to.
+	To       platform.Platform
+	Strategy ModifierStrategy
+}
+
+// ModifierCollision is a key that a translated binding took from a binding of another action in the
+// same scope.
+type ModifierCollision struct {
+	// Key is the contested key after translation, e.g. ctrl+c.
+	Key keybinding.Keybinding
+	// Action is the action whose translated binding took Key, e.g. the one bound to cmd+c.
+	Action string
+	// Displaced is the action that was bound to Key before translation.
+	Displaced string
+	Scope     Scope
+	// MovedTo is the key the displaced binding moved to. It has no chords when the binding was dropped.
+	MovedTo keybinding.Keybinding
+}
+
+// TranslationReport lists the collisions resolved by TranslateModifiers.
+type TranslationReport struct {
+	Collisions []ModifierCollision
+}
+
+// TranslateModifiers returns the keymap with its modifiers translated for another platform according
+// to opt.Strategy. Only top-level entries are translated: override layers are already written for a
+// specific platform or editor. k is not modified.
+func (k Keymap) TranslateModifiers(opt TranslateOptions) (Keymap, TranslationReport) {
+	from, to := primaryModifier(opt.From), primaryModifier(opt.To)
+	if opt.Strategy == "" || opt.Strategy == ModifierKeep || opt.From == "" || opt.To == "" || from == to {
+		return k, TranslationReport{}
+	}
+
+	out := k
+	out.Unbind = translateKeybindings(k.Unbind, from, to)
+	out.Actions = make([]Action, len(k.Actions))
+
+	// Translated bindings claim their keys first; untranslated bindings of other actions give way.
+	claimed := make(map[scopedKeybinding]string)
+	translated := make([][]bool, len(k.Actions))
+	for i, a := range k.Actions {
+		a.Bindings = slices.Clone(a.Bindings)
+		a.Unbind = translateKeybindings(a.Unbind, from, to)
+		translated[i] = make([]bool, len(a.Bindings))
+		for j, kb := range a.Bindings {
+			t, ok := translateKeybinding(kb, from, to)
+			if !ok {
+				continue
+			}
+			a.Bindings[j], translated[i][j] = t, true
+			key := scopedKeybinding{Scope: a.Scope, Key: canonicalKeybinding(t)}
+			if _, exists := claimed[key]; !exists {
+				claimed[key] = a.Name
+			}
+		}
+		out.Actions[i] = a
+	}
+
+	taken := make(map[scopedKeybinding]bool, len(claimed))
+	for key := range claimed {
+		taken[key] = true
+	}
+	for _, a := range out.Actions {
+		for _, kb := range a.Bindings {
+			taken[scopedKeybinding{Scope: a.Scope, Key: canonicalKeybinding(kb)}] = true
+		}
+	}
+
+	var report TranslationReport
+	for i := range out.Actions {
+		a := &out.Actions[i]
+		kept := make([]keybinding.Keybinding, 0, len(a.Bindings))
+		for j, kb := range a.Bindings {
+			holder, ok := claimed[scopedKeybinding{Scope: a.Scope, Key: canonicalKeybinding(kb)}]
+			if translated[i][j] || !ok {
+				kept = append(kept, kb)
+				continue
+			}
+			if holder == a.Name {
+				// The action already has this key through a translated binding.
+				continue
+			}
+			c := ModifierCollision{Key: kb, Action: holder, Displaced: a.Name, Scope: a.Scope}
+			if opt.Strategy == ModifierCtrlAltOnCollision {
+				moved, ok := addAlt(kb, to)
+				key := scopedKeybinding{Scope: a.Scope, Key: canonicalKeybinding(moved)}
+				if ok && !taken[key] {
+					taken[key] = true
+					c.MovedTo = moved
+					kept = append(kept, moved)
+				}
+			}
+			report.Collisions = append(report.Collisions, c)
+		}
+		a.Bindings = kept
+	}
+	return out, report
+}
+
+type scopedKeybinding struct {
+	Scope Scope
+	Key   string
+}
+
+// primaryModifier is the modifier used for most application shortcuts on p.
+func primaryModifier(p platform.Platform) keycode.KeyModifier {
+	if p == platform.PlatformMacOS {
+		return keycode.KeyModifierMeta
+	}
+	return keycode.KeyModifierCtrl
+}
+
+func translateKeybindings(kbs []keybinding.Keybinding, from, to keycode.KeyModifier) []keybinding.Keybinding {
+	if kbs == nil {
+		return nil
+	}
+	out := make([]keybinding.Keybinding, len(kbs))
+	for i, kb := range kbs {
+		out[i] = kb
+		if t, ok := translateKeybinding(kb, from, to); ok {
+			out[i] = t
+		}
+	}
+	return out
+}
+
+// translateKeybinding replaces from with to in every chord that uses from but not to, so that
+// cmd+ctrl+f, which already uses both, is left alone. It reports whether any chord changed.
+func translateKeybinding(kb keybinding.Keybinding, from, to keycode.KeyModifier) (keybinding.Keybinding, bool) {
+	return mapChords(kb, func(c keychord.KeyChord) (keychord.KeyChord, bool) {
+		i := slices.Index(c.Modifiers, from)
+		if i < 0 || slices.Contains(c.Modifiers, to) {
+			return c, false
+		}
+		c.Modifiers = slices.Clone(c.Modifiers)
+		c.Modifiers[i] = to
+		return c, true
+	})
+}
+
+// addAlt adds alt to every chord that uses primary. It reports false when no chord could take it.
+func addAlt(kb keybinding.Keybinding, primary keycode.KeyModifier) (keybinding.Keybinding, bool) {
+	return mapChords(kb, func(c keychord.KeyChord) (keychord.KeyChord, bool) {
+		if !slices.Contains(c.Modifiers, primary) || slices.Contains(c.Modifiers, keycode.KeyModifierAlt) {
+			return c, false
+		}
+		c.Modifiers = append(slices.Clone(c.Modifiers), keycode.KeyModifierAlt)
+		return c, true
+	})
+}
+
+func mapChords(
+	kb keybinding.Keybinding,
+	f func(keychord.KeyChord) (keychord.KeyChord, bool),
+) (keybinding.Keybinding, bool) {
+	chords := make([]keychord.KeyChord, len(kb.KeyChords))
+	changed := false
+	for i, c := range kb.KeyChords {
+		var ok bool
+		chords[i], ok = f(c)
+		changed = changed || ok
+	}
+	if !changed {
+		return kb, false
+	}
+	return keybinding.Keybinding{KeyChords: chords}, true
+}
@@ -0,0 +1,118 @@
+package keymap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+func TestKeymap_TranslateModifiers(t *testing.T) {
+	bindings := func(a keymap.Action) []string {
+		var out []string
+		for _, kb := range a.Bindings {
+			out = append(out, kb.String(keybinding.FormatOption{Platform: platform.PlatformMacOS, Separator: "+"}))
+		}
+		return out
+	}
+
+	macKeymap := keymap.Keymap{
+		Actions: []keymap.Action{
+			newAction("actions.edit.copy", "cmd+c"),
+			newAction("actions.terminal.interrupt", "ctrl+c"),
+			newActionWithBindings("actions.view.fullscreen", "cmd+ctrl+f", "cmd+k cmd+f"),
+			newAction("actions.edit.paste", "cmd+v"),
+			newAction("actions.edit.paste.other", "ctrl+v"),
+			newAction("actions.edit.paste.alt", "ctrl+alt+v"),
+		},
+		Unbind: []keybinding.Keybinding{mustNewKeybinding("cmd+w")},
+	}
+
+	t.Run("keep", func(t *testing.T) {
+		got, report := macKeymap.TranslateModifiers(keymap.TranslateOptions{
+			From: platform.PlatformMacOS, To: platform.PlatformLinux, Strategy: keymap.ModifierKeep,
+		})
+		assert.Equal(t, macKeymap, got)
+		assert.Empty(t, report.Collisions)
+	})
+
+	t.Run("same primary modifier", func(t *testing.T) {
+		got, report := macKeymap.TranslateModifiers(keymap.TranslateOptions{
+			From: platform.PlatformWindows, To: platform.PlatformLinux, Strategy: keymap.ModifierMetaToCtrl,
+		})
+		assert.Equal(t, macKeymap, got)
+		assert.Empty(t, report.Collisions)
+	})
+
+	t.Run("meta to ctrl drops displaced bindings", func(t *testing.T) {
+		got, report := macKeymap.TranslateModifiers(keymap.TranslateOptions{
+			From: platform.PlatformMacOS, To: platform.PlatformLinux, Strategy: keymap.ModifierMetaToCtrl,
+		})
+		require.Len(t, got.Actions, 6)
+		assert.Equal(t, []string{"ctrl+c"}, bindings(got.Actions[0]))
+		assert.Empty(t, bindings(got.Actions[1]))
+		assert.Equal(t, []string{"cmd+ctrl+f", "ctrl+k ctrl+f"}, bindings(got.Actions[2]))
+		assert.Equal(t, []string{"ctrl+v"}, bindings(got.Actions[3]))
+		assert.Empty(t, bindings(got.Actions[4]))
+		assert.Equal(t, []string{"ctrl+alt+v"}, bindings(got.Actions[5]))
+		assert.Equal(t, []keybinding.Keybinding{mustNewKeybinding("ctrl+w")}, got.Unbind)
+
+		require.Len(t, report.Collisions, 2)
+		assert.Equal(t, "actions.edit.copy", report.Collisions[0].Action)
+		assert.Equal(t, "actions.terminal.interrupt", report.Collisions[0].Displaced)
+		assert.Empty(t, report.Collisions[0].MovedTo.KeyChords)
+
+		// The input keymap is not modified.
+		assert.Equal(t, []string{"cmd+c"}, bindings(macKeymap.Actions[0]))
+	})
+
+	t.Run("ctrl+alt on collision", func(t *testing.T) {
+		got, report := macKeymap.TranslateModifiers(keymap.TranslateOptions{
+			From: platform.PlatformMacOS, To: platform.PlatformLinux, Strategy: keymap.ModifierCtrlAltOnCollision,
+		})
+		assert.Equal(t, []string{"ctrl+alt+c"}, bindings(got.Actions[1]))
+		// ctrl+alt+v is taken, so the displaced ctrl+v binding is dropped.
+		assert.Empty(t, bindings(got.Actions[4]))
+
+		require.Len(t, report.Collisions, 2)
+		assert.Equal(t, "ctrl+alt+c", report.Collisions[0].MovedTo.String(keybinding.FormatOption{Separator: "+"}))
+		assert.Equal(t, "actions.edit.paste.other", report.Collisions[1].Displaced)
+		assert.Empty(t, report.Collisions[1].MovedTo.KeyChords)
+	})
+
+	t.Run("collisions are per scope", func(t *testing.T) {
+		terminalInterrupt := newAction("actions.terminal.interrupt", "ctrl+c")
+		terminalInterrupt.Scope = keymap.NewScope(keymap.ScopeTerm{Predicate: keymap.ScopeTerminalFocus})
+		km := keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "cmd+c"), terminalInterrupt}}
+
+		got, report := km.TranslateModifiers(keymap.TranslateOptions{
+			From: platform.PlatformMacOS, To: platform.PlatformWindows, Strategy: keymap.ModifierMetaToCtrl,
+		})
+		assert.Equal(t, []string{"ctrl+c"}, bindings(got.Actions[1]))
+		assert.Empty(t, report.Collisions)
+	})
+
+	t.Run("to macOS", func(t *testing.T) {
+		km := keymap.Keymap{Actions: []keymap.Action{newAction("actions.edit.copy", "ctrl+c")}}
+		got, _ := km.TranslateModifiers(keymap.TranslateOptions{
+			From: platform.PlatformLinux, To: platform.PlatformMacOS, Strategy: keymap.ModifierMetaToCtrl,
+		})
+		assert.Equal(t, []string{"cmd+c"}, bindings(got.Actions[0]))
+	})
+}
+
+func TestParseModifierStrategy(t *testing.T) {
+	s, err := keymap.ParseModifierStrategy("")
+	require.NoError(t, err)
+	assert.Equal(t, keymap.ModifierKeep, s)
+
+	s, err = keymap.ParseModifierStrategy("ctrl-alt-on-collision")
+	require.NoError(t, err)
+	assert.Equal(t, keymap.ModifierCtrlAltOnCollision, s)
+
+	_, err = keymap.ParseModifierStrategy("swap")
+	assert.Error(t, err)
+}
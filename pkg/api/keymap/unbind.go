@@ -0,0 +1,54 @@
+package keymap
+
+import (
+	"slices"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+// HasUnbind reports whether the action removes any editor default binding.
+func (a Action) HasUnbind() bool {
+	return a.UnbindAll || len(a.Unbind) > 0
+}
+
+// UnionKeybindings appends the keybindings of more that are not yet in kbs, preserving order.
+// Keybindings without chords are dropped.
+func UnionKeybindings(kbs, more []keybinding.Keybinding) []keybinding.Keybinding {
+	seen := make(map[string]bool, len(kbs)+len(more))
+	for _, kb := range kbs {
+		seen[canonicalKeybinding(kb)] = true
+	}
+	for _, kb := range more {
+		if len(kb.KeyChords) == 0 {
+			continue
+		}
+		key := canonicalKeybinding(kb)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		kbs = append(kbs, kb)
+	}
+	return kbs
+}
+
+func canonicalKeybinding(kb keybinding.Keybinding) string {
+	return kb.String(keybinding.FormatOption{Platform: platform.PlatformMacOS, Separator: "+"})
+}
+
+// SameKeys reports whether two entries bind and unbind the same keys, ignoring order and metadata.
+func (a Action) SameKeys(b Action) bool {
+	return a.UnbindAll == b.UnbindAll &&
+		slices.Equal(canonicalKeybindings(a.Bindings), canonicalKeybindings(b.Bindings)) &&
+		slices.Equal(canonicalKeybindings(a.Unbind), canonicalKeybindings(b.Unbind))
+}
+
+func canonicalKeybindings(kbs []keybinding.Keybinding) []string {
+	keys := make([]string, 0, len(kbs))
+	for _, kb := range kbs {
+		keys = append(keys, canonicalKeybinding(kb))
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
@@ -1,6 +1,9 @@
 package platform
 
-import "runtime"
+import (
+	"fmt"
+	"runtime"
+)
 
 type Platform string
 
@@ -21,3 +24,25 @@ func Current() Platform {
 		return PlatformLinux
 	}
 }
+
+// Parse parses a platform name as used by the --platform flag. An empty name is the current platform.
+func Parse(name string) (Platform, error) {
+	if name == "" {
+		return Current(), nil
+	}
+	p := Platform(name)
+	if !p.IsValid() {
+		return "", fmt.Errorf("unknown platform '%s', expected one of macos, linux, windows", name)
+	}
+	return p, nil
+}
+
+// IsValid reports whether p is one of the known platforms.
+func (p Platform) IsValid() bool {
+	switch p {
+	case PlatformMacOS, PlatformWindows, PlatformLinux:
+		return true
+	default:
+		return false
+	}
+}
@@ -8,10 +8,16 @@ const (
 	// VSCode series
 
 	// EditorTypeVSCode represents Visual Studio Code editor.
-	EditorTypeVSCode       EditorType = "vscode"
-	EditorTypeWindsurf     EditorType = "vscode.windsurf"
-	EditorTypeWindsurfNext EditorType = "vscode.windsurf-next"
-	EditorTypeCursor       EditorType = "vscode.cursor"
+	EditorTypeVSCode         EditorType = "vscode"
+	EditorTypeWindsurf       EditorType = "vscode.windsurf"
+	EditorTypeWindsurfNext   EditorType = "vscode.windsurf-next"
+	EditorTypeCursor         EditorType = "vscode.cursor"
+	EditorTypeVSCodeInsiders EditorType = "vscode.insiders"
+	EditorTypeVSCodium       EditorType = "vscode.vscodium"
+	EditorTypeCodeOSS        EditorType = "vscode.code-oss"
+	EditorTypePositron       EditorType = "vscode.positron"
+	EditorTypeTrae           EditorType = "vscode.trae"
+	EditorTypeKiro           EditorType = "vscode.kiro"
 
 	// IntelliJ idea series
 
@@ -25,11 +31,28 @@ const (
 	EditorTypeRubyMine          EditorType = "intellij.rubymine"
 	EditorTypeGoLand            EditorType = "intellij.goland"
 	EditorTypeRustRover         EditorType = "intellij.rustrover"
+	// EditorTypeAndroidStudio represents Android Studio, whose settings live under Google rather than JetBrains.
+	EditorTypeAndroidStudio EditorType = "intellij.android-studio"
+	EditorTypeDataGrip      EditorType = "intellij.datagrip"
+	EditorTypeRider         EditorType = "intellij.rider"
+	EditorTypeDataSpell     EditorType = "intellij.dataspell"
+	EditorTypeAqua          EditorType = "intellij.aqua"
+	EditorTypeWriterside    EditorType = "intellij.writerside"
 
-	EditorTypeZed   EditorType = "zed"
-	EditorTypeVim   EditorType = "vim"
-	EditorTypeHelix EditorType = "helix"
-	EditorTypeXcode EditorType = "xcode"
+	EditorTypeZed     EditorType = "zed"
+	EditorTypeVim     EditorType = "vim"
+	EditorTypeNeovim  EditorType = "vim.neovim"
+	EditorTypeHelix   EditorType = "helix"
+	EditorTypeXcode   EditorType = "xcode"
+	EditorTypeSublime EditorType = "sublime"
+	EditorTypeEmacs   EditorType = "emacs"
+	EditorTypeEclipse EditorType = "eclipse"
+	EditorTypeLapce   EditorType = "lapce"
+	EditorTypeKakoune EditorType = "kakoune"
+	EditorTypePulsar  EditorType = "pulsar"
+
+	// EditorTypeVisualStudio represents Visual Studio (not VSCode), configured through .vssettings files.
+	EditorTypeVisualStudio EditorType = "visualstudio"
 
 	// EditorTypeBasekeymap is used to import base intellij/vscode/zed keymap
 	EditorTypeBasekeymap EditorType = "basekeymap"
@@ -46,6 +69,18 @@ func (e EditorType) AppName() string {
 		return "Windsurf Next"
 	case EditorTypeCursor:
 		return "Cursor"
+	case EditorTypeVSCodeInsiders:
+		return "VSCode Insiders"
+	case EditorTypeVSCodium:
+		return "VSCodium"
+	case EditorTypeCodeOSS:
+		return "Code - OSS"
+	case EditorTypePositron:
+		return "Positron"
+	case EditorTypeTrae:
+		return "Trae"
+	case EditorTypeKiro:
+		return "Kiro"
 	case EditorTypeIntelliJ:
 		return "IntelliJ IDEA"
 	case EditorTypeIntelliJCommunity:
@@ -64,14 +99,42 @@ func (e EditorType) AppName() string {
 		return "GoLand"
 	case EditorTypeRustRover:
 		return "RustRover"
+	case EditorTypeAndroidStudio:
+		return "Android Studio"
+	case EditorTypeDataGrip:
+		return "DataGrip"
+	case EditorTypeRider:
+		return "Rider"
+	case EditorTypeDataSpell:
+		return "DataSpell"
+	case EditorTypeAqua:
+		return "Aqua"
+	case EditorTypeWriterside:
+		return "Writerside"
 	case EditorTypeZed:
 		return "Zed"
 	case EditorTypeVim:
-		return "Vim"
+		return "Vim (Experimental)"
+	case EditorTypeNeovim:
+		return "Neovim (Experimental)"
 	case EditorTypeHelix:
 		return "Helix (Experimental)"
 	case EditorTypeXcode:
 		return "Xcode (Experimental)"
+	case EditorTypeSublime:
+		return "Sublime Text (Experimental)"
+	case EditorTypeEmacs:
+		return "Emacs (Experimental)"
+	case EditorTypeEclipse:
+		return "Eclipse (Experimental)"
+	case EditorTypeVisualStudio:
+		return "Visual Studio (Experimental)"
+	case EditorTypeLapce:
+		return "Lapce (Experimental)"
+	case EditorTypeKakoune:
+		return "Kakoune (Experimental)"
+	case EditorTypePulsar:
+		return "Pulsar (Experimental)"
 	case EditorTypeBasekeymap:
 		return "Base Keymap - Import default keymap from intellij/vscode/zed..."
 	default:
@@ -26,6 +26,10 @@ type PluginImporter interface {
 type ConfigDetectOptions struct {
 	// Whether to in sandbox mode, in sandbox mode, shell command lookup is not effective, like `code` command for vscode can not be found
 	Sandbox bool
+
+	// Platform selects platform-specific config file names, e.g. "Default (OSX).sublime-keymap" for Sublime Text.
+	// If empty, defaults to the current runtime platform.
+	Platform platform.Platform
 }
 
 // PluginImportResult contains the result of an import operation.
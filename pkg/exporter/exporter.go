@@ -10,6 +10,7 @@ import (
 	"github.com/xinnjie/onekeymap-cli/internal/diff"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/exporterapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
 	"github.com/xinnjie/onekeymap-cli/pkg/metrics"
@@ -60,6 +61,23 @@ func (s *exporter) Export(
 		return nil, fmt.Errorf("failed to get exporter for %s: %w", opts.EditorType, err)
 	}
 
+	targetPlatform := opts.TargetPlatform
+	if targetPlatform == "" {
+		targetPlatform = platform.Current()
+	}
+	keymapPlatform := opts.KeymapPlatform
+	if keymapPlatform == "" {
+		keymapPlatform = platform.Current()
+	}
+	// Translate before applying the override layers for the target platform and editor: their
+	// entries are already written for it.
+	setting, translation := setting.TranslateModifiers(keymap.TranslateOptions{
+		From:     keymapPlatform,
+		To:       targetPlatform,
+		Strategy: opts.ModifierStrategy,
+	})
+	setting = setting.Resolve(targetPlatform, string(opts.EditorType))
+
 	var newConfigBuf bytes.Buffer
 	writer := io.MultiWriter(destination, &newConfigBuf)
 
@@ -76,7 +94,7 @@ func (s *exporter) Export(
 		ctx,
 		writer,
 		setting,
-		pluginapi.PluginExportOption{ExistingConfig: baseReadForPlugin},
+		pluginapi.PluginExportOption{ExistingConfig: baseReadForPlugin, TargetPlatform: targetPlatform},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to export config: %w", err)
@@ -113,6 +131,7 @@ func (s *exporter) Export(
 		Diff:        diffStr,
 		Coverage:    coverage,
 		SkipActions: report.SkipReport.SkipActions,
+		Translation: translation,
 	}, nil
 }
 
@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/exporterapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/exporter"
 	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
@@ -42,6 +44,135 @@ func TestExportService_PropagatesSkipActions(t *testing.T) {
 	assert.Equal(t, "a1", report.SkipActions[0].Action)
 }
 
+func TestExportService_ResolvesOverrides(t *testing.T) {
+	exp := &testExporter{}
+	service := newTestExportService(t, exp)
+
+	setting := keymap.Keymap{
+		Actions: []keymap.Action{{Name: "a1"}, {Name: "a2"}},
+		Platforms: map[platform.Platform]keymap.Overrides{
+			platform.Current(): {Actions: []keymap.Action{{Name: "a2", UnbindAll: true}}},
+		},
+		Editors: map[string]keymap.Overrides{
+			"test":  {Actions: []keymap.Action{{Name: "a3"}}},
+			"other": {Actions: []keymap.Action{{Name: "a4"}}},
+		},
+	}
+
+	var out bytes.Buffer
+	_, err := service.Export(context.Background(), &out, setting, exporterapi.ExportOptions{
+		EditorType: pluginapi.EditorType("test"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, keymap.Keymap{
+		Actions: []keymap.Action{{Name: "a1"}, {Name: "a2", UnbindAll: true}, {Name: "a3"}},
+	}, exp.received)
+}
+
+func TestExportService_TargetPlatform(t *testing.T) {
+	setting := keymap.Keymap{
+		Actions: []keymap.Action{{Name: "a1"}},
+		Platforms: map[platform.Platform]keymap.Overrides{
+			platform.PlatformWindows: {Actions: []keymap.Action{{Name: "a2"}}},
+			platform.PlatformLinux:   {Actions: []keymap.Action{{Name: "a3"}}},
+		},
+	}
+
+	t.Run("explicit target platform", func(t *testing.T) {
+		exp := &testExporter{}
+		service := newTestExportService(t, exp)
+		var out bytes.Buffer
+		_, err := service.Export(context.Background(), &out, setting, exporterapi.ExportOptions{
+			EditorType:     pluginapi.EditorType("test"),
+			TargetPlatform: platform.PlatformWindows,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, platform.PlatformWindows, exp.receivedPlatform)
+		assert.Equal(t, []keymap.Action{{Name: "a1"}, {Name: "a2"}}, exp.received.Actions)
+	})
+
+	t.Run("defaults to current platform", func(t *testing.T) {
+		exp := &testExporter{}
+		service := newTestExportService(t, exp)
+		var out bytes.Buffer
+		_, err := service.Export(context.Background(), &out, setting, exporterapi.ExportOptions{
+			EditorType: pluginapi.EditorType("test"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, platform.Current(), exp.receivedPlatform)
+	})
+}
+
+func TestExportService_TranslatesModifiers(t *testing.T) {
+	kb := func(s string) keybinding.Keybinding {
+		k, err := keybinding.NewKeybinding(s, keybinding.ParseOption{Separator: "+"})
+		require.NoError(t, err)
+		return k
+	}
+	setting := keymap.Keymap{Actions: []keymap.Action{
+		{Name: "copy", Bindings: []keybinding.Keybinding{kb("cmd+c")}},
+		{Name: "interrupt", Bindings: []keybinding.Keybinding{kb("ctrl+c")}},
+	}}
+
+	exp := &testExporter{}
+	service := newTestExportService(t, exp)
+	var out bytes.Buffer
+	report, err := service.Export(context.Background(), &out, setting, exporterapi.ExportOptions{
+		EditorType:       pluginapi.EditorType("test"),
+		TargetPlatform:   platform.PlatformLinux,
+		KeymapPlatform:   platform.PlatformMacOS,
+		ModifierStrategy: keymap.ModifierCtrlAltOnCollision,
+	})
+	require.NoError(t, err)
+
+	format := keybinding.FormatOption{Platform: platform.PlatformLinux, Separator: "+"}
+	require.Len(t, exp.received.Actions, 2)
+	assert.Equal(t, "ctrl+c", exp.received.Actions[0].Bindings[0].String(format))
+	assert.Equal(t, "ctrl+alt+c", exp.received.Actions[1].Bindings[0].String(format))
+	require.Len(t, report.Translation.Collisions, 1)
+	assert.Equal(t, "interrupt", report.Translation.Collisions[0].Displaced)
+}
+
+func TestExportService_DoesNotTranslateOverrides(t *testing.T) {
+	kb := func(s string) keybinding.Keybinding {
+		k, err := keybinding.NewKeybinding(s, keybinding.ParseOption{Separator: "+"})
+		require.NoError(t, err)
+		return k
+	}
+	// The Linux entries are written for Linux already: translated again, meta+t would become ctrl+t
+	// and ctrl+c would give way to copy.
+	setting := keymap.Keymap{
+		Actions: []keymap.Action{
+			{Name: "copy", Bindings: []keybinding.Keybinding{kb("cmd+c")}},
+			{Name: "terminal", Bindings: []keybinding.Keybinding{kb("cmd+t")}},
+		},
+		Platforms: map[platform.Platform]keymap.Overrides{
+			platform.PlatformLinux: {Actions: []keymap.Action{
+				{Name: "terminal", Bindings: []keybinding.Keybinding{kb("meta+t")}},
+				{Name: "interrupt", Bindings: []keybinding.Keybinding{kb("ctrl+c")}},
+			}},
+		},
+	}
+
+	exp := &testExporter{}
+	service := newTestExportService(t, exp)
+	var out bytes.Buffer
+	report, err := service.Export(context.Background(), &out, setting, exporterapi.ExportOptions{
+		EditorType:       pluginapi.EditorType("test"),
+		TargetPlatform:   platform.PlatformLinux,
+		KeymapPlatform:   platform.PlatformMacOS,
+		ModifierStrategy: keymap.ModifierCtrlAltOnCollision,
+	})
+	require.NoError(t, err)
+
+	format := keybinding.FormatOption{Platform: platform.PlatformLinux, Separator: "+"}
+	require.Len(t, exp.received.Actions, 3)
+	assert.Equal(t, "ctrl+c", exp.received.Actions[0].Bindings[0].String(format))
+	assert.Equal(t, "meta+t", exp.received.Actions[1].Bindings[0].String(format))
+	assert.Equal(t, "ctrl+c", exp.received.Actions[2].Bindings[0].String(format))
+	assert.Empty(t, report.Translation.Collisions)
+}
+
 func (p *testExportPlugin) EditorType() pluginapi.EditorType { return p.editorType }
 func (p *testExportPlugin) ConfigDetect(_ pluginapi.ConfigDetectOptions) ([]string, bool, error) {
 	return nil, false, pluginapi.ErrNotSupported
@@ -58,14 +189,18 @@ type testExporter struct {
 	exportEditorConfig any
 	reportDiff         *string
 	skipActions        []pluginapi.ExportSkipAction
+	received           keymap.Keymap
+	receivedPlatform   platform.Platform
 }
 
 func (e *testExporter) Export(
 	_ context.Context,
 	destination io.Writer,
-	_ keymap.Keymap,
+	setting keymap.Keymap,
 	opts pluginapi.PluginExportOption,
 ) (*pluginapi.PluginExportReport, error) {
+	e.received = setting
+	e.receivedPlatform = opts.TargetPlatform
 	if e.writeContent != "" {
 		_, _ = io.Copy(destination, strings.NewReader(e.writeContent))
 	}
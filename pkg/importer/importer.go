@@ -68,11 +68,15 @@ func (s *importer) Import(ctx context.Context, opts importerapi.ImportOptions) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to get importer for %s: %w", opts.EditorType, err)
 	}
-	res, err := importer.Import(ctx, opts.InputStream, pluginapi.PluginImportOption{})
+	res, err := importer.Import(ctx, opts.InputStream, pluginapi.PluginImportOption{SourcePlatform: opts.SourcePlatform})
 	if err != nil {
 		return nil, fmt.Errorf("failed to import config: %w", err)
 	}
-	setting := res.Keymap
+	setting, translation := res.Keymap.TranslateModifiers(keymap.TranslateOptions{
+		From:     orCurrentPlatform(opts.SourcePlatform),
+		To:       orCurrentPlatform(opts.KeymapPlatform),
+		Strategy: opts.ModifierStrategy,
+	})
 
 	// Normalize: merge same-action entries and deduplicate identical bindings before downstream logic
 	setting.Actions = dedup.Actions(setting.Actions)
@@ -85,7 +89,7 @@ func (s *importer) Import(ctx context.Context, opts importerapi.ImportOptions) (
 
 	// Check if Actions slice is nil (uninitialized), which indicates import failure
 	// An empty slice (len=0) is valid for clearing all bindings
-	if setting.Actions == nil {
+	if setting.Actions == nil && len(setting.Unbind) == 0 {
 		return nil, errors.New("failed to import config: no keybindings found")
 	}
 
@@ -95,33 +99,40 @@ func (s *importer) Import(ctx context.Context, opts importerapi.ImportOptions) (
 	}
 
 	// No baseline provided: all imported keymaps are additions.
-	if len(opts.Base.Actions) == 0 {
+	if len(opts.Base.Actions) == 0 && len(opts.Base.Unbind) == 0 {
+		setting = keepBaseLayers(setting, opts.Base)
 		changes := &importerapi.KeymapChanges{}
 		if len(setting.Actions) > 0 {
 			changes.Add = append(changes.Add, setting.Actions...)
 		}
 		return &importerapi.ImportResult{
-			Setting:    setting,
-			Changes:    changes,
-			Report:     report,
-			SkipReport: res.Report.SkipReport,
+			Setting:     setting,
+			Changes:     changes,
+			Report:      report,
+			SkipReport:  res.Report.SkipReport,
+			Translation: translation,
 		}, nil
 	}
 
-	// If baseline provided, first union baseline chords into current setting so unchanged chords are retained.
-	setting = unionWithBase(opts.Base, setting)
+	// The editor's keymap is the baseline resolved for its platform and editor type. Union the
+	// baseline chords into it so unchanged chords are retained, then write the differences back to
+	// the layers they resolve from, so overrides for one platform or editor stay in their layer.
+	effectiveBase := opts.Base.Resolve(orCurrentPlatform(opts.SourcePlatform), string(opts.EditorType))
+	setting = unionWithBase(effectiveBase, setting)
 	setting.Actions = dedup.Actions(setting.Actions)
 
 	// With baseline: compute changes via helper.
-	changes := s.calculateChanges(opts.Base, setting)
+	changes := s.calculateChanges(effectiveBase, setting)
+	setting = opts.Base.Unresolve(orCurrentPlatform(opts.SourcePlatform), string(opts.EditorType), setting)
 
 	// Safety: ensure dedup on output as well
 	setting.Actions = dedup.Actions(setting.Actions)
 	return &importerapi.ImportResult{
-		Setting:    setting,
-		Changes:    changes,
-		Report:     report,
-		SkipReport: res.Report.SkipReport,
+		Setting:     setting,
+		Changes:     changes,
+		Report:      report,
+		SkipReport:  res.Report.SkipReport,
+		Translation: translation,
 	}, nil
 }
 
@@ -242,10 +253,31 @@ func (s *importer) buildChangesResult(
 	return changes
 }
 
-// pairKey builds a stable identifier for an action by name and normalized key bindings
+func orCurrentPlatform(p platform.Platform) platform.Platform {
+	if p == "" {
+		return platform.Current()
+	}
+	return p
+}
+
+// keepBaseLayers copies what editors cannot report from the baseline: its override layers and
+// extends chain. Editors only know effective bindings.
+func keepBaseLayers(setting, base keymap.Keymap) keymap.Keymap {
+	setting.Platforms, setting.Editors = base.Platforms, base.Editors
+	setting.Extends, setting.Inherited = base.Extends, base.Inherited
+	return setting
+}
+
+// actionScopeKey identifies an action entry; the same action may appear once per scope.
+type actionScopeKey struct {
+	Name  string
+	Scope keymap.Scope
+}
+
+// pairKey builds a stable identifier for an action by name, scope and normalized key bindings
 func pairKey(action keymap.Action) string {
 	if len(action.Bindings) == 0 {
-		return action.Name + "\x00"
+		return action.Name + "\x00" + string(action.Scope) + "\x00"
 	}
 	// Format each binding
 	parts := make([]string, 0, len(action.Bindings))
@@ -264,7 +296,7 @@ func pairKey(action keymap.Action) string {
 		}
 	}
 	// Join with NUL to avoid ambiguity
-	sig := action.Name + "\x00"
+	sig := action.Name + "\x00" + string(action.Scope) + "\x00"
 	for _, p := range parts {
 		sig += p + "\x00"
 	}
@@ -280,42 +312,57 @@ func hasValidChord(action keymap.Action) bool {
 	return false
 }
 
-// unionWithBase merges baseline and imported settings per action id,
+// unionWithBase merges baseline and imported settings per action id and scope,
 // preserving baseline bindings and adding new imported bindings.
 func unionWithBase(base keymap.Keymap, imported keymap.Keymap) keymap.Keymap {
+	unbind := keymap.UnionKeybindings(append([]keybinding.Keybinding(nil), base.Unbind...), imported.Unbind)
 	if len(imported.Actions) == 0 {
+		base.Unbind = unbind
 		return base
 	}
 	if len(base.Actions) == 0 {
+		imported.Unbind = unbind
 		return imported
 	}
-	// index existing results by action id
-	out := keymap.Keymap{Actions: []keymap.Action{}}
-	byID := make(map[string]*keymap.Action)
+	// index existing results by action id and scope
+	out := keymap.Keymap{Actions: []keymap.Action{}, Unbind: unbind}
+	byID := make(map[actionScopeKey]int)
 
 	// start with baseline (so Before reflects baseline order/first occurrence)
 	for _, kb := range base.Actions {
 		// copy action
 		ab := keymap.Action{
-			Name:     kb.Name,
-			Bindings: append([]keybinding.Keybinding{}, kb.Bindings...),
+			Name:      kb.Name,
+			Bindings:  append([]keybinding.Keybinding{}, kb.Bindings...),
+			Scope:     kb.Scope,
+			Metadata:  kb.Metadata,
+			Unbind:    append([]keybinding.Keybinding(nil), kb.Unbind...),
+			UnbindAll: kb.UnbindAll,
 		}
 		out.Actions = append(out.Actions, ab)
-		byID[ab.Name] = &out.Actions[len(out.Actions)-1]
+		byID[actionScopeKey{ab.Name, ab.Scope}] = len(out.Actions) - 1
 	}
 	// merge imported bindings into corresponding actions (or create new action entries)
 	for _, kb := range imported.Actions {
-		existing, ok := byID[kb.Name]
+		pos, ok := byID[actionScopeKey{kb.Name, kb.Scope}]
 		if !ok {
 			// add as new action
 			ab := keymap.Action{
-				Name:     kb.Name,
-				Bindings: append([]keybinding.Keybinding{}, kb.Bindings...),
+				Name:      kb.Name,
+				Bindings:  append([]keybinding.Keybinding{}, kb.Bindings...),
+				Scope:     kb.Scope,
+				Metadata:  kb.Metadata,
+				Unbind:    append([]keybinding.Keybinding(nil), kb.Unbind...),
+				UnbindAll: kb.UnbindAll,
 			}
 			out.Actions = append(out.Actions, ab)
-			byID[ab.Name] = &out.Actions[len(out.Actions)-1]
+			byID[actionScopeKey{ab.Name, ab.Scope}] = len(out.Actions) - 1
 			continue
 		}
+		existing := &out.Actions[pos]
+		existing.Metadata = existing.Metadata.Merge(kb.Metadata)
+		existing.Unbind = keymap.UnionKeybindings(existing.Unbind, kb.Unbind)
+		existing.UnbindAll = existing.UnbindAll || kb.UnbindAll
 		// union bindings
 		for _, nb := range kb.Bindings {
 			if len(nb.KeyChords) == 0 {
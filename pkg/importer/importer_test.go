@@ -5,6 +5,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -13,6 +14,7 @@ import (
 	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
 	"github.com/xinnjie/onekeymap-cli/pkg/api/pluginapi"
 	"github.com/xinnjie/onekeymap-cli/pkg/importer"
 	"github.com/xinnjie/onekeymap-cli/pkg/mappings"
@@ -26,6 +28,8 @@ type testPlugin struct {
 	configPath  string
 	importData  keymap.Keymap
 	importError error
+	// importOption records the options passed to the last import.
+	importOption pluginapi.PluginImportOption
 }
 
 func newTestPlugin(
@@ -52,8 +56,9 @@ func (p *testPlugin) ConfigDetect(_ pluginapi.ConfigDetectOptions) ([]string, bo
 
 func (p *testPlugin) Importer() (pluginapi.PluginImporter, error) {
 	return &testPluginImporter{
-		importData:  p.importData,
-		importError: p.importError,
+		importData:   p.importData,
+		importError:  p.importError,
+		importOption: &p.importOption,
 	}, nil
 }
 
@@ -63,15 +68,17 @@ func (p *testPlugin) Exporter() (pluginapi.PluginExporter, error) {
 
 // testPluginImporter implements pluginapi.PluginImporter interface for testing.
 type testPluginImporter struct {
-	importData  keymap.Keymap
-	importError error
+	importData   keymap.Keymap
+	importError  error
+	importOption *pluginapi.PluginImportOption
 }
 
 func (i *testPluginImporter) Import(
 	_ context.Context,
 	_ io.Reader,
-	_ pluginapi.PluginImportOption,
+	opts pluginapi.PluginImportOption,
 ) (pluginapi.PluginImportResult, error) {
+	*i.importOption = opts
 	return pluginapi.PluginImportResult{Keymap: i.importData}, i.importError
 }
 
@@ -100,6 +107,11 @@ func newAction(name string, bindings ...string) keymap.Action {
 	return action
 }
 
+func withMetadata(action keymap.Action, comment string, tags ...string) keymap.Action {
+	action.Metadata = keymap.Metadata{Comment: comment, Tags: tags}
+	return action
+}
+
 func TestImportService_Import(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -227,6 +239,31 @@ func TestImportService_Import(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "keeps metadata of baseline actions",
+			baseData: keymap.Keymap{
+				Actions: []keymap.Action{
+					withMetadata(newAction("actions.editor.paste", "ctrl+v"), "muscle memory", "personal"),
+				},
+			},
+			importData: keymap.Keymap{
+				Actions: []keymap.Action{
+					newAction("actions.editor.paste", "ctrl+v"),
+					newAction("actions.editor.cut", "ctrl+x"),
+				},
+			},
+			expect: &importerapi.ImportResult{
+				Setting: keymap.Keymap{Actions: []keymap.Action{
+					withMetadata(newAction("actions.editor.paste", "ctrl+v"), "muscle memory", "personal"),
+					newAction("actions.editor.cut", "ctrl+x"),
+				}},
+				Changes: &importerapi.KeymapChanges{
+					Add: []keymap.Action{
+						newAction("actions.editor.cut", "ctrl+x"),
+					},
+				},
+			},
+		},
 		{
 			name: "calculates not removed keybindings",
 			baseData: keymap.Keymap{
@@ -349,3 +386,56 @@ func TestImportService_Import(t *testing.T) {
 		})
 	}
 }
+
+func TestImportService_Import_PassesSourcePlatform(t *testing.T) {
+	importData := keymap.Keymap{Actions: []keymap.Action{newAction("actions.editor.copy", "ctrl+c")}}
+	testPlug := newTestPlugin(pluginapi.EditorTypeVSCode, "", importData, nil)
+	registry := registry.NewRegistry()
+	registry.Register(testPlug)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	service := importer.NewImporter(registry, &mappings.MappingConfig{}, logger, metrics.NewNoop())
+
+	_, err := service.Import(context.Background(), importerapi.ImportOptions{
+		EditorType:     pluginapi.EditorTypeVSCode,
+		InputStream:    strings.NewReader(`{}`),
+		SourcePlatform: platform.PlatformWindows,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, platform.PlatformWindows, testPlug.importOption.SourcePlatform)
+}
+
+func TestImportService_Import_KeepsOverridesInTheirLayer(t *testing.T) {
+	base := keymap.Keymap{
+		Actions: []keymap.Action{
+			newAction("actions.editor.copy", "meta+c"),
+			newAction("actions.editor.paste", "meta+v"),
+		},
+		Editors: map[string]keymap.Overrides{
+			"zed": {Actions: []keymap.Action{newAction("actions.editor.copy", "alt+c")}},
+		},
+	}
+	// Zed reports what was exported to it, with the copy binding of its layer, plus a new paste binding.
+	importData := keymap.Keymap{Actions: []keymap.Action{
+		newAction("actions.editor.copy", "alt+c"),
+		newAction("actions.editor.paste", "meta+v", "shift+insert"),
+	}}
+	registry := registry.NewRegistry()
+	registry.Register(newTestPlugin(pluginapi.EditorTypeZed, "", importData, nil))
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	service := importer.NewImporter(registry, &mappings.MappingConfig{}, logger, metrics.NewNoop())
+
+	res, err := service.Import(context.Background(), importerapi.ImportOptions{
+		EditorType:  pluginapi.EditorTypeZed,
+		InputStream: strings.NewReader(`{}`),
+		Base:        base,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []keymap.Action{
+		newAction("actions.editor.copy", "meta+c"),
+		newAction("actions.editor.paste", "meta+v", "shift+insert"),
+	}, res.Setting.Actions, "the zed override does not leak to the top level")
+	assert.Equal(t, base.Editors, res.Setting.Editors)
+	require.Len(t, res.Changes.Update, 1)
+	assert.Equal(t, "actions.editor.paste", res.Changes.Update[0].After.Name)
+}
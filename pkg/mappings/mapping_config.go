@@ -40,16 +40,29 @@ type ActionMappingConfig struct {
 	// Featured means that this action is implemented within one or few editors and is not portable
 	Featured bool `yaml:"featured"`
 	// FeaturedReason: Why this action is not common across editors, or recommand users to use another portable actioin of similar utility.
-	FeaturedReason string                `yaml:"featuredReason"`
-	Category       string                `yaml:"category"`
-	VSCode         VscodeConfigs         `yaml:"vscode"`
-	Windsurf       VscodeConfigs         `yaml:"windsurf"`
-	Cursor         VscodeConfigs         `yaml:"cursor"`
-	Zed            ZedConfigs            `yaml:"zed"`
-	IntelliJ       IntelliJMappingConfig `yaml:"intellij"`
-	Vim            VimMappingConfig      `yaml:"vim"`
-	Helix          HelixConfig           `yaml:"helix"`
-	Xcode          XcodeConfigs          `yaml:"xcode"`
+	FeaturedReason string                    `yaml:"featuredReason"`
+	Category       string                    `yaml:"category"`
+	VSCode         VscodeConfigs             `yaml:"vscode"`
+	Windsurf       VscodeConfigs             `yaml:"windsurf"`
+	Cursor         VscodeConfigs             `yaml:"cursor"`
+	VSCodeInsiders VscodeConfigs             `yaml:"vscodeInsiders"`
+	VSCodium       VscodeConfigs             `yaml:"vscodium"`
+	CodeOSS        VscodeConfigs             `yaml:"codeOSS"`
+	Positron       VscodeConfigs             `yaml:"positron"`
+	Trae           VscodeConfigs             `yaml:"trae"`
+	Kiro           VscodeConfigs             `yaml:"kiro"`
+	Zed            ZedConfigs                `yaml:"zed"`
+	IntelliJ       IntelliJMappingConfig     `yaml:"intellij"`
+	Vim            VimMappingConfig          `yaml:"vim"`
+	Helix          HelixConfig               `yaml:"helix"`
+	Sublime        SublimeConfigs            `yaml:"sublime"`
+	Emacs          EmacsMappingConfig        `yaml:"emacs"`
+	Eclipse        EclipseMappingConfig      `yaml:"eclipse"`
+	VisualStudio   VisualStudioMappingConfig `yaml:"visualstudio"`
+	Lapce          LapceConfigs              `yaml:"lapce"`
+	Kakoune        KakouneConfigs            `yaml:"kakoune"`
+	Pulsar         PulsarConfigs             `yaml:"pulsar"`
+	Xcode          XcodeConfigs              `yaml:"xcode"`
 	// Children is a list of child action IDs for UI hierarchical grouping only.
 	// This field has no effect on export/import logic.
 	Children []string `yaml:"children,omitempty"`
@@ -66,16 +79,32 @@ func (am *ActionMappingConfig) IsSupported(editorType pluginapi.EditorType) (boo
 	switch editorType {
 	case pluginapi.EditorTypeVSCode:
 		return am.isSupportedVSCode()
-	case pluginapi.EditorTypeWindsurf, pluginapi.EditorTypeWindsurfNext, pluginapi.EditorTypeCursor:
+	case pluginapi.EditorTypeWindsurf, pluginapi.EditorTypeWindsurfNext, pluginapi.EditorTypeCursor,
+		pluginapi.EditorTypeVSCodeInsiders, pluginapi.EditorTypeVSCodium, pluginapi.EditorTypeCodeOSS,
+		pluginapi.EditorTypePositron, pluginapi.EditorTypeTrae, pluginapi.EditorTypeKiro:
 		return am.isSupportedVSCodeVariant(editorType)
 	case pluginapi.EditorTypeIntelliJ:
 		return am.isSupportedIntelliJ()
 	case pluginapi.EditorTypeZed:
 		return am.isSupportedZed()
-	case pluginapi.EditorTypeVim:
+	case pluginapi.EditorTypeVim, pluginapi.EditorTypeNeovim:
 		return am.isSupportedVim()
 	case pluginapi.EditorTypeHelix:
 		return am.isSupportedHelix()
+	case pluginapi.EditorTypeSublime:
+		return am.isSupportedSublime()
+	case pluginapi.EditorTypeEmacs:
+		return am.isSupportedEmacs()
+	case pluginapi.EditorTypeEclipse:
+		return am.isSupportedEclipse()
+	case pluginapi.EditorTypeVisualStudio:
+		return am.isSupportedVisualStudio()
+	case pluginapi.EditorTypeLapce:
+		return am.isSupportedLapce()
+	case pluginapi.EditorTypeKakoune:
+		return am.isSupportedKakoune()
+	case pluginapi.EditorTypePulsar:
+		return am.isSupportedPulsar()
 	case pluginapi.EditorTypeXcode:
 		return am.isSupportedXcode()
 	default:
@@ -175,6 +204,134 @@ func (am *ActionMappingConfig) isSupportedHelix() (bool, string) {
 	return hasMapping, strings.Join(notes, ", ")
 }
 
+func (am *ActionMappingConfig) isSupportedSublime() (bool, string) {
+	if len(am.Sublime) == 0 {
+		return false, ""
+	}
+	var notes []string
+	for _, sc := range am.Sublime {
+		if sc.NotSupported {
+			if sc.Note == "" {
+				return false, explicitlyNotSupported
+			}
+			return false, sc.Note
+		}
+		if sc.Note != "" {
+			notes = append(notes, sc.Note)
+		}
+	}
+	hasMapping := slices.ContainsFunc(am.Sublime, func(sc SublimeMappingConfig) bool {
+		return sc.Command != ""
+	})
+	return hasMapping, strings.Join(notes, ", ")
+}
+
+func (am *ActionMappingConfig) isSupportedEmacs() (bool, string) {
+	if am.Emacs == (EmacsMappingConfig{}) {
+		return false, ""
+	}
+	if am.Emacs.NotSupported {
+		if am.Emacs.Note == "" {
+			return false, explicitlyNotSupported
+		}
+		return false, am.Emacs.Note
+	}
+	return am.Emacs.Command != "", am.Emacs.Note
+}
+
+func (am *ActionMappingConfig) isSupportedEclipse() (bool, string) {
+	if am.Eclipse == (EclipseMappingConfig{}) {
+		return false, ""
+	}
+	if am.Eclipse.NotSupported {
+		if am.Eclipse.Note == "" {
+			return false, explicitlyNotSupported
+		}
+		return false, am.Eclipse.Note
+	}
+	return am.Eclipse.CommandID != "", am.Eclipse.Note
+}
+
+func (am *ActionMappingConfig) isSupportedVisualStudio() (bool, string) {
+	vs := am.VisualStudio
+	if vs.Command == "" && vs.EditorActionMapping == (EditorActionMapping{}) {
+		return false, ""
+	}
+	if vs.NotSupported {
+		if vs.Note == "" {
+			return false, explicitlyNotSupported
+		}
+		return false, vs.Note
+	}
+	return vs.Command != "", vs.Note
+}
+
+func (am *ActionMappingConfig) isSupportedLapce() (bool, string) {
+	if len(am.Lapce) == 0 {
+		return false, ""
+	}
+	var notes []string
+	for _, lc := range am.Lapce {
+		if lc.NotSupported {
+			if lc.Note == "" {
+				return false, explicitlyNotSupported
+			}
+			return false, lc.Note
+		}
+		if lc.Note != "" {
+			notes = append(notes, lc.Note)
+		}
+	}
+	hasMapping := slices.ContainsFunc(am.Lapce, func(lc LapceMappingConfig) bool {
+		return lc.Command != ""
+	})
+	return hasMapping, strings.Join(notes, ", ")
+}
+
+func (am *ActionMappingConfig) isSupportedKakoune() (bool, string) {
+	if len(am.Kakoune) == 0 {
+		return false, ""
+	}
+	var notes []string
+	for _, kc := range am.Kakoune {
+		if kc.NotSupported {
+			if kc.Note == "" {
+				return false, explicitlyNotSupported
+			}
+			return false, kc.Note
+		}
+		if kc.Note != "" {
+			notes = append(notes, kc.Note)
+		}
+	}
+	hasMapping := slices.ContainsFunc(am.Kakoune, func(kc KakouneMappingConfig) bool {
+		return kc.Command != ""
+	})
+	return hasMapping, strings.Join(notes, ", ")
+}
+
+func (am *ActionMappingConfig) isSupportedPulsar() (bool, string) {
+	if len(am.Pulsar) == 0 {
+		return false, ""
+	}
+	var notes []string
+	for _, pc := range am.Pulsar {
+		if pc.NotSupported {
+			if pc.Note == "" {
+				return false, explicitlyNotSupported
+			}
+			return false, pc.Note
+		}
+		if pc.Note != "" {
+			notes = append(notes, pc.Note)
+		}
+	}
+	hasMapping := slices.ContainsFunc(am.Pulsar, func(pc PulsarMappingConfig) bool {
+		return pc.Command != ""
+	})
+	return hasMapping, strings.Join(notes, ", ")
+}
+
 func (am *ActionMappingConfig) isSupportedXcode() (bool, string) {
 	if len(am.Xcode) == 0 {
 		return false, ""
@@ -318,6 +475,30 @@ func checkEditorConfigs(mappings map[string]ActionMappingConfig) error {
 	if err := checkZedDuplicateConfig(mappings); err != nil {
 		return err
 	}
+	if err := checkHelixDuplicateConfig(mappings); err != nil {
+		return err
+	}
+	if err := checkSublimeDuplicateConfig(mappings); err != nil {
+		return err
+	}
+	if err := checkEmacsDuplicateConfig(mappings); err != nil {
+		return err
+	}
+	if err := checkEclipseDuplicateConfig(mappings); err != nil {
+		return err
+	}
+	if err := checkVisualStudioDuplicateConfig(mappings); err != nil {
+		return err
+	}
+	if err := checkLapceDuplicateConfig(mappings); err != nil {
+		return err
+	}
+	if err := checkKakouneDuplicateConfig(mappings); err != nil {
+		return err
+	}
+	if err := checkPulsarDuplicateConfig(mappings); err != nil {
+		return err
+	}
 	if err := checkXcodeDuplicateConfig(mappings); err != nil {
 		return err
 	}
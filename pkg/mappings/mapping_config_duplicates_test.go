@@ -111,6 +111,261 @@ func TestCheckZedDuplicateConfig_Duplicates(t *testing.T) {
 	assert.True(t, containsAll(got, "k1", "k2"), "expected ids [k1 k2] in any order, got %v", got)
 }
 
+// -------------------- Vim --------------------.
+func TestCheckVimDuplicateConfig_NoDuplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"a": {Vim: VimMappingConfig{Command: `"+y`, Mode: "visual"}},
+		"b": {Vim: VimMappingConfig{Command: `"+y`, Mode: "normal"}},
+		"c": {Vim: VimMappingConfig{Command: "u", EditorActionMapping: EditorActionMapping{DisableImport: true}}},
+		"d": {Vim: VimMappingConfig{Command: "u"}},
+	}
+	require.NoError(t, checkVimDuplicateConfig(mappings))
+}
+
+func TestCheckVimDuplicateConfig_Duplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"v1": {Vim: VimMappingConfig{Command: ":w<CR>"}},
+		"v2": {Vim: VimMappingConfig{Command: ":w<CR>", Mode: "normal"}},
+	}
+	err := checkVimDuplicateConfig(mappings)
+	require.Error(t, err)
+	var derr *DuplicateActionMappingError
+	require.ErrorAs(t, err, &derr, "expected DuplicateActionMappingError, got %T: %v", err, err)
+	assert.Equal(t, "vim", derr.Editor)
+	key := fmt.Sprintf(`{"command":%q,"mode":%q}`, ":w<CR>", "normal")
+	got, ok := derr.Duplicates[key]
+	assert.True(t, ok, "expected duplicates for key %s, got keys: %v", key, keys(derr.Duplicates))
+	assert.True(t, containsAll(got, "v1", "v2"), "expected ids [v1 v2] in any order, got %v", got)
+}
+
+// -------------------- Helix --------------------.
+func TestCheckHelixDuplicateConfig_NoDuplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"a": {Helix: HelixConfig{{Command: "yank", Mode: "insert"}}},
+		"b": {Helix: HelixConfig{{Command: "yank", Mode: "normal"}}},
+		"c": {Helix: HelixConfig{{Command: "undo", EditorActionMapping: EditorActionMapping{DisableImport: true}}}},
+		"d": {Helix: HelixConfig{{Command: "undo"}}},
+	}
+	require.NoError(t, checkHelixDuplicateConfig(mappings))
+}
+
+func TestCheckHelixDuplicateConfig_Duplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"h1": {Helix: HelixConfig{{Command: "code_action"}}},
+		"h2": {Helix: HelixConfig{{Command: "code_action", Mode: "normal"}}},
+	}
+	err := checkHelixDuplicateConfig(mappings)
+	require.Error(t, err)
+	var derr *DuplicateActionMappingError
+	require.ErrorAs(t, err, &derr, "expected DuplicateActionMappingError, got %T: %v", err, err)
+	assert.Equal(t, "helix", derr.Editor)
+	key := fmt.Sprintf(`{"command":%q,"mode":%q}`, "code_action", "normal")
+	got, ok := derr.Duplicates[key]
+	assert.True(t, ok, "expected duplicates for key %s, got keys: %v", key, keys(derr.Duplicates))
+	assert.True(t, containsAll(got, "h1", "h2"), "expected ids [h1 h2] in any order, got %v", got)
+}
+
+// -------------------- Sublime --------------------.
+func TestCheckSublimeDuplicateConfig_NoDuplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"a": {Sublime: SublimeConfigs{{Command: "toggle_comment", Args: map[string]interface{}{"block": false}}}},
+		"b": {Sublime: SublimeConfigs{{Command: "toggle_comment", Args: map[string]interface{}{"block": true}}}},
+		"c": {Sublime: SublimeConfigs{{Command: "copy", EditorActionMapping: EditorActionMapping{DisableImport: true}}}},
+		"d": {Sublime: SublimeConfigs{{Command: "copy"}}},
+	}
+	require.NoError(t, checkSublimeDuplicateConfig(mappings))
+}
+
+func TestCheckSublimeDuplicateConfig_Duplicates(t *testing.T) {
+	args := map[string]interface{}{"overlay": "goto"}
+	mappings := map[string]ActionMappingConfig{
+		"s1": {Sublime: SublimeConfigs{{Command: "show_overlay", Args: args}}},
+		"s2": {Sublime: SublimeConfigs{{Command: "show_overlay", Args: args}}},
+	}
+	err := checkSublimeDuplicateConfig(mappings)
+	require.Error(t, err)
+	var derr *DuplicateActionMappingError
+	require.ErrorAs(t, err, &derr, "expected DuplicateActionMappingError, got %T: %v", err, err)
+	assert.Equal(t, "sublime", derr.Editor)
+	argsBytes, _ := json.Marshal(args)
+	key := fmt.Sprintf(`{"command":%q,"args":%q,"context":%q}`, "show_overlay", string(argsBytes), "")
+	got, ok := derr.Duplicates[key]
+	assert.True(t, ok, "expected duplicates for key %s, got keys: %v", key, keys(derr.Duplicates))
+	assert.True(t, containsAll(got, "s1", "s2"), "expected ids [s1 s2] in any order, got %v", got)
+}
+
+// -------------------- Emacs --------------------.
+func TestCheckEmacsDuplicateConfig_NoDuplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"a": {Emacs: EmacsMappingConfig{Command: "isearch-repeat-forward"}},
+		"b": {Emacs: EmacsMappingConfig{Command: "isearch-repeat-forward", Keymap: "isearch-mode-map"}},
+		"c": {Emacs: EmacsMappingConfig{Command: "undo", EditorActionMapping: EditorActionMapping{DisableImport: true}}},
+		"d": {Emacs: EmacsMappingConfig{Command: "undo"}},
+	}
+	require.NoError(t, checkEmacsDuplicateConfig(mappings))
+}
+
+func TestCheckEmacsDuplicateConfig_Duplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"e1": {Emacs: EmacsMappingConfig{Command: "save-buffer"}},
+		"e2": {Emacs: EmacsMappingConfig{Command: "save-buffer", Keymap: "global-map"}},
+	}
+	err := checkEmacsDuplicateConfig(mappings)
+	require.Error(t, err)
+	var derr *DuplicateActionMappingError
+	require.ErrorAs(t, err, &derr, "expected DuplicateActionMappingError, got %T: %v", err, err)
+	assert.Equal(t, "emacs", derr.Editor)
+	key := fmt.Sprintf(`{"command":%q,"keymap":%q}`, "save-buffer", "global-map")
+	got, ok := derr.Duplicates[key]
+	assert.True(t, ok, "expected duplicates for key %s, got keys: %v", key, keys(derr.Duplicates))
+	assert.True(t, containsAll(got, "e1", "e2"), "expected ids [e1 e2] in any order, got %v", got)
+}
+
+// -------------------- Eclipse --------------------.
+func TestCheckEclipseDuplicateConfig_NoDuplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"a": {Eclipse: EclipseMappingConfig{CommandID: "org.eclipse.ui.edit.findNext"}},
+		"b": {Eclipse: EclipseMappingConfig{
+			CommandID: "org.eclipse.ui.edit.findNext",
+			ContextID: "org.eclipse.ui.textEditorScope",
+		}},
+		"c": {Eclipse: EclipseMappingConfig{
+			CommandID:           "org.eclipse.ui.edit.findReplace",
+			EditorActionMapping: EditorActionMapping{DisableImport: true},
+		}},
+		"d": {Eclipse: EclipseMappingConfig{CommandID: "org.eclipse.ui.edit.findReplace"}},
+	}
+	require.NoError(t, checkEclipseDuplicateConfig(mappings))
+}
+
+func TestCheckEclipseDuplicateConfig_Duplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"e1": {Eclipse: EclipseMappingConfig{CommandID: "org.eclipse.ui.file.save"}},
+		"e2": {Eclipse: EclipseMappingConfig{CommandID: "org.eclipse.ui.file.save", ContextID: EclipseWindowContext}},
+	}
+	err := checkEclipseDuplicateConfig(mappings)
+	require.Error(t, err)
+	var derr *DuplicateActionMappingError
+	require.ErrorAs(t, err, &derr, "expected DuplicateActionMappingError, got %T: %v", err, err)
+	assert.Equal(t, "eclipse", derr.Editor)
+	key := fmt.Sprintf(`{"commandId":%q,"contextId":%q}`, "org.eclipse.ui.file.save", EclipseWindowContext)
+	got, ok := derr.Duplicates[key]
+	assert.True(t, ok, "expected duplicates for key %s, got keys: %v", key, keys(derr.Duplicates))
+	assert.True(t, containsAll(got, "e1", "e2"), "expected ids [e1 e2] in any order, got %v", got)
+}
+
+// -------------------- Visual Studio --------------------.
+func TestCheckVisualStudioDuplicateConfig_NoDuplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"a": {VisualStudio: VisualStudioMappingConfig{Command: "Edit.LineDelete"}},
+		"b": {VisualStudio: VisualStudioMappingConfig{Command: "Edit.LineDelete", Scope: "Text Editor"}},
+		"c": {VisualStudio: VisualStudioMappingConfig{
+			Command:             "Edit.Find",
+			EditorActionMapping: EditorActionMapping{DisableImport: true},
+		}},
+		"d": {VisualStudio: VisualStudioMappingConfig{Command: "Edit.Find"}},
+	}
+	require.NoError(t, checkVisualStudioDuplicateConfig(mappings))
+}
+
+func TestCheckVisualStudioDuplicateConfig_Duplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"v1": {VisualStudio: VisualStudioMappingConfig{Command: "File.SaveAll"}},
+		"v2": {VisualStudio: VisualStudioMappingConfig{Command: "File.SaveAll", Scope: VisualStudioGlobalScope}},
+	}
+	err := checkVisualStudioDuplicateConfig(mappings)
+	require.Error(t, err)
+	var derr *DuplicateActionMappingError
+	require.ErrorAs(t, err, &derr, "expected DuplicateActionMappingError, got %T: %v", err, err)
+	assert.Equal(t, "visualstudio", derr.Editor)
+	key := fmt.Sprintf(`{"command":%q,"scope":%q}`, "File.SaveAll", VisualStudioGlobalScope)
+	got, ok := derr.Duplicates[key]
+	assert.True(t, ok, "expected duplicates for key %s, got keys: %v", key, keys(derr.Duplicates))
+	assert.True(t, containsAll(got, "v1", "v2"), "expected ids [v1 v2] in any order, got %v", got)
+}
+
+// -------------------- Lapce --------------------.
+func TestCheckLapceDuplicateConfig_NoDuplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"a": {Lapce: LapceConfigs{{Command: "search_forward"}}},
+		"b": {Lapce: LapceConfigs{{Command: "search_forward", When: "search_focus"}}},
+		"c": {Lapce: LapceConfigs{{Command: "search_forward", Mode: "n"}}},
+		"d": {Lapce: LapceConfigs{{Command: "undo", EditorActionMapping: EditorActionMapping{DisableImport: true}}}},
+		"e": {Lapce: LapceConfigs{{Command: "undo"}}},
+	}
+	require.NoError(t, checkLapceDuplicateConfig(mappings))
+}
+
+func TestCheckLapceDuplicateConfig_Duplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"l1": {Lapce: LapceConfigs{{Command: "save", When: "editor_focus"}}},
+		"l2": {Lapce: LapceConfigs{{Command: "undo"}, {Command: "save", When: "editor_focus"}}},
+	}
+	err := checkLapceDuplicateConfig(mappings)
+	require.Error(t, err)
+	var derr *DuplicateActionMappingError
+	require.ErrorAs(t, err, &derr, "expected DuplicateActionMappingError, got %T: %v", err, err)
+	assert.Equal(t, "lapce", derr.Editor)
+	key := fmt.Sprintf(`{"command":%q,"mode":%q,"when":%q}`, "save", "", "editor_focus")
+	got, ok := derr.Duplicates[key]
+	assert.True(t, ok, "expected duplicates for key %s, got keys: %v", key, keys(derr.Duplicates))
+	assert.True(t, containsAll(got, "l1", "l2"), "expected ids [l1 l2] in any order, got %v", got)
+}
+
+// -------------------- Kakoune --------------------.
+func TestCheckKakouneDuplicateConfig_NoDuplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"a": {Kakoune: KakouneConfigs{{Command: ":write<ret>"}}},
+		"b": {Kakoune: KakouneConfigs{{Command: ":write<ret>", Mode: "user"}}},
+		"c": {Kakoune: KakouneConfigs{{Command: "u", EditorActionMapping: EditorActionMapping{DisableImport: true}}}},
+		"d": {Kakoune: KakouneConfigs{{Command: "u"}}},
+	}
+	require.NoError(t, checkKakouneDuplicateConfig(mappings))
+}
+
+func TestCheckKakouneDuplicateConfig_Duplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"k1": {Kakoune: KakouneConfigs{{Command: ":write<ret>"}}},
+		"k2": {Kakoune: KakouneConfigs{{Command: ":write<ret>", Mode: "normal"}}},
+	}
+	err := checkKakouneDuplicateConfig(mappings)
+	require.Error(t, err)
+	var derr *DuplicateActionMappingError
+	require.ErrorAs(t, err, &derr, "expected DuplicateActionMappingError, got %T: %v", err, err)
+	assert.Equal(t, "kakoune", derr.Editor)
+	key := fmt.Sprintf(`{"command":%q,"mode":%q}`, ":write<ret>", "normal")
+	got, ok := derr.Duplicates[key]
+	assert.True(t, ok, "expected duplicates for key %s, got keys: %v", key, keys(derr.Duplicates))
+	assert.True(t, containsAll(got, "k1", "k2"), "expected ids [k1 k2] in any order, got %v", got)
+}
+
+// -------------------- Pulsar --------------------.
+func TestCheckPulsarDuplicateConfig_NoDuplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"a": {Pulsar: PulsarConfigs{{Command: "core:copy", Selector: "body"}}},
+		"b": {Pulsar: PulsarConfigs{{Command: "core:copy", Selector: "atom-text-editor"}}},
+		"c": {Pulsar: PulsarConfigs{{Command: "core:undo", EditorActionMapping: EditorActionMapping{DisableImport: true}}}},
+		"d": {Pulsar: PulsarConfigs{{Command: "core:undo"}}},
+	}
+	require.NoError(t, checkPulsarDuplicateConfig(mappings))
+}
+
+func TestCheckPulsarDuplicateConfig_Duplicates(t *testing.T) {
+	mappings := map[string]ActionMappingConfig{
+		"p1": {Pulsar: PulsarConfigs{{Command: "core:save"}}},
+		"p2": {Pulsar: PulsarConfigs{{Command: "core:save", Selector: PulsarWorkspaceSelector}}},
+	}
+	err := checkPulsarDuplicateConfig(mappings)
+	require.Error(t, err)
+	var derr *DuplicateActionMappingError
+	require.ErrorAs(t, err, &derr, "expected DuplicateActionMappingError, got %T: %v", err, err)
+	assert.Equal(t, "pulsar", derr.Editor)
+	key := fmt.Sprintf(`{"command":%q,"selector":%q}`, "core:save", PulsarWorkspaceSelector)
+	got, ok := derr.Duplicates[key]
+	assert.True(t, ok, "expected duplicates for key %s, got keys: %v", key, keys(derr.Duplicates))
+	assert.True(t, containsAll(got, "p1", "p2"), "expected ids [p1 p2] in any order, got %v", got)
+}
+
 func keys(m map[string][]string) []string {
 	out := make([]string, 0, len(m))
 	for k := range m {
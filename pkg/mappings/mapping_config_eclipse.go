@@ -0,0 +1,52 @@
+package mappings
+
+import "fmt"
+
+// EclipseWindowContext is the context used when an Eclipse mapping does not name one.
+const EclipseWindowContext = "org.eclipse.ui.contexts.window"
+
+type EclipseMappingConfig struct {
+	EditorActionMapping `yaml:",inline"`
+
+	// CommandID is the Eclipse command id, e.g. "org.eclipse.ui.edit.copy".
+	CommandID string `yaml:"commandId"`
+	// ContextID is the binding context, e.g. "org.eclipse.ui.textEditorScope". Empty means "In Windows".
+	ContextID string `yaml:"contextId"`
+}
+
+// EffectiveContextID returns the configured context, defaulting to "org.eclipse.ui.contexts.window".
+func (c EclipseMappingConfig) EffectiveContextID() string {
+	if c.ContextID == "" {
+		return EclipseWindowContext
+	}
+	return c.ContextID
+}
+
+func checkEclipseDuplicateConfig(mappings map[string]ActionMappingConfig) error {
+	seen := make(map[struct{ CommandID, ContextID string }]string)
+	dups := make(map[string][]string) // key string -> list of universal action IDs
+	for id, mapping := range mappings {
+		econf := mapping.Eclipse
+		if econf.CommandID == "" {
+			continue
+		}
+		// Skip configs that are disabled for import (export-only)
+		if econf.DisableImport {
+			continue
+		}
+		key := struct{ CommandID, ContextID string }{econf.CommandID, econf.EffectiveContextID()}
+		if originalID, exists := seen[key]; exists {
+			dupKey := fmt.Sprintf(`{"commandId":%q,"contextId":%q}`, key.CommandID, key.ContextID)
+			if _, ok := dups[dupKey]; !ok {
+				dups[dupKey] = []string{originalID}
+			}
+			dups[dupKey] = append(dups[dupKey], id)
+			continue
+		}
+		seen[key] = id
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	return &DuplicateActionMappingError{Editor: "eclipse", Duplicates: dups}
+}
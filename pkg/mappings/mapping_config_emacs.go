@@ -0,0 +1,52 @@
+package mappings
+
+import "fmt"
+
+// EmacsGlobalMap is the keymap used when an Emacs mapping does not name one.
+const EmacsGlobalMap = "global-map"
+
+type EmacsMappingConfig struct {
+	EditorActionMapping `yaml:",inline"`
+
+	// Command is the interactive command symbol, e.g. "kill-ring-save".
+	Command string `yaml:"command"`
+	// Keymap is the keymap variable to bind in, e.g. "isearch-mode-map". Empty means "global-map".
+	Keymap string `yaml:"keymap"`
+}
+
+// EffectiveKeymap returns the configured keymap, defaulting to "global-map".
+func (c EmacsMappingConfig) EffectiveKeymap() string {
+	if c.Keymap == "" {
+		return EmacsGlobalMap
+	}
+	return c.Keymap
+}
+
+func checkEmacsDuplicateConfig(mappings map[string]ActionMappingConfig) error {
+	seen := make(map[struct{ Command, Keymap string }]string)
+	dups := make(map[string][]string) // key string -> list of universal action IDs
+	for id, mapping := range mappings {
+		econf := mapping.Emacs
+		if econf.Command == "" {
+			continue
+		}
+		// Skip configs that are disabled for import (export-only)
+		if econf.DisableImport {
+			continue
+		}
+		key := struct{ Command, Keymap string }{econf.Command, econf.EffectiveKeymap()}
+		if originalID, exists := seen[key]; exists {
+			dupKey := fmt.Sprintf(`{"command":%q,"keymap":%q}`, key.Command, key.Keymap)
+			if _, ok := dups[dupKey]; !ok {
+				dups[dupKey] = []string{originalID}
+			}
+			dups[dupKey] = append(dups[dupKey], id)
+			continue
+		}
+		seen[key] = id
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	return &DuplicateActionMappingError{Editor: "emacs", Duplicates: dups}
+}
@@ -40,3 +40,37 @@ func (h *HelixConfig) UnmarshalYAML(node *yaml.Node) error {
 	}
 	return nil
 }
+
+func checkHelixDuplicateConfig(mappings map[string]ActionMappingConfig) error {
+	seen := make(map[struct{ Command, Mode string }]string)
+	dups := make(map[string][]string) // key string -> list of universal action IDs
+	for id, mapping := range mappings {
+		for _, hconf := range mapping.Helix {
+			if hconf.Command == "" {
+				continue
+			}
+			// Skip configs that are disabled for import (export-only)
+			if hconf.DisableImport {
+				continue
+			}
+			mode := hconf.Mode
+			if mode == "" {
+				mode = "normal"
+			}
+			key := struct{ Command, Mode string }{hconf.Command, mode}
+			if originalID, exists := seen[key]; exists {
+				dupKey := fmt.Sprintf(`{"command":%q,"mode":%q}`, key.Command, key.Mode)
+				if _, ok := dups[dupKey]; !ok {
+					dups[dupKey] = []string{originalID}
+				}
+				dups[dupKey] = append(dups[dupKey], id)
+				continue
+			}
+			seen[key] = id
+		}
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	return &DuplicateActionMappingError{Editor: "helix", Duplicates: dups}
+}
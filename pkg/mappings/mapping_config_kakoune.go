@@ -0,0 +1,82 @@
+package mappings
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+type KakouneMappingConfig struct {
+	EditorActionMapping `yaml:",inline"`
+
+	// Command is the key sequence the mapping expands to, e.g. `:write<ret>` or `<a-x>`.
+	Command string `yaml:"command"`
+	// Mode is the Kakoune mode of the mapping, e.g. "normal", "insert" or "user". Empty means "normal".
+	Mode string `yaml:"mode"`
+}
+
+// EffectiveMode returns the configured mode, defaulting to "normal".
+func (c KakouneMappingConfig) EffectiveMode() string {
+	if c.Mode == "" {
+		return "normal"
+	}
+	return c.Mode
+}
+
+type KakouneConfigs []KakouneMappingConfig
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for KakouneConfigs.
+// It supports both a single mapping object and a sequence of objects.
+func (k *KakouneConfigs) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		var single KakouneMappingConfig
+		if err := node.Decode(&single); err != nil {
+			return err
+		}
+		*k = []KakouneMappingConfig{single}
+	case yaml.SequenceNode:
+		var slice []KakouneMappingConfig
+		if err := node.Decode(&slice); err != nil {
+			return err
+		}
+		*k = slice
+	default:
+		return fmt.Errorf(
+			"cannot unmarshal! (line %d, col %d): expected a mapping or sequence node for kakoune config",
+			node.Line,
+			node.Column,
+		)
+	}
+	return nil
+}
+
+func checkKakouneDuplicateConfig(mappings map[string]ActionMappingConfig) error {
+	seen := make(map[struct{ Command, Mode string }]string)
+	dups := make(map[string][]string) // key string -> list of universal action IDs
+	for id, mapping := range mappings {
+		for _, kconf := range mapping.Kakoune {
+			if kconf.Command == "" {
+				continue
+			}
+			// Skip configs that are disabled for import (export-only)
+			if kconf.DisableImport {
+				continue
+			}
+			key := struct{ Command, Mode string }{kconf.Command, kconf.EffectiveMode()}
+			if originalID, exists := seen[key]; exists {
+				dupKey := fmt.Sprintf(`{"command":%q,"mode":%q}`, key.Command, key.Mode)
+				if _, ok := dups[dupKey]; !ok {
+					dups[dupKey] = []string{originalID}
+				}
+				dups[dupKey] = append(dups[dupKey], id)
+				continue
+			}
+			seen[key] = id
+		}
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	return &DuplicateActionMappingError{Editor: "kakoune", Duplicates: dups}
+}
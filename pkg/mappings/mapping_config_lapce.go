@@ -0,0 +1,77 @@
+package mappings
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+type LapceMappingConfig struct {
+	EditorActionMapping `yaml:",inline"`
+
+	// Command is the Lapce command name, e.g. "palette.command" or "toggle_line_comment".
+	Command string `yaml:"command"`
+	// Mode restricts the binding to the given modal-editing modes: "n" (normal), "i" (insert),
+	// "v" (visual) or "t" (terminal), combined as e.g. "nv". Empty applies to every mode.
+	Mode string `yaml:"mode,omitempty"`
+	// When is the context condition, e.g. "editor_focus" or "!list_focus".
+	When string `yaml:"when,omitempty"`
+}
+
+type LapceConfigs []LapceMappingConfig
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for LapceConfigs.
+// It supports both a single mapping object and a sequence of objects.
+func (l *LapceConfigs) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		var single LapceMappingConfig
+		if err := node.Decode(&single); err != nil {
+			return err
+		}
+		*l = []LapceMappingConfig{single}
+	case yaml.SequenceNode:
+		var slice []LapceMappingConfig
+		if err := node.Decode(&slice); err != nil {
+			return err
+		}
+		*l = slice
+	default:
+		return fmt.Errorf(
+			"cannot unmarshal! (line %d, col %d): expected a mapping or sequence node for lapce config",
+			node.Line,
+			node.Column,
+		)
+	}
+	return nil
+}
+
+func checkLapceDuplicateConfig(mappings map[string]ActionMappingConfig) error {
+	seen := make(map[struct{ Command, Mode, When string }]string)
+	dups := make(map[string][]string) // key string -> list of universal action IDs
+	for id, mapping := range mappings {
+		for _, lconf := range mapping.Lapce {
+			if lconf.Command == "" {
+				continue
+			}
+			// Skip configs that are disabled for import (export-only)
+			if lconf.DisableImport {
+				continue
+			}
+			key := struct{ Command, Mode, When string }{lconf.Command, lconf.Mode, lconf.When}
+			if originalID, exists := seen[key]; exists {
+				dupKey := fmt.Sprintf(`{"command":%q,"mode":%q,"when":%q}`, key.Command, key.Mode, key.When)
+				if _, ok := dups[dupKey]; !ok {
+					dups[dupKey] = []string{originalID}
+				}
+				dups[dupKey] = append(dups[dupKey], id)
+				continue
+			}
+			seen[key] = id
+		}
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	return &DuplicateActionMappingError{Editor: "lapce", Duplicates: dups}
+}
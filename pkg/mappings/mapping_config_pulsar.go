@@ -0,0 +1,86 @@
+package mappings
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PulsarWorkspaceSelector is the selector used when a Pulsar mapping does not name one.
+const PulsarWorkspaceSelector = "atom-workspace"
+
+type PulsarMappingConfig struct {
+	EditorActionMapping `yaml:",inline"`
+
+	// Command is the Pulsar (Atom) command name, e.g. "editor:toggle-line-comments".
+	Command string `yaml:"command"`
+	// Selector is the CSS selector the keystroke is bound under in keymap.cson,
+	// e.g. "atom-text-editor:not([mini])". Empty means "atom-workspace".
+	Selector string `yaml:"selector,omitempty"`
+}
+
+// EffectiveSelector returns the configured selector, defaulting to "atom-workspace".
+func (c PulsarMappingConfig) EffectiveSelector() string {
+	if c.Selector == "" {
+		return PulsarWorkspaceSelector
+	}
+	return c.Selector
+}
+
+type PulsarConfigs []PulsarMappingConfig
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for PulsarConfigs.
+// It supports both a single mapping object and a sequence of objects.
+func (p *PulsarConfigs) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		var single PulsarMappingConfig
+		if err := node.Decode(&single); err != nil {
+			return err
+		}
+		*p = []PulsarMappingConfig{single}
+	case yaml.SequenceNode:
+		var slice []PulsarMappingConfig
+		if err := node.Decode(&slice); err != nil {
+			return err
+		}
+		*p = slice
+	default:
+		return fmt.Errorf(
+			"cannot unmarshal! (line %d, col %d): expected a mapping or sequence node for pulsar config",
+			node.Line,
+			node.Column,
+		)
+	}
+	return nil
+}
+
+func checkPulsarDuplicateConfig(mappings map[string]ActionMappingConfig) error {
+	seen := make(map[struct{ Command, Selector string }]string)
+	dups := make(map[string][]string) // key string -> list of universal action IDs
+	for id, mapping := range mappings {
+		for _, pconf := range mapping.Pulsar {
+			if pconf.Command == "" {
+				continue
+			}
+			// Skip configs that are disabled for import (export-only)
+			if pconf.DisableImport {
+				continue
+			}
+			key := struct{ Command, Selector string }{pconf.Command, pconf.EffectiveSelector()}
+			if originalID, exists := seen[key]; exists {
+				dupKey := fmt.Sprintf(`{"command":%q,"selector":%q}`, key.Command, key.Selector)
+				if _, ok := dups[dupKey]; !ok {
+					dups[dupKey] = []string{originalID}
+				}
+				dups[dupKey] = append(dups[dupKey], id)
+				continue
+			}
+			seen[key] = id
+		}
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	return &DuplicateActionMappingError{Editor: "pulsar", Duplicates: dups}
+}
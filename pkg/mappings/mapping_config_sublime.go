@@ -0,0 +1,87 @@
+package mappings
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+type SublimeMappingConfig struct {
+	EditorActionMapping `yaml:",inline"`
+
+	// Command is the Sublime Text command name, e.g. "toggle_comment".
+	Command string `yaml:"command"`
+	// Args are the command arguments, e.g. {"block": false}.
+	Args map[string]interface{} `yaml:"args,omitempty"`
+	// Context is the list of context conditions, e.g. [{"key": "overlay_visible", "operand": true}].
+	Context []map[string]interface{} `yaml:"context,omitempty"`
+}
+
+type SublimeConfigs []SublimeMappingConfig
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for SublimeConfigs.
+// It supports both a single mapping object and a sequence of objects.
+func (s *SublimeConfigs) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		var single SublimeMappingConfig
+		if err := node.Decode(&single); err != nil {
+			return err
+		}
+		*s = []SublimeMappingConfig{single}
+	case yaml.SequenceNode:
+		var slice []SublimeMappingConfig
+		if err := node.Decode(&slice); err != nil {
+			return err
+		}
+		*s = slice
+	default:
+		return fmt.Errorf(
+			"cannot unmarshal! (line %d, col %d): expected a mapping or sequence node for sublime config",
+			node.Line,
+			node.Column,
+		)
+	}
+	return nil
+}
+
+func checkSublimeDuplicateConfig(mappings map[string]ActionMappingConfig) error {
+	seen := make(map[struct{ Command, Args, Context string }]string)
+	dups := make(map[string][]string) // key string -> list of universal action IDs
+	for id, mapping := range mappings {
+		for _, sconf := range mapping.Sublime {
+			if sconf.Command == "" {
+				continue
+			}
+			// Skip configs that are disabled for import (export-only)
+			if sconf.DisableImport {
+				continue
+			}
+			var argsStr, contextStr string
+			if sconf.Args != nil {
+				argsBytes, _ := json.Marshal(sconf.Args)
+				argsStr = string(argsBytes)
+			}
+			if sconf.Context != nil {
+				contextBytes, _ := json.Marshal(sconf.Context)
+				contextStr = string(contextBytes)
+			}
+
+			key := struct{ Command, Args, Context string }{sconf.Command, argsStr, contextStr}
+			if originalID, exists := seen[key]; exists {
+				dupKey := fmt.Sprintf(`{"command":%q,"args":%q,"context":%q}`, key.Command, key.Args, key.Context)
+				if _, ok := dups[dupKey]; !ok {
+					dups[dupKey] = []string{originalID}
+				}
+				dups[dupKey] = append(dups[dupKey], id)
+				continue
+			}
+			seen[key] = id
+		}
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	return &DuplicateActionMappingError{Editor: "sublime", Duplicates: dups}
+}
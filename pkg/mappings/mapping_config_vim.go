@@ -1,13 +1,49 @@
 package mappings
 
+import "fmt"
+
 type VimMappingConfig struct {
 	EditorActionMapping `yaml:",inline"`
 
+	// Command is the right-hand side of the mapping, e.g. `"+y` or `:w<CR>`.
 	Command string `yaml:"command"`
-	Mode    string `yaml:"mode"`
+	// Mode is one of "normal", "visual" or "insert". Empty means "normal".
+	Mode string `yaml:"mode"`
+}
+
+// EffectiveMode returns the configured mode, defaulting to "normal".
+func (c VimMappingConfig) EffectiveMode() string {
+	if c.Mode == "" {
+		return "normal"
+	}
+	return c.Mode
 }
 
-func checkVimDuplicateConfig(_ map[string]ActionMappingConfig) error {
-	// TODO(xinnjie): Vim plugin not implemented
-	return nil
+func checkVimDuplicateConfig(mappings map[string]ActionMappingConfig) error {
+	seen := make(map[struct{ Command, Mode string }]string)
+	dups := make(map[string][]string) // key string -> list of universal action IDs
+	for id, mapping := range mappings {
+		vconf := mapping.Vim
+		if vconf.Command == "" {
+			continue
+		}
+		// Skip configs that are disabled for import (export-only)
+		if vconf.DisableImport {
+			continue
+		}
+		key := struct{ Command, Mode string }{vconf.Command, vconf.EffectiveMode()}
+		if originalID, exists := seen[key]; exists {
+			dupKey := fmt.Sprintf(`{"command":%q,"mode":%q}`, key.Command, key.Mode)
+			if _, ok := dups[dupKey]; !ok {
+				dups[dupKey] = []string{originalID}
+			}
+			dups[dupKey] = append(dups[dupKey], id)
+			continue
+		}
+		seen[key] = id
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	return &DuplicateActionMappingError{Editor: "vim", Duplicates: dups}
 }
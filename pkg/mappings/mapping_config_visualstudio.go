@@ -0,0 +1,55 @@
+package mappings
+
+import "fmt"
+
+// VisualStudioGlobalScope is the scope used when a Visual Studio mapping does not name one.
+const VisualStudioGlobalScope = "Global"
+
+type VisualStudioMappingConfig struct {
+	EditorActionMapping `yaml:",inline"`
+
+	// Command is the canonical command name, e.g. "Edit.Copy".
+	Command string `yaml:"command"`
+	// Scope is the editor scope the shortcut applies to, e.g. "Text Editor". Empty means "Global".
+	Scope string `yaml:"scope"`
+	// DefaultShortcuts are the shortcuts the Default keyboard mapping scheme assigns to Command in Scope,
+	// e.g. ["Ctrl+C", "Ctrl+Ins"]. They are written as RemoveShortcut entries when onekeymap rebinds the command.
+	DefaultShortcuts []string `yaml:"defaultShortcuts,omitempty"`
+}
+
+// EffectiveScope returns the configured scope, defaulting to "Global".
+func (c VisualStudioMappingConfig) EffectiveScope() string {
+	if c.Scope == "" {
+		return VisualStudioGlobalScope
+	}
+	return c.Scope
+}
+
+func checkVisualStudioDuplicateConfig(mappings map[string]ActionMappingConfig) error {
+	seen := make(map[struct{ Command, Scope string }]string)
+	dups := make(map[string][]string) // key string -> list of universal action IDs
+	for id, mapping := range mappings {
+		vconf := mapping.VisualStudio
+		if vconf.Command == "" {
+			continue
+		}
+		// Skip configs that are disabled for import (export-only)
+		if vconf.DisableImport {
+			continue
+		}
+		key := struct{ Command, Scope string }{vconf.Command, vconf.EffectiveScope()}
+		if originalID, exists := seen[key]; exists {
+			dupKey := fmt.Sprintf(`{"command":%q,"scope":%q}`, key.Command, key.Scope)
+			if _, ok := dups[dupKey]; !ok {
+				dups[dupKey] = []string{originalID}
+			}
+			dups[dupKey] = append(dups[dupKey], id)
+			continue
+		}
+		seen[key] = id
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	return &DuplicateActionMappingError{Editor: "visualstudio", Duplicates: dups}
+}
@@ -27,17 +27,34 @@ type VscodeConfigs []VscodeMappingConfig
 // GetVSCodeConfigs returns the VSCode configs for the given editor type.
 // Priority: variant-specific config > vscode config (fallback).
 func (am *ActionMappingConfig) GetVSCodeConfigs(editorType pluginapi.EditorType) VscodeConfigs {
+	if configs := am.vscodeVariantConfigs(editorType); len(configs) > 0 {
+		return configs
+	}
+	return am.VSCode // fallback to vscode
+}
+
+// vscodeVariantConfigs returns the variant-specific configs for editorType, or nil for VSCode itself.
+func (am *ActionMappingConfig) vscodeVariantConfigs(editorType pluginapi.EditorType) VscodeConfigs {
 	switch editorType {
 	case pluginapi.EditorTypeWindsurf, pluginapi.EditorTypeWindsurfNext:
-		if len(am.Windsurf) > 0 {
-			return am.Windsurf
-		}
+		return am.Windsurf
 	case pluginapi.EditorTypeCursor:
-		if len(am.Cursor) > 0 {
-			return am.Cursor
-		}
+		return am.Cursor
+	case pluginapi.EditorTypeVSCodeInsiders:
+		return am.VSCodeInsiders
+	case pluginapi.EditorTypeVSCodium:
+		return am.VSCodium
+	case pluginapi.EditorTypeCodeOSS:
+		return am.CodeOSS
+	case pluginapi.EditorTypePositron:
+		return am.Positron
+	case pluginapi.EditorTypeTrae:
+		return am.Trae
+	case pluginapi.EditorTypeKiro:
+		return am.Kiro
+	default:
+		return nil
 	}
-	return am.VSCode // fallback to vscode
 }
 
 // isSupportedVSCodeVariant checks if the action is supported by a VSCode variant editor.
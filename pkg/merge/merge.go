@@ -0,0 +1,405 @@
+package merge
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/xinnjie/onekeymap-cli/pkg/api/importerapi"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+)
+
+// SourceOnekeymap names onekeymap.json as the source of a version; other sources are editor types.
+const SourceOnekeymap = "onekeymap"
+
+// Edits are the changes one source made since the last sync.
+type Edits struct {
+	Source  string
+	Changes *importerapi.KeymapChanges
+	// Unbind are the changes to the keymap-level unbinds, which are not actions.
+	Unbind UnbindChanges
+	// Platform is the platform an editor's keymap was exported for. An editor's change to an entry
+	// is written to the override layer it resolves from for that platform and editor.
+	Platform platform.Platform
+}
+
+// UnbindChanges are the keys added to and removed from a keymap's Unbind list.
+type UnbindChanges struct {
+	Add    []keybinding.Keybinding
+	Remove []keybinding.Keybinding
+}
+
+// HasChanges reports whether any key was added or removed.
+func (u UnbindChanges) HasChanges() bool {
+	return len(u.Add) > 0 || len(u.Remove) > 0
+}
+
+// DiffUnbind returns the keys added to and removed from the keymap-level Unbind list from before
+// to after.
+func DiffUnbind(before, after keymap.Keymap) UnbindChanges {
+	var u UnbindChanges
+	beforeKeys := keySet(before.Unbind)
+	afterKeys := keySet(after.Unbind)
+	for _, kb := range after.Unbind {
+		if !beforeKeys[canonical(kb)] {
+			u.Add = keymap.UnionKeybindings(u.Add, []keybinding.Keybinding{kb})
+		}
+	}
+	for _, kb := range before.Unbind {
+		if !afterKeys[canonical(kb)] {
+			u.Remove = keymap.UnionKeybindings(u.Remove, []keybinding.Keybinding{kb})
+		}
+	}
+	return u
+}
+
+// Version is one source's version of a conflicting entry.
+type Version struct {
+	Source string
+	Action keymap.Action
+	// Removed means the source deleted the entry; Action is the entry as it was before.
+	Removed bool
+}
+
+// Conflict is an entry that several sources changed in different ways since the last sync.
+type Conflict struct {
+	Name     string
+	Scope    keymap.Scope
+	Versions []Version
+}
+
+// Result is the outcome of ThreeWay.
+type Result struct {
+	// Keymap is the merged keymap. Conflicting entries keep their onekeymap.json version until resolved.
+	Keymap keymap.Keymap
+	// Applied lists the editor changes taken into Keymap.
+	Applied *importerapi.KeymapChanges
+	// AppliedUnbind lists the editor changes to the keymap-level unbinds taken into Keymap.
+	AppliedUnbind UnbindChanges
+	// Conflicts is sorted by action ID and scope.
+	Conflicts []Conflict
+}
+
+type entryKey struct {
+	Name  string
+	Scope keymap.Scope
+}
+
+func keyOf(a keymap.Action) entryKey {
+	return entryKey{Name: a.Name, Scope: a.Scope}
+}
+
+// Changes returns the changes from before to after, with one Add, Remove or Update per action ID
+// and scope. Entries that neither bind nor unbind any key count as absent.
+func Changes(before, after keymap.Keymap) *importerapi.KeymapChanges {
+	beforeByKey, beforeOrder := index(before)
+	afterByKey, afterOrder := index(after)
+
+	changes := &importerapi.KeymapChanges{}
+	for _, key := range afterOrder {
+		a := afterByKey[key]
+		b, ok := beforeByKey[key]
+		switch {
+		case !ok:
+			changes.Add = append(changes.Add, a)
+		case !a.SameKeys(b):
+			changes.Update = append(changes.Update, importerapi.KeymapDiff{Before: b, After: a})
+		}
+	}
+	for _, key := range beforeOrder {
+		if _, ok := afterByKey[key]; !ok {
+			changes.Remove = append(changes.Remove, beforeByKey[key])
+		}
+	}
+	return changes
+}
+
+// index returns the entries of km that bind or unbind keys, by action ID and scope, and their keys
+// in order of first appearance. Entries for the same key are combined.
+func index(km keymap.Keymap) (map[entryKey]keymap.Action, []entryKey) {
+	byKey := make(map[entryKey]keymap.Action, len(km.Actions))
+	var order []entryKey
+	for _, a := range km.Actions {
+		if !hasKeys(a) {
+			continue
+		}
+		key := keyOf(a)
+		existing, ok := byKey[key]
+		if !ok {
+			byKey[key] = a
+			order = append(order, key)
+			continue
+		}
+		existing.Bindings = keymap.UnionKeybindings(slices.Clone(existing.Bindings), a.Bindings)
+		existing.Unbind = keymap.UnionKeybindings(slices.Clone(existing.Unbind), a.Unbind)
+		existing.UnbindAll = existing.UnbindAll || a.UnbindAll
+		byKey[key] = existing
+	}
+	return byKey, order
+}
+
+func hasKeys(a keymap.Action) bool {
+	return len(a.Bindings) > 0 || a.HasUnbind()
+}
+
+// proposal is a change one source made to an entry.
+type proposal struct {
+	source string
+	before keymap.Action
+	after  keymap.Action
+	// removed means the source deleted the entry.
+	removed bool
+}
+
+func (p proposal) agrees(other proposal) bool {
+	if p.removed || other.removed {
+		return p.removed == other.removed
+	}
+	return p.after.SameKeys(other.after)
+}
+
+// ThreeWay merges edits made in editors since the last sync into current, the content of
+// onekeymap.json. Edits whose Source is SourceOnekeymap mark the entries changed in onekeymap.json
+// itself. An entry changed by one source, or changed the same way by several, takes that change;
+// editor changes are applied key by key, so bindings an editor cannot express survive. Entries
+// changed differently are reported as conflicts. current is not modified.
+func ThreeWay(current keymap.Keymap, edits []Edits) Result {
+	currentByKey, _ := index(current)
+	proposals := make(map[entryKey][]proposal)
+	var order []entryKey
+	propose := func(key entryKey, p proposal) {
+		if _, ok := proposals[key]; !ok {
+			order = append(order, key)
+		}
+		if p.source == SourceOnekeymap {
+			// Whatever the ancestor, onekeymap.json's version is its current content.
+			a, ok := currentByKey[key]
+			p = proposal{source: SourceOnekeymap, before: p.before, after: a, removed: !ok}
+			for _, existing := range proposals[key] {
+				if existing.source == SourceOnekeymap {
+					return
+				}
+			}
+		}
+		proposals[key] = append(proposals[key], p)
+	}
+	platforms := make(map[string]platform.Platform, len(edits))
+	for _, e := range edits {
+		platforms[e.Source] = e.Platform
+		if e.Changes == nil {
+			continue
+		}
+		for _, a := range e.Changes.Add {
+			propose(keyOf(a), proposal{source: e.Source, after: a})
+		}
+		for _, d := range e.Changes.Update {
+			propose(keyOf(d.After), proposal{source: e.Source, before: d.Before, after: d.After})
+		}
+		for _, a := range e.Changes.Remove {
+			propose(keyOf(a), proposal{source: e.Source, before: a, removed: true})
+		}
+	}
+	sort.Slice(order, func(i, j int) bool {
+		if order[i].Name != order[j].Name {
+			return order[i].Name < order[j].Name
+		}
+		return order[i].Scope < order[j].Scope
+	})
+
+	result := Result{Keymap: current, Applied: &importerapi.KeymapChanges{}}
+	result.Keymap.Actions = slices.Clone(current.Actions)
+	result.Keymap.Unbind, result.AppliedUnbind = mergeUnbind(current.Unbind, edits)
+	for _, key := range order {
+		ps := proposals[key]
+		if !allAgree(ps) {
+			result.Conflicts = append(result.Conflicts, newConflict(key, ps))
+			continue
+		}
+		if slices.ContainsFunc(ps, func(p proposal) bool { return p.source == SourceOnekeymap }) {
+			continue
+		}
+		// Apply the change to the entry the editor sees, in the layer that entry resolves from.
+		source := ps[0].source
+		effective := result.Keymap.Resolve(platforms[source], source)
+		before, hadEntry := entry(effective, key)
+		after := applyProposal(before, ps[0])
+		result.Keymap = result.Keymap.Unresolve(platforms[source], source, setEntry(effective, key, after))
+		switch {
+		case !hadEntry:
+			result.Applied.Add = append(result.Applied.Add, after)
+		case !hasKeys(after):
+			result.Applied.Remove = append(result.Applied.Remove, before)
+		default:
+			result.Applied.Update = append(result.Applied.Update, importerapi.KeymapDiff{Before: before, After: after})
+		}
+	}
+	return result
+}
+
+// mergeUnbind applies the editors' changes to the keymap-level unbinds. They are sets of keys, so
+// changes never conflict; a key one editor removes and another adds stays unbound.
+func mergeUnbind(current []keybinding.Keybinding, edits []Edits) ([]keybinding.Keybinding, UnbindChanges) {
+	var applied UnbindChanges
+	removed := make(map[string]bool)
+	for _, e := range edits {
+		if e.Source == SourceOnekeymap {
+			continue
+		}
+		for _, kb := range e.Unbind.Remove {
+			removed[canonical(kb)] = true
+		}
+	}
+	for _, e := range edits {
+		if e.Source == SourceOnekeymap {
+			continue
+		}
+		for _, kb := range e.Unbind.Add {
+			delete(removed, canonical(kb))
+		}
+	}
+
+	currentKeys := keySet(current)
+	out := make([]keybinding.Keybinding, 0, len(current))
+	for _, kb := range current {
+		if removed[canonical(kb)] {
+			applied.Remove = append(applied.Remove, kb)
+			continue
+		}
+		out = append(out, kb)
+	}
+	for _, e := range edits {
+		if e.Source == SourceOnekeymap {
+			continue
+		}
+		for _, kb := range e.Unbind.Add {
+			if !currentKeys[canonical(kb)] && !keySet(applied.Add)[canonical(kb)] {
+				applied.Add = append(applied.Add, kb)
+			}
+		}
+	}
+	if !applied.HasChanges() {
+		return current, applied
+	}
+	return append(out, applied.Add...), applied
+}
+
+func keySet(kbs []keybinding.Keybinding) map[string]bool {
+	set := make(map[string]bool, len(kbs))
+	for _, kb := range kbs {
+		set[canonical(kb)] = true
+	}
+	return set
+}
+
+// Resolve returns the merged keymap with each conflict set to a version: choices[i] indexes the
+// Versions of Conflicts[i].
+func (r Result) Resolve(choices []int) keymap.Keymap {
+	km := r.Keymap
+	for i, c := range r.Conflicts {
+		if i >= len(choices) || choices[i] < 0 || choices[i] >= len(c.Versions) {
+			continue
+		}
+		v := c.Versions[choices[i]]
+		key := entryKey{Name: c.Name, Scope: c.Scope}
+		if v.Removed {
+			km = setEntry(km, key, keymap.Action{Name: c.Name, Scope: c.Scope})
+			continue
+		}
+		current, _ := entry(km, key)
+		a := v.Action
+		a.Metadata = current.Metadata
+		km = setEntry(km, key, a)
+	}
+	return km
+}
+
+func allAgree(ps []proposal) bool {
+	for _, p := range ps[1:] {
+		if !p.agrees(ps[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+func newConflict(key entryKey, ps []proposal) Conflict {
+	c := Conflict{Name: key.Name, Scope: key.Scope}
+	for _, p := range ps {
+		v := Version{Source: p.source, Action: p.after, Removed: p.removed}
+		if p.removed {
+			v.Action = p.before
+		}
+		c.Versions = append(c.Versions, v)
+	}
+	return c
+}
+
+// applyProposal applies an editor's change to the current entry key by key: keys the editor
+// removed are removed, keys it added are added, and other keys of the entry are kept.
+func applyProposal(current keymap.Action, p proposal) keymap.Action {
+	out := keymap.Action{
+		Name:      p.after.Name,
+		Scope:     p.after.Scope,
+		Metadata:  current.Metadata,
+		UnbindAll: p.after.UnbindAll,
+	}
+	if p.removed {
+		out.Name, out.Scope, out.UnbindAll = p.before.Name, p.before.Scope, false
+	}
+	out.Bindings = applyKeys(current.Bindings, p.before.Bindings, p.after.Bindings)
+	out.Unbind = applyKeys(current.Unbind, p.before.Unbind, p.after.Unbind)
+	return out
+}
+
+func applyKeys(current, before, after []keybinding.Keybinding) []keybinding.Keybinding {
+	removed := make(map[string]bool)
+	for _, kb := range before {
+		removed[canonical(kb)] = true
+	}
+	for _, kb := range after {
+		delete(removed, canonical(kb))
+	}
+	var out []keybinding.Keybinding
+	for _, kb := range current {
+		if !removed[canonical(kb)] {
+			out = append(out, kb)
+		}
+	}
+	return keymap.UnionKeybindings(out, after)
+}
+
+func canonical(kb keybinding.Keybinding) string {
+	return kb.String(keybinding.FormatOption{Platform: platform.PlatformMacOS, Separator: "+"})
+}
+
+// entry returns the combined entry for key, and whether km has one that binds or unbinds keys.
+func entry(km keymap.Keymap, key entryKey) (keymap.Action, bool) {
+	byKey, _ := index(km)
+	a, ok := byKey[key]
+	if !ok {
+		return keymap.Action{Name: key.Name, Scope: key.Scope}, false
+	}
+	return a, true
+}
+
+// setEntry replaces the entries for key with a, or drops them when a has no keys. km is not modified.
+func setEntry(km keymap.Keymap, key entryKey, a keymap.Action) keymap.Keymap {
+	actions := make([]keymap.Action, 0, len(km.Actions)+1)
+	placed := false
+	for _, existing := range km.Actions {
+		if keyOf(existing) != key {
+			actions = append(actions, existing)
+			continue
+		}
+		if !placed && hasKeys(a) {
+			actions = append(actions, a)
+		}
+		placed = true
+	}
+	if !placed && hasKeys(a) {
+		actions = append(actions, a)
+	}
+	km.Actions = actions
+	return km
+}
@@ -0,0 +1,196 @@
+package merge_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/keymap/keybinding"
+	"github.com/xinnjie/onekeymap-cli/pkg/api/platform"
+	"github.com/xinnjie/onekeymap-cli/pkg/merge"
+)
+
+func newAction(name string, keys ...string) keymap.Action {
+	a := keymap.Action{Name: name}
+	for _, k := range keys {
+		kb, err := keybinding.NewKeybinding(k, keybinding.ParseOption{Separator: "+"})
+		if err != nil {
+			panic(err)
+		}
+		a.Bindings = append(a.Bindings, kb)
+	}
+	return a
+}
+
+func keysOf(km keymap.Keymap) map[string][]string {
+	out := make(map[string][]string)
+	for _, a := range km.Actions {
+		for _, kb := range a.Bindings {
+			out[a.Name] = append(out[a.Name], kb.String(keybinding.FormatOption{
+				Platform:  platform.PlatformMacOS,
+				Separator: "+",
+			}))
+		}
+	}
+	return out
+}
+
+func TestChanges(t *testing.T) {
+	before := keymap.Keymap{Actions: []keymap.Action{
+		newAction("copy", "cmd+c"),
+		newAction("paste", "cmd+v"),
+		newAction("cut", "cmd+x"),
+		{Name: "empty"},
+	}}
+	after := keymap.Keymap{Actions: []keymap.Action{
+		newAction("copy", "cmd+c"),
+		newAction("paste", "cmd+shift+v"),
+		newAction("save", "cmd+s"),
+	}}
+
+	changes := merge.Changes(before, after)
+	require.Len(t, changes.Add, 1)
+	assert.Equal(t, "save", changes.Add[0].Name)
+	require.Len(t, changes.Update, 1)
+	assert.Equal(t, "paste", changes.Update[0].After.Name)
+	require.Len(t, changes.Remove, 1)
+	assert.Equal(t, "cut", changes.Remove[0].Name)
+}
+
+func TestThreeWay(t *testing.T) {
+	snapshot := keymap.Keymap{Actions: []keymap.Action{
+		newAction("copy", "cmd+c"),
+		newAction("paste", "cmd+v"),
+		newAction("cut", "cmd+x"),
+		newAction("save", "cmd+s"),
+	}}
+
+	t.Run("edits in different entries are all kept", func(t *testing.T) {
+		current := keymap.Keymap{Actions: []keymap.Action{
+			newAction("copy", "cmd+c"),
+			newAction("paste", "cmd+v"),
+			newAction("cut", "cmd+x", "shift+delete"),
+			newAction("save", "cmd+s"),
+		}}
+		zed := keymap.Keymap{Actions: []keymap.Action{
+			newAction("copy", "cmd+c"),
+			newAction("paste", "cmd+shift+v"),
+			newAction("cut", "cmd+x"),
+			newAction("save", "cmd+s"),
+		}}
+		vscode := keymap.Keymap{Actions: []keymap.Action{
+			newAction("copy", "cmd+c"),
+			newAction("paste", "cmd+v"),
+			newAction("cut", "cmd+x"),
+		}}
+
+		result := merge.ThreeWay(current, []merge.Edits{
+			{Source: merge.SourceOnekeymap, Changes: merge.Changes(snapshot, current)},
+			{Source: "zed", Changes: merge.Changes(snapshot, zed)},
+			{Source: "vscode", Changes: merge.Changes(snapshot, vscode)},
+		})
+		assert.Empty(t, result.Conflicts)
+		assert.Equal(t, map[string][]string{
+			"copy":  {"cmd+c"},
+			"paste": {"cmd+shift+v"},
+			"cut":   {"cmd+x", "shift+delete"},
+		}, keysOf(result.Keymap))
+		assert.Len(t, result.Applied.Update, 1)
+		assert.Len(t, result.Applied.Remove, 1)
+
+		// current is not modified.
+		assert.Len(t, current.Actions, 4)
+	})
+
+	t.Run("editor change keeps keys the editor cannot express", func(t *testing.T) {
+		current := keymap.Keymap{Actions: []keymap.Action{newAction("copy", "cmd+c", "cmd+k cmd+c")}}
+		editorSnapshot := keymap.Keymap{Actions: []keymap.Action{newAction("copy", "cmd+c")}}
+		editor := keymap.Keymap{Actions: []keymap.Action{newAction("copy", "ctrl+c")}}
+
+		result := merge.ThreeWay(current, []merge.Edits{
+			{Source: "helix", Changes: merge.Changes(editorSnapshot, editor)},
+		})
+		assert.Empty(t, result.Conflicts)
+		assert.Equal(t, map[string][]string{"copy": {"cmd+k cmd+c", "ctrl+c"}}, keysOf(result.Keymap))
+	})
+
+	t.Run("same change on both sides is not a conflict", func(t *testing.T) {
+		current := keymap.Keymap{Actions: []keymap.Action{newAction("copy", "ctrl+c")}}
+		editor := keymap.Keymap{Actions: []keymap.Action{newAction("copy", "ctrl+c")}}
+		base := keymap.Keymap{Actions: []keymap.Action{newAction("copy", "cmd+c")}}
+
+		result := merge.ThreeWay(current, []merge.Edits{
+			{Source: merge.SourceOnekeymap, Changes: merge.Changes(base, current)},
+			{Source: "zed", Changes: merge.Changes(base, editor)},
+		})
+		assert.Empty(t, result.Conflicts)
+		assert.Empty(t, result.Applied.Update)
+	})
+
+	t.Run("different changes conflict until resolved", func(t *testing.T) {
+		current := keymap.Keymap{Actions: []keymap.Action{newAction("paste", "cmd+alt+v")}}
+		current.Actions[0].Metadata.Comment = "mine"
+		base := keymap.Keymap{Actions: []keymap.Action{newAction("paste", "cmd+v")}}
+		zed := keymap.Keymap{Actions: []keymap.Action{newAction("paste", "cmd+shift+v")}}
+		vscode := keymap.Keymap{}
+
+		result := merge.ThreeWay(current, []merge.Edits{
+			{Source: merge.SourceOnekeymap, Changes: merge.Changes(base, current)},
+			{Source: "zed", Changes: merge.Changes(base, zed)},
+			{Source: "vscode", Changes: merge.Changes(base, vscode)},
+		})
+		require.Len(t, result.Conflicts, 1)
+		c := result.Conflicts[0]
+		assert.Equal(t, "paste", c.Name)
+		require.Len(t, c.Versions, 3)
+		assert.Equal(t, merge.SourceOnekeymap, c.Versions[0].Source)
+		assert.Equal(t, "zed", c.Versions[1].Source)
+		assert.True(t, c.Versions[2].Removed)
+
+		// Unresolved, the onekeymap.json version is kept.
+		assert.Equal(t, map[string][]string{"paste": {"cmd+alt+v"}}, keysOf(result.Keymap))
+
+		resolved := result.Resolve([]int{1})
+		assert.Equal(t, map[string][]string{"paste": {"cmd+shift+v"}}, keysOf(resolved))
+		assert.Equal(t, "mine", resolved.Actions[0].Metadata.Comment)
+
+		assert.Empty(t, result.Resolve([]int{2}).Actions)
+	})
+}
+
+func TestThreeWay_Unbind(t *testing.T) {
+	unbind := func(keys ...string) []keybinding.Keybinding {
+		return newAction("", keys...).Bindings
+	}
+	current := keymap.Keymap{
+		Actions: []keymap.Action{newAction("copy", "cmd+c")},
+		Unbind:  unbind("cmd+k", "cmd+j"),
+	}
+	snapshot := keymap.Keymap{Unbind: unbind("cmd+k", "cmd+j")}
+	// The editor unbinds ctrl+space, e.g. with a Helix no_op, and drops its unbind of cmd+j.
+	edited := keymap.Keymap{Unbind: unbind("cmd+k", "ctrl+space")}
+
+	edits := []merge.Edits{{
+		Source:  "helix",
+		Changes: merge.Changes(snapshot, edited),
+		Unbind:  merge.DiffUnbind(snapshot, edited),
+	}}
+	result := merge.ThreeWay(current, edits)
+
+	require.Empty(t, result.Conflicts)
+	assert.True(t, result.AppliedUnbind.HasChanges())
+	assert.Empty(t, merge.DiffUnbind(edited, result.Keymap).Add)
+	assert.Empty(t, merge.DiffUnbind(edited, result.Keymap).Remove)
+	assert.Len(t, result.Keymap.Unbind, 2)
+	assert.Len(t, current.Unbind, 2, "current is not modified")
+	assert.Equal(t, "cmd+j", result.AppliedUnbind.Remove[0].String(keybinding.FormatOption{
+		Platform:  platform.PlatformMacOS,
+		Separator: "+",
+	}))
+
+	// Without unbind changes, the keymap-level unbinds are kept as they are.
+	result = merge.ThreeWay(current, []merge.Edits{{Source: "helix", Changes: merge.Changes(snapshot, snapshot)}})
+	assert.False(t, result.AppliedUnbind.HasChanges())
+	assert.Equal(t, current.Unbind, result.Keymap.Unbind)
+}
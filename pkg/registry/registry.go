@@ -4,8 +4,16 @@ import (
 	"log/slog"
 
 	"github.com/xinnjie/onekeymap-cli/internal/plugins/basekeymap"
+	"github.com/xinnjie/onekeymap-cli/internal/plugins/eclipse"
+	"github.com/xinnjie/onekeymap-cli/internal/plugins/emacs"
 	"github.com/xinnjie/onekeymap-cli/internal/plugins/helix"
 	"github.com/xinnjie/onekeymap-cli/internal/plugins/intellij"
+	"github.com/xinnjie/onekeymap-cli/internal/plugins/kakoune"
+	"github.com/xinnjie/onekeymap-cli/internal/plugins/lapce"
+	"github.com/xinnjie/onekeymap-cli/internal/plugins/pulsar"
+	"github.com/xinnjie/onekeymap-cli/internal/plugins/sublime"
+	"github.com/xinnjie/onekeymap-cli/internal/plugins/vim"
+	"github.com/xinnjie/onekeymap-cli/internal/plugins/visualstudio"
 	"github.com/xinnjie/onekeymap-cli/internal/plugins/vscode"
 	"github.com/xinnjie/onekeymap-cli/internal/plugins/xcode"
 	"github.com/xinnjie/onekeymap-cli/internal/plugins/zed"
@@ -38,6 +46,12 @@ func NewRegistryWithPlugins(
 	r.Register(vscode.NewWindsurf(mappingConfig, logger, recorder))
 	r.Register(vscode.NewWindsurfNext(mappingConfig, logger, recorder))
 	r.Register(vscode.NewCursor(mappingConfig, logger, recorder))
+	r.Register(vscode.NewVSCodeInsiders(mappingConfig, logger, recorder))
+	r.Register(vscode.NewVSCodium(mappingConfig, logger, recorder))
+	r.Register(vscode.NewCodeOSS(mappingConfig, logger, recorder))
+	r.Register(vscode.NewPositron(mappingConfig, logger, recorder))
+	r.Register(vscode.NewTrae(mappingConfig, logger, recorder))
+	r.Register(vscode.NewKiro(mappingConfig, logger, recorder))
 
 	// IntelliJ family
 	r.Register(intellij.New(mappingConfig, logger, recorder))
@@ -49,10 +63,25 @@ func NewRegistryWithPlugins(
 	r.Register(intellij.NewRubyMine(mappingConfig, logger, recorder))
 	r.Register(intellij.NewGoLand(mappingConfig, logger, recorder))
 	r.Register(intellij.NewRustRover(mappingConfig, logger, recorder))
+	r.Register(intellij.NewAndroidStudio(mappingConfig, logger, recorder))
+	r.Register(intellij.NewDataGrip(mappingConfig, logger, recorder))
+	r.Register(intellij.NewRider(mappingConfig, logger, recorder))
+	r.Register(intellij.NewDataSpell(mappingConfig, logger, recorder))
+	r.Register(intellij.NewAqua(mappingConfig, logger, recorder))
+	r.Register(intellij.NewWriterside(mappingConfig, logger, recorder))
 
-	r.Register(helix.New(mappingConfig, logger))
+	r.Register(vim.New(mappingConfig, logger, recorder))
+	r.Register(vim.NewNeovim(mappingConfig, logger, recorder))
+	r.Register(helix.New(mappingConfig, logger, recorder))
 	r.Register(zed.New(mappingConfig, logger, recorder))
 	r.Register(xcode.New(mappingConfig, logger, recorder))
+	r.Register(sublime.New(mappingConfig, logger, recorder))
+	r.Register(emacs.New(mappingConfig, logger, recorder))
+	r.Register(eclipse.New(mappingConfig, logger, recorder))
+	r.Register(visualstudio.New(mappingConfig, logger, recorder))
+	r.Register(lapce.New(mappingConfig, logger, recorder))
+	r.Register(kakoune.New(mappingConfig, logger, recorder))
+	r.Register(pulsar.New(mappingConfig, logger, recorder))
 
 	r.Register(basekeymap.New())
 	return r
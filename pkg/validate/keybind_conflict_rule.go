@@ -32,10 +32,14 @@ func (r *KeybindConflictRule) Validate(_ context.Context, validationContext *val
 		mappingConfig = nil
 	}
 
-	// Group keybindings by their formatted key combination
+	// Group keybindings by their formatted key combination. Bindings in different scopes do not conflict.
+	type scopedKeybinding struct {
+		Keybinding string
+		Scope      keymap.Scope
+	}
 	keybindingMap := make(
-		map[string][]keymap.Action,
-	) // key: formatted keybinding, value: list of actions having it
+		map[scopedKeybinding][]keymap.Action,
+	) // key: formatted keybinding and scope, value: list of actions having it
 
 	for _, action := range validationContext.Setting.Actions {
 		for _, b := range action.Bindings {
@@ -44,12 +48,14 @@ func (r *KeybindConflictRule) Validate(_ context.Context, validationContext *val
 				Platform:  platform.PlatformMacOS,
 				Separator: "+",
 			})
-			keybindingMap[formatted] = append(keybindingMap[formatted], action)
+			key := scopedKeybinding{Keybinding: formatted, Scope: action.Scope}
+			keybindingMap[key] = append(keybindingMap[key], action)
 		}
 	}
 
 	// Check for conflicts (multiple actions for same keybinding)
-	for keybindingStr, actions := range keybindingMap {
+	for key, actions := range keybindingMap {
+		keybindingStr := key.Keybinding
 		if len(actions) > 1 {
 			// Create action objects with editor commands
 			var conflictActions []validateapi.ConflictAction
@@ -103,8 +109,30 @@ func getEditorCommand(mapping *mappings.ActionMappingConfig, sourceEditor string
 		if len(mapping.Helix) > 0 {
 			return mapping.Helix[0].Command
 		}
-	case "vim":
+	case "vim", "vim.neovim":
 		return mapping.Vim.Command
+	case "emacs":
+		return mapping.Emacs.Command
+	case "eclipse":
+		return mapping.Eclipse.CommandID
+	case "visualstudio":
+		return mapping.VisualStudio.Command
+	case "lapce":
+		if len(mapping.Lapce) > 0 {
+			return mapping.Lapce[0].Command
+		}
+	case "kakoune":
+		if len(mapping.Kakoune) > 0 {
+			return mapping.Kakoune[0].Command
+		}
+	case "pulsar":
+		if len(mapping.Pulsar) > 0 {
+			return mapping.Pulsar[0].Command
+		}
+	case "sublime":
+		if len(mapping.Sublime) > 0 {
+			return mapping.Sublime[0].Command
+		}
 	}
 	return ""
 }
@@ -36,3 +36,42 @@ func TestValidator_Validate_WithKeybindConflict(t *testing.T) {
 	require.True(t, ok)
 	assert.Len(t, conflict.Actions, 2)
 }
+
+func TestValidator_Validate_KeybindInDifferentScopesDoesNotConflict(t *testing.T) {
+	validator := validateapi.NewValidator(validate.NewKeybindConflictRule())
+
+	scoped := newAction("action2", "ctrl+c")
+	scoped.Scope = "terminalFocus"
+	setting := keymap.Keymap{
+		Actions: []keymap.Action{
+			newAction("action1", "ctrl+c"),
+			scoped,
+		},
+	}
+
+	report, err := validator.Validate(context.Background(), setting, "vscode")
+	require.NoError(t, err)
+	assert.Empty(t, report.Issues)
+}
+
+func TestValidator_Validate_KeybindConflictNamesNeovimCommands(t *testing.T) {
+	validator := validateapi.NewValidator(validate.NewKeybindConflictRule())
+
+	setting := keymap.Keymap{
+		Actions: []keymap.Action{
+			newAction("actions.file.save", "ctrl+s"),
+			newAction("actions.file.saveAll", "ctrl+s"),
+		},
+	}
+
+	report, err := validator.Validate(context.Background(), setting, "vim.neovim")
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 1)
+	conflict, ok := report.Issues[0].Details.(validateapi.KeybindConflict)
+	require.True(t, ok)
+	var commands []string
+	for _, a := range conflict.Actions {
+		commands = append(commands, a.Context)
+	}
+	assert.ElementsMatch(t, []string{":w<CR>", ":wa<CR>"}, commands)
+}
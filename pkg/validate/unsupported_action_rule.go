@@ -51,6 +51,22 @@ func (r *UnsupportedActionRule) Validate(_ context.Context, validationContext *v
 			hasTargetMapping = actionMapping.Helix != nil
 		case pluginapi.EditorTypeIntelliJ:
 			hasTargetMapping = actionMapping.IntelliJ.Action != ""
+		case pluginapi.EditorTypeVim, pluginapi.EditorTypeNeovim:
+			hasTargetMapping = actionMapping.Vim.Command != ""
+		case pluginapi.EditorTypeSublime:
+			hasTargetMapping = actionMapping.Sublime != nil
+		case pluginapi.EditorTypeEmacs:
+			hasTargetMapping = actionMapping.Emacs.Command != ""
+		case pluginapi.EditorTypeEclipse:
+			hasTargetMapping = actionMapping.Eclipse.CommandID != ""
+		case pluginapi.EditorTypeVisualStudio:
+			hasTargetMapping = actionMapping.VisualStudio.Command != ""
+		case pluginapi.EditorTypeLapce:
+			hasTargetMapping = actionMapping.Lapce != nil
+		case pluginapi.EditorTypeKakoune:
+			hasTargetMapping = actionMapping.Kakoune != nil
+		case pluginapi.EditorTypePulsar:
+			hasTargetMapping = actionMapping.Pulsar != nil
 		default:
 			// Unknown editor type, skip
 			continue